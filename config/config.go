@@ -22,6 +22,8 @@ type Config struct {
     Queue       QueueConfig       `koanf:"queue"`
     Vault       VaultConfig       `koanf:"vault"`
     Credentials CredentialsConfig `koanf:"credentials"`
+    Admin       AdminConfig       `koanf:"admin"`
+    Inbox       InboxConfig       `koanf:"inbox"`
     Env         string            `koanf:"env"`
 }
 
@@ -66,6 +68,15 @@ type CredentialsConfig struct {
     DefaultToDatabase  bool   `koanf:"default_to_database"` // Default to database if vault fails
 }
 
+type AdminConfig struct {
+    APIKeyEnv string `koanf:"api_key_env"` // Environment variable holding the admin API key; admin routes are disabled without it
+}
+
+type InboxConfig struct {
+    TokenSecretEnv string        `koanf:"token_secret_env"` // Environment variable holding the key inbox tokens are signed with; tokens are disabled without it
+    TokenTTL       time.Duration `koanf:"token_ttl"`        // How long an issued inbox token is valid
+}
+
 var k = koanf.New(".")
 
 func LoadConfig() (*Config, error) {
@@ -173,6 +184,13 @@ func loadDefaults() error {
         "credentials.allow_custom_keys":   true,
         "credentials.default_to_database": true,
         
+        // Admin defaults
+        "admin.api_key_env":               "NOTI_ADMIN_API_KEY",
+        
+        // Inbox defaults
+        "inbox.token_secret_env":          "NOTI_INBOX_TOKEN_SECRET",
+        "inbox.token_ttl":                 "1h",
+        
         // Environment
         "env":                            "development",
     }
@@ -286,4 +304,19 @@ func (c *Config) GetQueueURL() string {
 
 func (c *Config) GetEncryptionKey() string {
     return os.Getenv(c.Credentials.EncryptionKeyEnv)
-}
\ No newline at end of file
+}
+
+// GetAdminAPIKey returns the operator key for admin routes, or "" when none is set
+func (c *Config) GetAdminAPIKey() string {
+    if c.Admin.APIKeyEnv == "" {
+        return ""
+    }
+    return os.Getenv(c.Admin.APIKeyEnv)
+}
+// GetInboxTokenSecret returns the key inbox tokens are signed with, or "" when none is set
+func (c *Config) GetInboxTokenSecret() string {
+    if c.Inbox.TokenSecretEnv == "" {
+        return ""
+    }
+    return os.Getenv(c.Inbox.TokenSecretEnv)
+}
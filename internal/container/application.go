@@ -7,6 +7,9 @@ import (
 
 	notificationHandlers "getnoti.com/internal/notifications/events/handlers"
 	notificationServices "getnoti.com/internal/notifications/services"
+	sendbatch "getnoti.com/internal/notifications/usecases/send_batch"
+	senddigest "getnoti.com/internal/notifications/usecases/send_digest"
+	sendnotification "getnoti.com/internal/notifications/usecases/send_notification"
 	providerServices "getnoti.com/internal/providers/services"
 	sharedEvents "getnoti.com/internal/shared/events"
 	templateServices "getnoti.com/internal/templates/services"
@@ -26,14 +29,14 @@ import (
 // initializeServices sets up all application services
 func (c *ServiceContainer) initializeServices() error {
 	c.logger.Info("Initializing application services")
-		// Initialize hybrid event bus (leverages existing infrastructure)
+	// Initialize hybrid event bus (leverages existing infrastructure)
 	c.eventBus = sharedEvents.NewHybridEventBus(
 		c.dbManager,
 		c.workerPoolManager,
 		c.logger,
 	)
 	c.logger.Info("Hybrid event bus initialized successfully")
-		// Initialize tenant service
+	// Initialize tenant service
 	c.tenantService = tenantServices.NewTenantService(
 		c.tenantRepo,
 		c.userRepo,
@@ -41,34 +44,53 @@ func (c *ServiceContainer) initializeServices() error {
 		c.configResolver,
 		c.logger,
 	)
-	c.logger.Info("Tenant service initialized successfully")	// Initialize user preference service
+	c.logger.Info("Tenant service initialized successfully")
+	// Initialize API key service
+	c.apiKeyService = tenantServices.NewAPIKeyService(
+		c.apiKeyRepo,
+		c.logger,
+	)
+	c.logger.Info("API key service initialized successfully") // Initialize user preference service
 	c.userPreferenceService = tenantServices.NewUserPreferenceService(
 		c.dbManager,
 		c.logger,
 		c.repositoryFactory,
 	)
 	c.logger.Info("User preference service initialized successfully")
-		// Initialize notification service with event bus
+	c.digestService = notificationServices.NewDigestService(
+		c.userPreferenceService,
+		c.logger,
+		c.repositoryFactory,
+	)
+	c.logger.Info("Digest service initialized successfully")
+	c.frequencyCapService = notificationServices.NewFrequencyCapService(
+		c.logger,
+		c.repositoryFactory,
+	)
+	c.logger.Info("Frequency cap service initialized successfully")
+	// Initialize notification service with event bus
 	c.notificationService = notificationServices.NewNotificationService(
 		c.notificationRepo,
 		c.tenantService,
 		c.dbManager,
 		c.queueManager,
 		c.workerPoolManager,
+		c.frequencyCapService,
 		c.logger,
+		c.repositoryFactory,
 	)
 	c.logger.Info("Notification service initialized successfully")
-		// Initialize template service
+	// Initialize template service
 	c.templateService = templateServices.NewTemplateService(
-		c.templateRepo,
 		c.tenantService,
 		c.logger,
+		c.repositoryFactory,
 	)
 	c.logger.Info("Template service initialized successfully")
-	
+
 	// Initialize provider service with event bus
 	var notificationQueue queue.Queue
-	
+
 	// Try to get a notification queue but don't fail if not configured
 	if c.config.Queue.URL != "" {
 		var err error
@@ -80,7 +102,7 @@ func (c *ServiceContainer) initializeServices() error {
 	} else {
 		c.logger.Warn("No queue URL configured, notifications will be processed synchronously")
 	}
-		c.providerService = providerServices.NewProviderService(
+	c.providerService = providerServices.NewProviderService(
 		c.providerRepo,
 		c.tenantService,
 		c.credentialManager,
@@ -88,10 +110,10 @@ func (c *ServiceContainer) initializeServices() error {
 		c.providerFactory,
 		notificationQueue,
 		c.workerPoolManager,
-		c.userPreferenceService,
+		c.notificationService,
 		c.logger,
 	)
-	c.logger.Info("Provider service initialized successfully")	// Initialize webhook service with event bus
+	c.logger.Info("Provider service initialized successfully") // Initialize webhook service with event bus
 	webhookSecurityManager := webhook.NewSecurityManager()
 	c.webhookService = webhookServices.NewWebhookService(
 		c.dbManager,
@@ -102,14 +124,9 @@ func (c *ServiceContainer) initializeServices() error {
 		c.repositoryFactory,
 	)
 	c.logger.Info("Webhook service initialized successfully")
+	// Tenants prune device tokens that queued sends found unregistered through their webhooks
+	c.notificationService.SetInvalidTokenHandler(notificationServices.NewWebhookTokenPruner(c.webhookService))
 
-	// Initialize workflow service
-	c.workflowService = workflowServices.NewWorkflowService(
-		c.workflowRepo,
-		c.executionRepo,
-		c.logger,
-	)
-	c.logger.Info("Workflow service initialized successfully")
 	// Initialize workflow engine with event bus
 	workflowWorkerPool := c.workerPoolManager.GetOrCreatePool(workerpool.WorkerPoolConfig{
 		Name:           "workflow_engine",
@@ -128,17 +145,119 @@ func (c *ServiceContainer) initializeServices() error {
 		c.logger,
 		c.eventBus,
 		c.notificationService,
-		30 * time.Second, // Set poll interval to 30 seconds
+		c.digestService,
+		30*time.Second, // Set poll interval to 30 seconds
 	)
-	
+
 	c.logger.Info("Workflow engine initialized successfully")
-	
+
+	// Initialize workflow and execution services
+	c.workflowService = workflowServices.NewWorkflowService(
+		c.workflowRepo,
+		c.executionRepo,
+		c.workflowEngine,
+		c.logger,
+	)
+	c.executionService = workflowServices.NewExecutionService(
+		c.executionRepo,
+		c.logger,
+	)
+	c.logger.Info("Workflow service initialized successfully")
+
 	// Start the workflow engine
 	if err := c.workflowEngine.Start(context.Background()); err != nil {
 		return fmt.Errorf("failed to start workflow engine: %w", err)
 	}
 	c.logger.Info("Workflow engine started")
-	
+
+	// Initialize the scheduled notification worker; schedules are stored per tenant
+	// so pending sends resume after a restart
+	schedulerWorkerPool := c.workerPoolManager.GetOrCreatePool(workerpool.WorkerPoolConfig{
+		Name:           "notification_scheduler",
+		InitialWorkers: 5,
+		MaxJobs:        500,
+		MinWorkers:     2,
+		MaxWorkers:     20,
+		ScaleFactor:    1.5,
+		IdleTimeout:    5 * time.Minute,
+		ScaleInterval:  30 * time.Second,
+	})
+	c.scheduledNotificationWorker = sendnotification.NewScheduledNotificationWorker(
+		c.tenantRepo,
+		c.repositoryFactory,
+		c.providerService,
+		c.templateService,
+		c.cache,
+		c.userPreferenceService,
+		c.frequencyCapService,
+		schedulerWorkerPool,
+		c.logger,
+		30*time.Second,
+	)
+	if err := c.scheduledNotificationWorker.Start(context.Background()); err != nil {
+		return fmt.Errorf("failed to start scheduled notification worker: %w", err)
+	}
+	c.logger.Info("Scheduled notification worker started")
+
+	// Initialize the digest worker; open digests are stored per tenant and sent
+	// when their window ends
+	digestWorkerPool := c.workerPoolManager.GetOrCreatePool(workerpool.WorkerPoolConfig{
+		Name:           "notification_digests",
+		InitialWorkers: 2,
+		MaxJobs:        500,
+		MinWorkers:     1,
+		MaxWorkers:     10,
+		ScaleFactor:    1.5,
+		IdleTimeout:    5 * time.Minute,
+		ScaleInterval:  30 * time.Second,
+	})
+	c.digestWorker = senddigest.NewDigestWorker(
+		c.tenantRepo,
+		c.repositoryFactory,
+		c.providerService,
+		c.templateService,
+		c.cache,
+		c.userPreferenceService,
+		c.frequencyCapService,
+		digestWorkerPool,
+		c.logger,
+		time.Minute,
+	)
+	if err := c.digestWorker.Start(context.Background()); err != nil {
+		return fmt.Errorf("failed to start digest worker: %w", err)
+	}
+	c.logger.Info("Digest worker started")
+
+	// Initialize the batch worker; batch recipients are stored per tenant and
+	// claimed while they are sent, so batches carry on after a restart
+	batchWorkerPool := c.workerPoolManager.GetOrCreatePool(workerpool.WorkerPoolConfig{
+		Name:           "notification_batches",
+		InitialWorkers: 5,
+		MaxJobs:        1000,
+		MinWorkers:     2,
+		MaxWorkers:     20,
+		ScaleFactor:    1.5,
+		IdleTimeout:    5 * time.Minute,
+		ScaleInterval:  30 * time.Second,
+	})
+	c.batchWorker = sendbatch.NewBatchWorker(
+		c.tenantRepo,
+		c.repositoryFactory,
+		c.providerService,
+		c.templateService,
+		c.cache,
+		c.userPreferenceService,
+		c.digestService,
+		c.frequencyCapService,
+		batchWorkerPool,
+		c.logger,
+		5*time.Second,
+	)
+	if err := c.batchWorker.Start(context.Background()); err != nil {
+		return fmt.Errorf("failed to start batch worker: %w", err)
+	}
+	c.logger.Info("Batch worker started")
+
 	// Start the event bus
 	if err := c.eventBus.Start(context.Background()); err != nil {
 		return fmt.Errorf("failed to start event bus: %w", err)
@@ -163,17 +282,17 @@ func (c *ServiceContainer) registerEventHandlers() error {
 		if err := c.eventBus.Subscribe(eventType, handler); err != nil {
 			return fmt.Errorf("failed to register notification handler for %s: %w", eventType, err)
 		}
-		c.logger.Info("Registered notification event handler", 
+		c.logger.Info("Registered notification event handler",
 			logger.Field{Key: "event_type", Value: eventType})
 	}
 
-	// Create tenant event handlers  
+	// Create tenant event handlers
 	tenantHandlerInstance := tenantHandlers.NewTenantEventHandlers(c.logger)
 	for eventType, handler := range tenantHandlerInstance.GetHandlerMethods() {
 		if err := c.eventBus.Subscribe(eventType, handler); err != nil {
 			return fmt.Errorf("failed to register tenant handler for %s: %w", eventType, err)
 		}
-		c.logger.Info("Registered tenant event handler", 
+		c.logger.Info("Registered tenant event handler",
 			logger.Field{Key: "event_type", Value: eventType})
 	}
 
@@ -183,16 +302,16 @@ func (c *ServiceContainer) registerEventHandlers() error {
 		if err := c.eventBus.Subscribe(eventType, handler); err != nil {
 			return fmt.Errorf("failed to register webhook handler for %s: %w", eventType, err)
 		}
-		c.logger.Info("Registered webhook event handler", 
+		c.logger.Info("Registered webhook event handler",
 			logger.Field{Key: "event_type", Value: eventType})
 	}
-		// Create workflow event handlers
+	// Create workflow event handlers
 	workflowHandlerInstance := workflowHandlers.NewWorkflowEventHandlers(c.logger)
 	for eventType, handler := range workflowHandlerInstance.GetHandlerMethods() {
 		if err := c.eventBus.Subscribe(eventType, handler); err != nil {
 			return fmt.Errorf("failed to register workflow handler for %s: %w", eventType, err)
 		}
-		c.logger.Info("Registered workflow event handler", 
+		c.logger.Info("Registered workflow event handler",
 			logger.Field{Key: "event_type", Value: eventType})
 	}
 
@@ -7,6 +7,9 @@ import (
 	"getnoti.com/config"
 	notificationRepos "getnoti.com/internal/notifications/repos"
 	notificationServices "getnoti.com/internal/notifications/services"
+	sendbatch "getnoti.com/internal/notifications/usecases/send_batch"
+	senddigest "getnoti.com/internal/notifications/usecases/send_digest"
+	sendnotification "getnoti.com/internal/notifications/usecases/send_notification"
 	"getnoti.com/internal/providers/infra/providers"
 	providerRepos "getnoti.com/internal/providers/repos"
 	providerServices "getnoti.com/internal/providers/services"
@@ -26,6 +29,7 @@ import (
 	"getnoti.com/pkg/db"
 	"getnoti.com/pkg/logger"
 	"getnoti.com/pkg/queue"
+	"getnoti.com/pkg/sse"
 	"getnoti.com/pkg/webhook"
 	"getnoti.com/pkg/workerpool"
 )
@@ -36,34 +40,43 @@ type ServiceContainer struct {
 	config *config.Config
 	logger logger.Logger
 	// Infrastructure Services
-	mainDB            db.Database
-	dbManager         *db.Manager
-	cache             *cache.GenericCache
-	credentialManager *credentials.Manager
-	queueManager      *queue.QueueManager
-	workerPoolManager *workerpool.WorkerPoolManager
-	configResolver    db.ConfigResolver
-	providerFactory   *providers.ProviderFactory
-	webhookSender     *webhook.Sender
-	eventBus          *events.HybridEventBus	// Application Services
-	tenantService         *tenantServices.TenantService
-	notificationService   *notificationServices.NotificationService
-	templateService       *templateServices.TemplateService
-	providerService       *providerServices.ProviderService
-	webhookService        *webhookServices.WebhookService
-	userPreferenceService *tenantServices.UserPreferenceService
-	workflowService       *workflowServices.WorkflowService
-	workflowEngine        *workflowEngine.WorkflowEngine
+	mainDB                      db.Database
+	dbManager                   *db.Manager
+	cache                       *cache.GenericCache
+	credentialManager           *credentials.Manager
+	queueManager                *queue.QueueManager
+	workerPoolManager           *workerpool.WorkerPoolManager
+	configResolver              db.ConfigResolver
+	providerFactory             *providers.ProviderFactory
+	webhookSender               *webhook.Sender
+	sseServer                   sse.Server
+	eventBus                    *events.HybridEventBus // Application Services
+	tenantService               *tenantServices.TenantService
+	apiKeyService               *tenantServices.APIKeyService
+	notificationService         *notificationServices.NotificationService
+	templateService             *templateServices.TemplateService
+	providerService             *providerServices.ProviderService
+	webhookService              *webhookServices.WebhookService
+	userPreferenceService       *tenantServices.UserPreferenceService
+	digestService               *notificationServices.DigestService
+	frequencyCapService         *notificationServices.FrequencyCapService
+	workflowService             *workflowServices.WorkflowService
+	executionService            *workflowServices.ExecutionService
+	workflowEngine              *workflowEngine.WorkflowEngine
+	scheduledNotificationWorker *sendnotification.ScheduledNotificationWorker
+	digestWorker                *senddigest.DigestWorker
+	batchWorker                 *sendbatch.BatchWorker
 	// Repositories
-	tenantRepo         tenantRepos.TenantsRepository
-	userRepo           tenantRepos.UserRepository
-	notificationRepo   notificationRepos.NotificationRepository
-	templateRepo       templateRepos.TemplateRepository
-	webhookRepo        webhookRepos.WebhookRepository
-	providerRepo       providerRepos.ProviderRepository
-	workflowRepo       workflowRepos.WorkflowRepository
-	executionRepo      workflowRepos.ExecutionRepository
-	repositoryFactory  *RepositoryFactory
+	tenantRepo        tenantRepos.TenantsRepository
+	userRepo          tenantRepos.UserRepository
+	apiKeyRepo        tenantRepos.APIKeyRepository
+	notificationRepo  notificationRepos.NotificationRepository
+	templateRepo      templateRepos.TemplateRepository
+	webhookRepo       webhookRepos.WebhookRepository
+	providerRepo      providerRepos.ProviderRepository
+	workflowRepo      workflowRepos.WorkflowRepository
+	executionRepo     workflowRepos.ExecutionRepository
+	repositoryFactory *RepositoryFactory
 }
 
 // NewServiceContainer creates and initializes the service container
@@ -90,10 +103,18 @@ func NewServiceContainer(cfg *config.Config, log logger.Logger) (*ServiceContain
 }
 
 // Service Getters
+func (c *ServiceContainer) GetConfig() *config.Config {
+	return c.config
+}
+
 func (c *ServiceContainer) GetTenantService() *tenantServices.TenantService {
 	return c.tenantService
 }
 
+func (c *ServiceContainer) GetAPIKeyService() *tenantServices.APIKeyService {
+	return c.apiKeyService
+}
+
 func (c *ServiceContainer) GetNotificationService() *notificationServices.NotificationService {
 	return c.notificationService
 }
@@ -114,14 +135,35 @@ func (c *ServiceContainer) GetUserPreferenceService() *tenantServices.UserPrefer
 	return c.userPreferenceService
 }
 
+func (c *ServiceContainer) GetDigestService() *notificationServices.DigestService {
+	return c.digestService
+}
+
+func (c *ServiceContainer) GetFrequencyCapService() *notificationServices.FrequencyCapService {
+	return c.frequencyCapService
+}
+
+func (c *ServiceContainer) GetBatchWorker() *sendbatch.BatchWorker {
+	return c.batchWorker
+}
+
 func (c *ServiceContainer) GetWebhookSender() *webhook.Sender {
 	return c.webhookSender
 }
 
+// GetSSEServer returns the server streaming real-time events to clients
+func (c *ServiceContainer) GetSSEServer() sse.Server {
+	return c.sseServer
+}
+
 func (c *ServiceContainer) GetWorkflowService() *workflowServices.WorkflowService {
 	return c.workflowService
 }
 
+func (c *ServiceContainer) GetExecutionService() *workflowServices.ExecutionService {
+	return c.executionService
+}
+
 func (c *ServiceContainer) GetWorkflowEngine() *workflowEngine.WorkflowEngine {
 	return c.workflowEngine
 }
@@ -172,32 +214,64 @@ func (c *ServiceContainer) GetInfrastructure() (*Infrastructure, error) {
 // Update repository getters to use the factory
 
 func (c *ServiceContainer) GetNotificationRepositoryForTenant(tenantID string) (notificationRepos.NotificationRepository, error) {
-    return c.repositoryFactory.GetNotificationRepositoryForTenant(tenantID)
+	return c.repositoryFactory.GetNotificationRepositoryForTenant(tenantID)
+}
+
+func (c *ServiceContainer) GetIdempotencyRepositoryForTenant(tenantID string) (notificationRepos.IdempotencyRepository, error) {
+	return c.repositoryFactory.GetIdempotencyRepositoryForTenant(tenantID)
+}
+
+func (c *ServiceContainer) GetBatchRepositoryForTenant(tenantID string) (notificationRepos.BatchRepository, error) {
+	return c.repositoryFactory.GetBatchRepositoryForTenant(tenantID)
+}
+
+func (c *ServiceContainer) GetTopicRepositoryForTenant(tenantID string) (notificationRepos.TopicRepository, error) {
+	return c.repositoryFactory.GetTopicRepositoryForTenant(tenantID)
+}
+
+func (c *ServiceContainer) GetDigestRepositoryForTenant(tenantID string) (notificationRepos.DigestRepository, error) {
+	return c.repositoryFactory.GetDigestRepositoryForTenant(tenantID)
+}
+
+func (c *ServiceContainer) GetInboxRepositoryForTenant(tenantID string) (notificationRepos.InboxRepository, error) {
+	return c.repositoryFactory.GetInboxRepositoryForTenant(tenantID)
+}
+
+func (c *ServiceContainer) GetFrequencyCounterRepositoryForTenant(tenantID string) (notificationRepos.FrequencyCounterRepository, error) {
+	return c.repositoryFactory.GetFrequencyCounterRepositoryForTenant(tenantID)
 }
 
 func (c *ServiceContainer) GetTemplateRepositoryForTenant(tenantID string) (templateRepos.TemplateRepository, error) {
-    return c.repositoryFactory.GetTemplateRepositoryForTenant(tenantID)
+	return c.repositoryFactory.GetTemplateRepositoryForTenant(tenantID)
 }
 
 func (c *ServiceContainer) GetProviderRepositoryForTenant(tenantID string) (providerRepos.ProviderRepository, error) {
-    return c.repositoryFactory.GetProviderRepositoryForTenant(tenantID)
+	return c.repositoryFactory.GetProviderRepositoryForTenant(tenantID)
 }
 
 func (c *ServiceContainer) GetWebhookRepositoryForTenant(tenantID string) (webhookRepos.WebhookRepository, error) {
-    return c.repositoryFactory.GetWebhookRepositoryForTenant(tenantID)
+	return c.repositoryFactory.GetWebhookRepositoryForTenant(tenantID)
+}
+
+func (c *ServiceContainer) GetUserRepositoryForTenant(tenantID string) (tenantRepos.UserRepository, error) {
+	return c.repositoryFactory.GetUserRepositoryForTenant(tenantID)
 }
 
 func (c *ServiceContainer) GetUserPreferenceRepositoryForTenant(tenantID string) (tenantRepos.UserPreferenceRepository, error) {
-    return c.repositoryFactory.GetUserPreferenceRepositoryForTenant(tenantID)
+	return c.repositoryFactory.GetUserPreferenceRepositoryForTenant(tenantID)
 }
 
 func (c *ServiceContainer) GetTenantPreferenceRepositoryForTenant(tenantID string) (tenantRepos.TenantPreferenceRepository, error) {
-    return c.repositoryFactory.GetTenantPreferenceRepositoryForTenant(tenantID)
+	return c.repositoryFactory.GetTenantPreferenceRepositoryForTenant(tenantID)
+}
+
+func (c *ServiceContainer) GetWebPushSubscriptionRepositoryForTenant(tenantID string) (tenantRepos.WebPushSubscriptionRepository, error) {
+	return c.repositoryFactory.GetWebPushSubscriptionRepositoryForTenant(tenantID)
 }
 
 // GetSchedulerRepository gets the scheduler repository (uses main database)
 func (c *ServiceContainer) GetSchedulerRepository() (schedulerRepos.Repository, error) {
-    return c.repositoryFactory.GetSchedulerRepository()
+	return c.repositoryFactory.GetSchedulerRepository()
 }
 
 // Infrastructure holds infrastructure components
@@ -215,6 +289,16 @@ type Infrastructure struct {
 func (c *ServiceContainer) Cleanup(ctx context.Context) error {
 	var errors []error
 
+	if c.scheduledNotificationWorker != nil {
+		c.scheduledNotificationWorker.Stop()
+	}
+	if c.digestWorker != nil {
+		c.digestWorker.Stop()
+	}
+	if c.batchWorker != nil {
+		c.batchWorker.Stop()
+	}
+
 	// Close infrastructure in reverse order
 	if c.workerPoolManager != nil {
 		if err := c.workerPoolManager.Shutdown(); err != nil {
@@ -19,36 +19,32 @@ func (c *ServiceContainer) initializeRepositories() error {
 	// Initialize user repository (uses main DB)
 	c.userRepo = tenantRepos.NewUserRepository(c.mainDB)
 	c.logger.Info("User repository initialized successfully")
+	// Initialize API key repository (uses main DB)
+	c.apiKeyRepo = tenantRepos.NewAPIKeyRepository(c.mainDB)
+	c.logger.Info("API key repository initialized successfully")
 	// Initialize provider repository (uses main DB for provider metadata)
 	c.providerRepo = providerRepos.NewProviderRepository(c.mainDB)
 	c.logger.Info("Provider repository initialized successfully")
-	
+
 	// Initialize notification repository (uses main DB for notification metadata)
 	c.notificationRepo = notificationRepos.NewNotificationRepository(c.mainDB)
 	c.logger.Info("Notification repository initialized successfully")
-	
+
 	// Initialize template repository (uses main DB for template metadata)
 	c.templateRepo = templateRepos.NewTemplateRepository(c.mainDB)
 	c.logger.Info("Template repository initialized successfully")
-	
+
 	// Initialize webhook repository (uses main DB for webhook metadata)
 	c.webhookRepo = webhookRepos.NewWebhookRepository(c.mainDB)
 	c.logger.Info("Webhook repository initialized successfully")
 
-	// Initialize provider factory (needs provider repo, cache, and credential manager)
-	c.providerFactory = providers.NewProviderFactory(
-		c.cache,
-		c.providerRepo,
-		c.credentialManager,	)
-	c.logger.Info("Provider factory initialized successfully")
-	
 	// Initialize workflow repositories
 	c.workflowRepo = workflowRepos.NewWorkflowRepository(c.mainDB)
 	c.logger.Info("Workflow repository initialized successfully")
-	
+
 	c.executionRepo = workflowRepos.NewExecutionRepository(c.mainDB)
 	c.logger.Info("Workflow execution repository initialized successfully")
-	
+
 	// Initialize repository factory for tenant-specific repositories
 	c.repositoryFactory = NewRepositoryFactory(
 		c.dbManager,
@@ -57,6 +53,18 @@ func (c *ServiceContainer) initializeRepositories() error {
 	)
 	c.logger.Info("Repository factory initialized successfully")
 
+	// Initialize provider factory (needs provider repo, cache, credential manager,
+	// the repository factory for web push subscriptions and inboxes, and the SSE
+	// server in-app messages are streamed on)
+	c.providerFactory = providers.NewProviderFactory(
+		c.cache,
+		c.providerRepo,
+		c.credentialManager,
+		c.repositoryFactory,
+		c.sseServer,
+	)
+	c.logger.Info("Provider factory initialized successfully")
+
 	c.logger.Info("Repository initialization completed successfully")
 	return nil
 }
@@ -22,97 +22,177 @@ import (
 
 // RepositoryFactory creates tenant-specific repositories
 type RepositoryFactory struct {
-    dbManager         *db.Manager
-    credentialManager *credentials.Manager
-    logger            logger.Logger
+	dbManager         *db.Manager
+	credentialManager *credentials.Manager
+	logger            logger.Logger
 }
 
 // NewRepositoryFactory creates a new repository factory
 func NewRepositoryFactory(
-    dbManager *db.Manager,
-    credentialManager *credentials.Manager,
-    logger logger.Logger,
+	dbManager *db.Manager,
+	credentialManager *credentials.Manager,
+	logger logger.Logger,
 ) *RepositoryFactory {
-    return &RepositoryFactory{
-        dbManager:         dbManager,
-        credentialManager: credentialManager,
-        logger:           logger,
-    }
+	return &RepositoryFactory{
+		dbManager:         dbManager,
+		credentialManager: credentialManager,
+		logger:            logger,
+	}
 }
 
 // GetNotificationRepositoryForTenant creates a notification repository for a tenant
 func (f *RepositoryFactory) GetNotificationRepositoryForTenant(tenantID string) (notificationRepos.NotificationRepository, error) {
-    // Get tenant DB connection
-    db, err := f.dbManager.GetDatabaseConnection(tenantID)
-    if err != nil {
-        return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
-    }
-    
-    return notificationImpl.NewNotificationRepository(db), nil
+	// Get tenant DB connection
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return notificationImpl.NewNotificationRepository(db), nil
+}
+
+// GetIdempotencyRepositoryForTenant creates an idempotency key repository for a tenant
+func (f *RepositoryFactory) GetIdempotencyRepositoryForTenant(tenantID string) (notificationRepos.IdempotencyRepository, error) {
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return notificationImpl.NewIdempotencyRepository(db), nil
+}
+
+// GetBatchRepositoryForTenant creates a notification batch repository for a tenant
+func (f *RepositoryFactory) GetBatchRepositoryForTenant(tenantID string) (notificationRepos.BatchRepository, error) {
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return notificationImpl.NewBatchRepository(db), nil
+}
+
+// GetTopicRepositoryForTenant creates a topic repository for a tenant
+func (f *RepositoryFactory) GetTopicRepositoryForTenant(tenantID string) (notificationRepos.TopicRepository, error) {
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return notificationImpl.NewTopicRepository(db), nil
+}
+
+// GetDigestRepositoryForTenant creates a digest repository for a tenant
+func (f *RepositoryFactory) GetDigestRepositoryForTenant(tenantID string) (notificationRepos.DigestRepository, error) {
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return notificationImpl.NewDigestRepository(db), nil
+}
+
+// GetInboxRepositoryForTenant creates an inbox repository for a tenant
+func (f *RepositoryFactory) GetInboxRepositoryForTenant(tenantID string) (notificationRepos.InboxRepository, error) {
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return notificationImpl.NewInboxRepository(db), nil
+}
+
+// GetFrequencyCounterRepositoryForTenant creates a frequency counter repository for a tenant
+func (f *RepositoryFactory) GetFrequencyCounterRepositoryForTenant(tenantID string) (notificationRepos.FrequencyCounterRepository, error) {
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return notificationImpl.NewFrequencyCounterRepository(db), nil
 }
 
 // GetTemplateRepositoryForTenant creates a template repository for a tenant
 func (f *RepositoryFactory) GetTemplateRepositoryForTenant(tenantID string) (templateRepos.TemplateRepository, error) {
-    // Get tenant DB connection
-    db, err := f.dbManager.GetDatabaseConnection(tenantID)
-    if err != nil {
-        return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
-    }
-    
-    return templateImpl.NewTemplateRepository(db), nil
+	// Get tenant DB connection
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return templateImpl.NewTemplateRepository(db), nil
 }
 
 // GetProviderRepositoryForTenant creates a provider repository for a tenant
 func (f *RepositoryFactory) GetProviderRepositoryForTenant(tenantID string) (providerRepos.ProviderRepository, error) {
-    // Get tenant DB connection
-    db, err := f.dbManager.GetDatabaseConnection(tenantID)
-    if err != nil {
-        return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
-    }
-    
-    return providerImpl.NewProviderRepository(db), nil
+	// Get tenant DB connection
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return providerImpl.NewProviderRepository(db), nil
 }
 
 // GetWebhookRepositoryForTenant creates a webhook repository for a tenant
 func (f *RepositoryFactory) GetWebhookRepositoryForTenant(tenantID string) (webhookRepos.WebhookRepository, error) {
-    // Get tenant DB connection
-    db, err := f.dbManager.GetDatabaseConnection(tenantID)
-    if err != nil {
-        return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
-    }
-    
-    return webhookImpl.NewWebhookRepository(db), nil
+	// Get tenant DB connection
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return webhookImpl.NewWebhookRepository(db), nil
 }
 
 // GetSchedulerRepository creates a scheduler repository using the admin database
 // Note: Scheduler uses admin database for better scalability across tenants
 func (f *RepositoryFactory) GetSchedulerRepository() (schedulerRepos.Repository, error) {
-    // Get admin/main DB connection instead of tenant-specific DB
-    mainDB := f.dbManager.GetMainDatabase()
-    if mainDB == nil {
-        return nil, fmt.Errorf("main database is not initialized")
-    }
-    
-    return schedulerRepos.NewSchedulerRepository(mainDB), nil
+	// Get admin/main DB connection instead of tenant-specific DB
+	mainDB := f.dbManager.GetMainDatabase()
+	if mainDB == nil {
+		return nil, fmt.Errorf("main database is not initialized")
+	}
+
+	return schedulerRepos.NewSchedulerRepository(mainDB), nil
 }
 
 func (f *RepositoryFactory) GetUserPreferenceRepositoryForTenant(tenantID string) (tenantRepos.UserPreferenceRepository, error) {
-    
-    db, err := f.dbManager.GetDatabaseConnection(tenantID)
 
-    if err != nil {
-        return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
-    }
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
 
-    return tenantImpl.NewUserPreferenceRepository(db), nil
+	return tenantImpl.NewUserPreferenceRepository(db), nil
+}
+
+// GetUserRepositoryForTenant creates a user repository for a tenant
+func (f *RepositoryFactory) GetUserRepositoryForTenant(tenantID string) (tenantRepos.UserRepository, error) {
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return tenantImpl.NewUserRepository(db), nil
 }
 
 // GetTenantPreferenceRepositoryForTenant creates a tenant preference repository for a tenant
 func (f *RepositoryFactory) GetTenantPreferenceRepositoryForTenant(tenantID string) (tenantRepos.TenantPreferenceRepository, error) {
-    db, err := f.dbManager.GetDatabaseConnection(tenantID)
-    if err != nil {
-        return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
-    }
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
 
-    return tenantImpl.NewTenantPreferenceRepository(db), nil
-}
\ No newline at end of file
+	return tenantImpl.NewTenantPreferenceRepository(db), nil
+}
+
+// GetWebPushSubscriptionRepositoryForTenant creates a web push subscription repository for a tenant
+func (f *RepositoryFactory) GetWebPushSubscriptionRepositoryForTenant(tenantID string) (tenantRepos.WebPushSubscriptionRepository, error) {
+	db, err := f.dbManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database for tenant %s: %w", tenantID, err)
+	}
+
+	return tenantImpl.NewWebPushSubscriptionRepository(db), nil
+}
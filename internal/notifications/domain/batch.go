@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// BatchStatus is the overall progress of a batch send
+type BatchStatus string
+
+const (
+	BatchStatusProcessing BatchStatus = "processing"
+	BatchStatusCompleted  BatchStatus = "completed"
+)
+
+// RecipientStatusPending marks a batch recipient whose notification has not been sent yet.
+// Once sent, the recipient takes the status of its notification.
+const RecipientStatusPending = StatusPending
+
+// NotificationBatch sends the same notification to many recipients
+type NotificationBatch struct {
+	ID         string
+	TenantID   string
+	Type       string
+	Channel    string
+	TemplateID string `json:",omitempty"`
+	// TopicID is set when the batch was expanded from a topic
+	TopicID         string `json:",omitempty"`
+	TotalRecipients int
+	// Request is the encoded send request every recipient is sent with
+	Request   []byte `json:"-"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BatchRecipient is one recipient of a batch and the outcome of its send
+type BatchRecipient struct {
+	BatchID string `json:"-"`
+	// Position is the recipient's index in the batch request
+	Position int
+	UserID   string
+	// Variables override the batch variables for this recipient
+	Variables      []TemplateVariable `json:",omitempty"`
+	NotificationID string             `json:",omitempty"`
+	Status         NotificationStatus
+	Error          string `json:",omitempty"`
+	UpdatedAt      time.Time
+}
+
+// IsProcessed reports whether the recipient's send has finished
+func (r *BatchRecipient) IsProcessed() bool {
+	return r.Status != RecipientStatusPending
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// DigestStatus is a step in a digest's lifecycle: a digest is open while it
+// collects events, sending once its window ends, then sent or failed.
+type DigestStatus string
+
+const (
+	DigestStatusOpen    DigestStatus = "open"
+	DigestStatusSending DigestStatus = "sending"
+	DigestStatusSent    DigestStatus = "sent"
+	DigestStatusFailed  DigestStatus = "failed"
+)
+
+// Digest collects a user's events for a digest key and sends them as one
+// notification when its window ends. A user has at most one open digest per key.
+type Digest struct {
+	ID         string
+	TenantID   string
+	UserID     string
+	Key        string
+	Channel    string
+	TemplateID string
+	Category   string `json:",omitempty"`
+	Status     DigestStatus
+	// SendAt is when the window ends and the digest is sent
+	SendAt     time.Time
+	EventCount int
+	// NotificationID is the notification that delivered the digest
+	NotificationID string `json:",omitempty"`
+	Error          string `json:",omitempty"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// DigestEvent is one event collected into a digest
+type DigestEvent struct {
+	ID       string
+	DigestID string
+	// Data is listed in the digest notification as one of its items
+	Data      map[string]interface{}
+	CreatedAt time.Time
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// FrequencyCounter counts a user's sends against one frequency cap in one window
+type FrequencyCounter struct {
+	// CapKey identifies the cap the counter belongs to
+	CapKey      string
+	WindowStart time.Time
+	// Limit is the most sends the window allows
+	Limit int
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// IdempotencyKey remembers a send made with a client-supplied key so retries
+// of the same request return the original response instead of sending again
+type IdempotencyKey struct {
+	Key string
+	// RequestHash identifies the request body the key was first used with
+	RequestHash string
+	// Response is the stored response; nil while the first request is still running
+	Response  []byte
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// IsComplete reports whether the original request finished and stored its response
+func (k *IdempotencyKey) IsComplete() bool {
+	return k.Response != nil
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// InboxStatus selects inbox items by whether they have been read or archived
+type InboxStatus string
+
+const (
+	// InboxStatusAll lists every item that is not archived
+	InboxStatusAll      InboxStatus = "all"
+	InboxStatusUnread   InboxStatus = "unread"
+	InboxStatusRead     InboxStatus = "read"
+	InboxStatusArchived InboxStatus = "archived"
+)
+
+// IsValid checks the status is one of the defined inbox statuses
+func (s InboxStatus) IsValid() bool {
+	switch s {
+	case InboxStatusAll, InboxStatusUnread, InboxStatusRead, InboxStatusArchived:
+		return true
+	}
+	return false
+}
+
+// InboxItem is an in-app message in a user's inbox. Items are unread until
+// ReadAt is set and leave the feed once archived.
+type InboxItem struct {
+	ID     string
+	UserID string
+	// NotificationID is the notification that delivered the item
+	NotificationID string `json:",omitempty"`
+	Category       string `json:",omitempty"`
+	Title          string `json:",omitempty"`
+	Body           string
+	Data           map[string]interface{} `json:",omitempty"`
+	ReadAt         *time.Time             `json:",omitempty"`
+	ArchivedAt     *time.Time             `json:",omitempty"`
+	CreatedAt      time.Time
+}
+
+// InboxEvent is published on the user's inbox stream when the inbox changes,
+// so open clients can show new items and keep their unread count current
+type InboxEvent struct {
+	Type string
+	// Item is the new item of an inbox.item.created event
+	Item *InboxItem `json:",omitempty"`
+	// ItemID is the item that was read or archived
+	ItemID      string `json:",omitempty"`
+	UnreadCount int
+}
+
+// Inbox event types
+const (
+	InboxEventItemCreated  = "inbox.item.created"
+	InboxEventItemRead     = "inbox.item.read"
+	InboxEventAllRead      = "inbox.all.read"
+	InboxEventItemArchived = "inbox.item.archived"
+)
+
+// InboxStreamChannel is the SSE channel a user's inbox events are published on
+func InboxStreamChannel(tenantID, userID string) string {
+	return "tenant_" + tenantID + "_user_" + userID + "_inbox"
+}
@@ -1,19 +1,36 @@
 package domain
 
+import "time"
+
 type TemplateVariable struct {
 	Key   string
 	Value string
 }
 
 type Notification struct {
-	ID         string
-	TenantID   string
-	UserID     string
-	Type       string
+	ID       string
+	TenantID string
+	UserID   string
+	Type     string
+	// Category is the preference category the notification belongs to
+	Category string `json:",omitempty"`
+	// OverridePreferences sends regardless of the user's preferences, for
+	// critical and transactional notifications
+	OverridePreferences bool `json:",omitempty"`
+	// Urgent notifications are delivered during the user's quiet hours instead of deferred
+	Urgent     bool `json:",omitempty"`
 	Channel    string
 	TemplateID string
-	Status     string
+	// TemplateVersion is the template version that rendered the content
+	TemplateVersion int
+	// Locale is the template translation that was rendered; empty for the base content
+	Locale     string
+	Status     NotificationStatus
 	Content    string
 	ProviderID string
 	Variables  []TemplateVariable
+	// ScheduledFor is when a scheduled notification is released for sending
+	ScheduledFor *time.Time `json:",omitempty"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
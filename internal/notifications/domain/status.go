@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NotificationStatus is a step in a notification's delivery lifecycle:
+// pending → queued → sent → delivered, with failed, bounced and suppressed as
+// the other ways a notification can end. Notifications sent for a future time
+// wait as scheduled until they are released to pending or cancelled, and
+// pending notifications deferred by quiet hours go back to scheduled.
+type NotificationStatus string
+
+const (
+	StatusScheduled  NotificationStatus = "scheduled"
+	StatusPending    NotificationStatus = "pending"
+	StatusQueued     NotificationStatus = "queued"
+	StatusSent       NotificationStatus = "sent"
+	StatusDelivered  NotificationStatus = "delivered"
+	StatusFailed     NotificationStatus = "failed"
+	StatusBounced    NotificationStatus = "bounced"
+	StatusSuppressed NotificationStatus = "suppressed"
+	StatusCancelled  NotificationStatus = "cancelled"
+)
+
+var ErrInvalidStatusTransition = errors.New("invalid notification status transition")
+
+// statusTransitions lists the statuses each status may move to
+var statusTransitions = map[NotificationStatus][]NotificationStatus{
+	StatusScheduled: {StatusPending, StatusCancelled},
+	StatusPending:   {StatusScheduled, StatusQueued, StatusFailed, StatusSuppressed},
+	StatusQueued:    {StatusSent, StatusFailed, StatusSuppressed},
+	StatusSent:      {StatusDelivered, StatusFailed, StatusBounced},
+}
+
+// IsValid reports whether the status is part of the lifecycle
+func (s NotificationStatus) IsValid() bool {
+	switch s {
+	case StatusScheduled, StatusPending, StatusQueued, StatusSent, StatusDelivered, StatusFailed, StatusBounced, StatusSuppressed, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports whether the notification can no longer change status
+func (s NotificationStatus) IsFinal() bool {
+	return s.IsValid() && len(statusTransitions[s]) == 0
+}
+
+// CanTransitionTo reports whether the lifecycle allows moving from s to next
+func (s NotificationStatus) CanTransitionTo(next NotificationStatus) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationAttempt records a notification status change and the provider
+// that caused it
+type NotificationAttempt struct {
+	ID             string
+	NotificationID string
+	Status         NotificationStatus
+	ProviderID     string `json:",omitempty"`
+	// ProviderMessageID is the provider's ID for the message, used to match delivery receipts
+	ProviderMessageID string `json:",omitempty"`
+	// Reason explains a failure or suppression
+	Reason    string `json:",omitempty"`
+	CreatedAt time.Time
+}
+
+// Transition moves the notification to next, or returns ErrInvalidStatusTransition
+func (n *Notification) Transition(next NotificationStatus) error {
+	if !n.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, n.Status, next)
+	}
+	n.Status = next
+	n.UpdatedAt = time.Now()
+	return nil
+}
+
+// NewAttempt records the notification's current status with the provider details
+func (n *Notification) NewAttempt(id, providerMessageID, reason string) *NotificationAttempt {
+	return &NotificationAttempt{
+		ID:                id,
+		NotificationID:    n.ID,
+		Status:            n.Status,
+		ProviderID:        n.ProviderID,
+		ProviderMessageID: providerMessageID,
+		Reason:            reason,
+		CreatedAt:         time.Now(),
+	}
+}
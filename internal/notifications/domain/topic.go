@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidTopic = errors.New("topic needs a key and a name")
+
+// Topic is a named group of users that notifications can be sent to together
+type Topic struct {
+	ID string
+	// Key is the tenant's own identifier for the topic, such as "account:42:admins"
+	Key         string
+	Name        string
+	Description string `json:",omitempty"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Validate checks the fields a topic needs
+func (t *Topic) Validate() error {
+	if t.Key == "" || t.Name == "" {
+		return ErrInvalidTopic
+	}
+	return nil
+}
+
+// TopicSubscription makes a user a member of a topic
+type TopicSubscription struct {
+	TopicID   string
+	UserID    string
+	CreatedAt time.Time
+}
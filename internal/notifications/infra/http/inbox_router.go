@@ -0,0 +1,263 @@
+package notificationroutes
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"getnoti.com/internal/container"
+	"getnoti.com/internal/notifications/domain"
+	repos "getnoti.com/internal/notifications/repos/implementations"
+	archiveinboxitem "getnoti.com/internal/notifications/usecases/archive_inbox_item"
+	getinbox "getnoti.com/internal/notifications/usecases/get_inbox"
+	getinboxunreadcount "getnoti.com/internal/notifications/usecases/get_inbox_unread_count"
+	markallinboxread "getnoti.com/internal/notifications/usecases/mark_all_inbox_read"
+	markinboxitemread "getnoti.com/internal/notifications/usecases/mark_inbox_item_read"
+	"getnoti.com/internal/shared/handler"
+	"getnoti.com/internal/shared/middleware"
+	"getnoti.com/internal/shared/utils"
+	"getnoti.com/pkg/cache"
+	"getnoti.com/pkg/credentials"
+	"getnoti.com/pkg/db"
+	"getnoti.com/pkg/queue"
+	"getnoti.com/pkg/workerpool"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *Handlers) GetInbox(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	inboxRepo, err := h.ServiceContainer.GetInboxRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get inbox repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	getInboxUseCase := getinbox.NewGetInboxUseCase(inboxRepo)
+	getInboxController := getinbox.NewGetInboxController(getInboxUseCase)
+
+	query := r.URL.Query()
+	req := getinbox.GetInboxRequest{
+		Status: query.Get("status"),
+		Cursor: query.Get("cursor"),
+	}
+	if v, err := strconv.Atoi(query.Get("limit")); err == nil {
+		req.Limit = v
+	}
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := getInboxController.GetInbox(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get inbox", err, inboxErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) GetInboxUnreadCount(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	inboxRepo, err := h.ServiceContainer.GetInboxRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get inbox repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	unreadCountUseCase := getinboxunreadcount.NewGetInboxUnreadCountUseCase(inboxRepo)
+	unreadCountController := getinboxunreadcount.NewGetInboxUnreadCountController(unreadCountUseCase)
+
+	var req getinboxunreadcount.GetInboxUnreadCountRequest
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := unreadCountController.GetInboxUnreadCount(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get unread count", err, inboxErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) MarkInboxItemRead(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	inboxRepo, err := h.ServiceContainer.GetInboxRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get inbox repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	markReadUseCase := markinboxitemread.NewMarkInboxItemReadUseCase(inboxRepo, h.ServiceContainer.GetSSEServer())
+	markReadController := markinboxitemread.NewMarkInboxItemReadController(markReadUseCase)
+
+	req := markinboxitemread.MarkInboxItemReadRequest{ItemID: chi.URLParam(r, "itemID")}
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+	if err := utils.AddTenantIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process tenant ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := markReadController.MarkInboxItemRead(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to mark inbox item read", err, inboxErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) MarkAllInboxRead(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	inboxRepo, err := h.ServiceContainer.GetInboxRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get inbox repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	markAllReadUseCase := markallinboxread.NewMarkAllInboxReadUseCase(inboxRepo, h.ServiceContainer.GetSSEServer())
+	markAllReadController := markallinboxread.NewMarkAllInboxReadController(markAllReadUseCase)
+
+	var req markallinboxread.MarkAllInboxReadRequest
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+	if err := utils.AddTenantIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process tenant ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := markAllReadController.MarkAllInboxRead(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to mark inbox read", err, inboxErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) ArchiveInboxItem(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	inboxRepo, err := h.ServiceContainer.GetInboxRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get inbox repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	archiveUseCase := archiveinboxitem.NewArchiveInboxItemUseCase(inboxRepo, h.ServiceContainer.GetSSEServer())
+	archiveController := archiveinboxitem.NewArchiveInboxItemController(archiveUseCase)
+
+	req := archiveinboxitem.ArchiveInboxItemRequest{ItemID: chi.URLParam(r, "itemID")}
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+	if err := utils.AddTenantIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process tenant ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := archiveController.ArchiveInboxItem(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to archive inbox item", err, inboxErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+// StreamInbox streams the user's inbox events as they happen
+func (h *Handlers) StreamInbox(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	userID := chi.URLParam(r, "id")
+	if userID == "" {
+		http.Error(w, "User ID is required", http.StatusBadRequest)
+		return
+	}
+
+	h.ServiceContainer.GetSSEServer().ServeHTTP(w, r, domain.InboxStreamChannel(tenantID, userID))
+}
+
+// InboxTokenResponse carries a token the user's browser or app uses for their inbox
+type InboxTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IssueInboxToken returns a handler that issues an inbox token for the user.
+// It is called by the tenant's backend with the API key; an inbox token cannot
+// be used to issue another one.
+func (h *Handlers) IssueInboxToken(secret string, ttl time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if secret == "" {
+			http.NotFound(w, r)
+			return
+		}
+		if _, ok := r.Context().Value(middleware.InboxUserIDKey).(string); ok {
+			http.Error(w, "Inbox tokens must be issued with an API key", http.StatusForbidden)
+			return
+		}
+
+		tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+		userID := chi.URLParam(r, "id")
+		if userID == "" {
+			http.Error(w, "User ID is required", http.StatusBadRequest)
+			return
+		}
+
+		expiresAt := time.Now().Add(ttl).UTC().Truncate(time.Second)
+		token, err := middleware.SignInboxToken(secret, tenantID, userID, expiresAt)
+		if err != nil {
+			h.BaseHandler.HandleError(w, "Failed to issue inbox token", err, http.StatusInternalServerError)
+			return
+		}
+
+		h.BaseHandler.RespondWithJSONStatus(w, http.StatusCreated, InboxTokenResponse{Token: token, ExpiresAt: expiresAt})
+	}
+}
+
+// inboxErrorStatus maps inbox errors to HTTP status codes
+func inboxErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, getinbox.ErrInvalidStatus), errors.Is(err, getinbox.ErrInvalidCursor):
+		return http.StatusBadRequest
+	case errors.Is(err, repos.ErrInboxItemNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// NewInboxRouter serves a user's inbox; it is mounted under the user's path
+// so {id} is the user ID. Inbox tokens are signed with tokenSecret and are
+// valid for tokenTTL.
+func NewInboxRouter(serviceContainer *container.ServiceContainer, dbManager *db.Manager, providerCache *cache.GenericCache, queueManager *queue.QueueManager, credentialManager *credentials.Manager, wpm *workerpool.WorkerPoolManager, tokenSecret string, tokenTTL time.Duration) *chi.Mux {
+	b := handler.NewBaseHandler(dbManager)
+	h := NewHandlers(b, serviceContainer, providerCache, queueManager, credentialManager, wpm)
+
+	r := chi.NewRouter()
+
+	// Set up routes
+	r.Get("/", h.GetInbox)
+	r.Get("/unread-count", h.GetInboxUnreadCount)
+	r.Get("/stream", h.StreamInbox)
+	r.Post("/token", h.IssueInboxToken(tokenSecret, tokenTTL))
+	r.Post("/read-all", h.MarkAllInboxRead)
+	r.Post("/{itemID}/read", h.MarkInboxItemRead)
+	r.Post("/{itemID}/archive", h.ArchiveInboxItem)
+
+	return r
+}
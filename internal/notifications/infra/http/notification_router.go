@@ -1,9 +1,18 @@
 package notificationroutes
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"getnoti.com/internal/container"
+	repos "getnoti.com/internal/notifications/repos/implementations"
+	cancelnotification "getnoti.com/internal/notifications/usecases/cancel_notification"
+	getbatch "getnoti.com/internal/notifications/usecases/get_batch"
+	getnotification "getnoti.com/internal/notifications/usecases/get_notification"
+	listnotifications "getnoti.com/internal/notifications/usecases/list_notifications"
+	reschedulenotification "getnoti.com/internal/notifications/usecases/reschedule_notification"
+	sendbatch "getnoti.com/internal/notifications/usecases/send_batch"
 	sendnotification "getnoti.com/internal/notifications/usecases/send_notification"
 	"getnoti.com/internal/shared/handler"
 	"getnoti.com/internal/shared/middleware"
@@ -16,16 +25,21 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
 type Handlers struct {
 	BaseHandler       *handler.BaseHandler
 	ServiceContainer  *container.ServiceContainer
 	GenericCache      *cache.GenericCache
 	QueueManager      *queue.QueueManager
-	CredentialManager  *credentials.Manager
+	CredentialManager *credentials.Manager
 	WorkerPoolManager *workerpool.WorkerPoolManager
 }
 
-func NewHandlers(baseHandler *handler.BaseHandler, serviceContainer *container.ServiceContainer, genericCache *cache.GenericCache, queueManager *queue.QueueManager, credentialManager  *credentials.Manager,wpm *workerpool.WorkerPoolManager) *Handlers {
+func NewHandlers(baseHandler *handler.BaseHandler, serviceContainer *container.ServiceContainer, genericCache *cache.GenericCache, queueManager *queue.QueueManager, credentialManager *credentials.Manager, wpm *workerpool.WorkerPoolManager) *Handlers {
 	return &Handlers{
 		BaseHandler:       baseHandler,
 		ServiceContainer:  serviceContainer,
@@ -50,24 +64,38 @@ func (h *Handlers) SendNotification(w http.ResponseWriter, r *http.Request) {
 		h.BaseHandler.HandleError(w, "Failed to get notification repository", err, http.StatusInternalServerError)
 		return
 	}
-	
+
 	providerRepo, err := h.ServiceContainer.GetProviderRepositoryForTenant(tenantID)
 	if err != nil {
 		h.BaseHandler.HandleError(w, "Failed to get provider repository", err, http.StatusInternalServerError)
 		return
 	}
 
+	userRepo, err := h.ServiceContainer.GetUserRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get user repository", err, http.StatusInternalServerError)
+		return
+	}
+
 	// Initialize use case with container services
 	sendNotificationUseCase := sendnotification.NewSendNotificationUseCase(
-		providerService, 
-		templateService, 
-		providerRepo, 
-		notificationRepo, 
+		providerService,
+		templateService,
+		providerRepo,
+		notificationRepo,
+		userRepo,
 		h.GenericCache,
+		h.ServiceContainer.GetUserPreferenceService(),
+		h.ServiceContainer.GetDigestService(),
+		h.ServiceContainer.GetFrequencyCapService(),
 	)
 
-	// Initialize controller
-	sendNotificationController := sendnotification.NewSendNotificationController(sendNotificationUseCase)
+	idempotencyRepo, err := h.ServiceContainer.GetIdempotencyRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get idempotency repository", err, http.StatusInternalServerError)
+		return
+	}
+	idempotentSendUseCase := sendnotification.NewIdempotentSendNotificationUseCase(sendNotificationUseCase, idempotencyRepo, sendnotification.DefaultIdempotencyRetention)
 
 	// Decode the request body
 	var req sendnotification.SendNotificationRequest
@@ -80,24 +108,267 @@ func (h *Handlers) SendNotification(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Execute the controller method
-	res, err := sendNotificationController.SendNotification(r.Context(), req)
+	// Retries carrying the same Idempotency-Key get the original response instead of a second send
+	res, replayed, err := idempotentSendUseCase.Execute(r.Context(), r.Header.Get(idempotencyKeyHeader), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to send notification", err, sendErrorStatus(err))
+		return
+	}
+	if replayed {
+		w.Header().Set(idempotentReplayedHeader, "true")
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+// sendErrorStatus maps send errors to HTTP status codes
+func sendErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, sendnotification.ErrInvalidIdempotencyKey):
+		return http.StatusBadRequest
+	case errors.Is(err, sendnotification.ErrIdempotencyKeyMismatch), errors.Is(err, sendnotification.ErrIdempotencyKeyInProgress):
+		return http.StatusConflict
+	case errors.Is(err, sendnotification.ErrNoReceiverAddress):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
+
+func (h *Handlers) GetNotification(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	notificationRepo, err := h.ServiceContainer.GetNotificationRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get notification repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	getNotificationUseCase := getnotification.NewGetNotificationUseCase(notificationRepo)
+	getNotificationController := getnotification.NewGetNotificationController(getNotificationUseCase)
+
+	var req getnotification.GetNotificationRequest
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := getNotificationController.GetNotification(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, repos.ErrNotificationNotFound) {
+			status = http.StatusNotFound
+		}
+		h.BaseHandler.HandleError(w, "Failed to get notification", err, status)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	notificationRepo, err := h.ServiceContainer.GetNotificationRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get notification repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	listNotificationsUseCase := listnotifications.NewListNotificationsUseCase(notificationRepo)
+	listNotificationsController := listnotifications.NewListNotificationsController(listNotificationsUseCase)
+
+	query := r.URL.Query()
+	req := listnotifications.ListNotificationsRequest{
+		UserID:     query.Get("user_id"),
+		Channel:    query.Get("channel"),
+		Status:     query.Get("status"),
+		TemplateID: query.Get("template_id"),
+		ProviderID: query.Get("provider_id"),
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+		Cursor:     query.Get("cursor"),
+	}
+	if v, err := strconv.Atoi(query.Get("limit")); err == nil {
+		req.Limit = v
+	}
+
+	res, err := listNotificationsController.ListNotifications(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, listnotifications.ErrInvalidFilter) || errors.Is(err, listnotifications.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+		}
+		h.BaseHandler.HandleError(w, "Failed to list notifications", err, status)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) CancelNotification(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	notificationRepo, err := h.ServiceContainer.GetNotificationRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get notification repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	cancelNotificationUseCase := cancelnotification.NewCancelNotificationUseCase(notificationRepo)
+	cancelNotificationController := cancelnotification.NewCancelNotificationController(cancelNotificationUseCase)
+
+	// The body is optional and only carries a reason
+	var req cancelnotification.CancelNotificationRequest
+	if r.ContentLength != 0 && !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := cancelNotificationController.CancelNotification(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to cancel notification", err, scheduleErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) RescheduleNotification(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	notificationRepo, err := h.ServiceContainer.GetNotificationRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get notification repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	rescheduleNotificationUseCase := reschedulenotification.NewRescheduleNotificationUseCase(notificationRepo)
+	rescheduleNotificationController := reschedulenotification.NewRescheduleNotificationController(rescheduleNotificationUseCase)
+
+	var req reschedulenotification.RescheduleNotificationRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := rescheduleNotificationController.RescheduleNotification(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to reschedule notification", err, scheduleErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+// scheduleErrorStatus maps cancel and reschedule errors to HTTP status codes
+func scheduleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repos.ErrNotificationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, reschedulenotification.ErrInvalidScheduledTime):
+		return http.StatusBadRequest
+	case errors.Is(err, cancelnotification.ErrNotScheduled), errors.Is(err, reschedulenotification.ErrNotScheduled):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func (h *Handlers) SendBatch(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	batchRepo, err := h.ServiceContainer.GetBatchRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get batch repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	sendBatchUseCase := sendbatch.NewSendBatchUseCase(batchRepo, h.ServiceContainer.GetBatchWorker())
+	sendBatchController := sendbatch.NewSendBatchController(sendBatchUseCase)
+
+	var req sendbatch.SendBatchRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if err := utils.AddTenantIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process tenant ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := sendBatchController.SendBatch(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, sendbatch.ErrNoRecipients) || errors.Is(err, sendbatch.ErrTooManyRecipients) ||
+			errors.Is(err, sendbatch.ErrInvalidRecipient) || errors.Is(err, sendbatch.ErrChannelRequired) {
+			status = http.StatusBadRequest
+		}
+		h.BaseHandler.HandleError(w, "Failed to send batch", err, status)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) GetBatch(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	batchRepo, err := h.ServiceContainer.GetBatchRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get batch repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	getBatchUseCase := getbatch.NewGetBatchUseCase(batchRepo)
+	getBatchController := getbatch.NewGetBatchController(getBatchUseCase)
+
+	query := r.URL.Query()
+	req := getbatch.GetBatchRequest{Cursor: query.Get("cursor")}
+	if v, err := strconv.Atoi(query.Get("limit")); err == nil {
+		req.Limit = v
+	}
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := getBatchController.GetBatch(r.Context(), req)
 	if err != nil {
-		h.BaseHandler.HandleError(w, "Failed to send notification", err, http.StatusInternalServerError)
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, repos.ErrBatchNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, getbatch.ErrInvalidCursor):
+			status = http.StatusBadRequest
+		}
+		h.BaseHandler.HandleError(w, "Failed to get batch", err, status)
 		return
 	}
 
 	h.BaseHandler.RespondWithJSON(w, res)
 }
 
-func NewRouter(serviceContainer *container.ServiceContainer, dbManager *db.Manager, providerCache *cache.GenericCache, queueManager *queue.QueueManager, credentialManager  *credentials.Manager,wpm *workerpool.WorkerPoolManager) *chi.Mux {
+func NewRouter(serviceContainer *container.ServiceContainer, dbManager *db.Manager, providerCache *cache.GenericCache, queueManager *queue.QueueManager, credentialManager *credentials.Manager, wpm *workerpool.WorkerPoolManager) *chi.Mux {
 	b := handler.NewBaseHandler(dbManager)
-	h := NewHandlers(b, serviceContainer, providerCache, queueManager, credentialManager,wpm)
+	h := NewHandlers(b, serviceContainer, providerCache, queueManager, credentialManager, wpm)
 
 	r := chi.NewRouter()
 
 	// Set up routes
 	r.Post("/", h.SendNotification)
+	r.Get("/", h.ListNotifications)
+	r.Post("/batches", h.SendBatch)
+	r.Get("/batches/{id}", h.GetBatch)
+	r.Get("/{id}", h.GetNotification)
+	r.Post("/{id}/cancel", h.CancelNotification)
+	r.Post("/{id}/reschedule", h.RescheduleNotification)
 
 	// Add more routes here
 	// r.Get("/another-route", h.AnotherHandler)
@@ -0,0 +1,308 @@
+package notificationroutes
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"getnoti.com/internal/container"
+	"getnoti.com/internal/notifications/domain"
+	repos "getnoti.com/internal/notifications/repos/implementations"
+	createtopic "getnoti.com/internal/notifications/usecases/create_topic"
+	deletetopic "getnoti.com/internal/notifications/usecases/delete_topic"
+	gettopic "getnoti.com/internal/notifications/usecases/get_topic"
+	gettopicsubscribers "getnoti.com/internal/notifications/usecases/get_topic_subscribers"
+	gettopics "getnoti.com/internal/notifications/usecases/get_topics"
+	sendbatch "getnoti.com/internal/notifications/usecases/send_batch"
+	sendtopic "getnoti.com/internal/notifications/usecases/send_topic"
+	subscribetopic "getnoti.com/internal/notifications/usecases/subscribe_topic"
+	unsubscribetopic "getnoti.com/internal/notifications/usecases/unsubscribe_topic"
+	"getnoti.com/internal/shared/handler"
+	"getnoti.com/internal/shared/middleware"
+	"getnoti.com/internal/shared/utils"
+	"getnoti.com/pkg/cache"
+	"getnoti.com/pkg/credentials"
+	"getnoti.com/pkg/db"
+	"getnoti.com/pkg/queue"
+	"getnoti.com/pkg/workerpool"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *Handlers) CreateTopic(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	topicRepo, err := h.ServiceContainer.GetTopicRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topic repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	createTopicUseCase := createtopic.NewCreateTopicUseCase(topicRepo)
+	createTopicController := createtopic.NewCreateTopicController(createTopicUseCase)
+
+	var req createtopic.CreateTopicRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	res, err := createTopicController.CreateTopic(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to create topic", err, topicErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) GetTopics(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	topicRepo, err := h.ServiceContainer.GetTopicRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topic repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	getTopicsUseCase := gettopics.NewGetTopicsUseCase(topicRepo)
+	getTopicsController := gettopics.NewGetTopicsController(getTopicsUseCase)
+
+	res, err := getTopicsController.GetTopics(r.Context(), gettopics.GetTopicsRequest{})
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topics", err, http.StatusInternalServerError)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) GetTopic(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	topicRepo, err := h.ServiceContainer.GetTopicRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topic repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	getTopicUseCase := gettopic.NewGetTopicUseCase(topicRepo)
+	getTopicController := gettopic.NewGetTopicController(getTopicUseCase)
+
+	var req gettopic.GetTopicRequest
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := getTopicController.GetTopic(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topic", err, topicErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) DeleteTopic(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	topicRepo, err := h.ServiceContainer.GetTopicRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topic repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	deleteTopicUseCase := deletetopic.NewDeleteTopicUseCase(topicRepo)
+	deleteTopicController := deletetopic.NewDeleteTopicController(deleteTopicUseCase)
+
+	var req deletetopic.DeleteTopicRequest
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := deleteTopicController.DeleteTopic(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to delete topic", err, topicErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) SubscribeTopic(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	topicRepo, err := h.ServiceContainer.GetTopicRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topic repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	subscribeTopicUseCase := subscribetopic.NewSubscribeTopicUseCase(topicRepo)
+	subscribeTopicController := subscribetopic.NewSubscribeTopicController(subscribeTopicUseCase)
+
+	var req subscribetopic.SubscribeTopicRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := subscribeTopicController.SubscribeTopic(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to subscribe users", err, topicErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) UnsubscribeTopic(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	topicRepo, err := h.ServiceContainer.GetTopicRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topic repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	unsubscribeTopicUseCase := unsubscribetopic.NewUnsubscribeTopicUseCase(topicRepo)
+	unsubscribeTopicController := unsubscribetopic.NewUnsubscribeTopicController(unsubscribeTopicUseCase)
+
+	var req unsubscribetopic.UnsubscribeTopicRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := unsubscribeTopicController.UnsubscribeTopic(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to unsubscribe users", err, topicErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) GetTopicSubscribers(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	topicRepo, err := h.ServiceContainer.GetTopicRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topic repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	getTopicSubscribersUseCase := gettopicsubscribers.NewGetTopicSubscribersUseCase(topicRepo)
+	getTopicSubscribersController := gettopicsubscribers.NewGetTopicSubscribersController(getTopicSubscribersUseCase)
+
+	query := r.URL.Query()
+	req := gettopicsubscribers.GetTopicSubscribersRequest{Cursor: query.Get("cursor")}
+	if v, err := strconv.Atoi(query.Get("limit")); err == nil {
+		req.Limit = v
+	}
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := getTopicSubscribersController.GetTopicSubscribers(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topic subscribers", err, topicErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) SendTopic(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	topicRepo, err := h.ServiceContainer.GetTopicRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get topic repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	batchRepo, err := h.ServiceContainer.GetBatchRepositoryForTenant(tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get batch repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	sendBatchUseCase := sendbatch.NewSendBatchUseCase(batchRepo, h.ServiceContainer.GetBatchWorker())
+	sendTopicUseCase := sendtopic.NewSendTopicUseCase(topicRepo, sendBatchUseCase)
+	sendTopicController := sendtopic.NewSendTopicController(sendTopicUseCase)
+
+	var req sendtopic.SendTopicRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+	if err := utils.AddTenantIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process tenant ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := sendTopicController.SendTopic(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to send to topic", err, topicErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+// topicErrorStatus maps topic errors to HTTP status codes
+func topicErrorStatus(err error) int {
+	var invalid = []error{
+		domain.ErrInvalidTopic,
+		subscribetopic.ErrNoUsers, subscribetopic.ErrTooManyUsers, subscribetopic.ErrInvalidUserID,
+		unsubscribetopic.ErrNoUsers, unsubscribetopic.ErrTooManyUsers, unsubscribetopic.ErrInvalidUserID,
+		gettopicsubscribers.ErrInvalidCursor,
+		sendtopic.ErrChannelRequired, sendtopic.ErrNoSubscribers,
+	}
+	for _, target := range invalid {
+		if errors.Is(err, target) {
+			return http.StatusBadRequest
+		}
+	}
+
+	switch {
+	case errors.Is(err, repos.ErrTopicNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repos.ErrTopicKeyExists):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func NewTopicRouter(serviceContainer *container.ServiceContainer, dbManager *db.Manager, providerCache *cache.GenericCache, queueManager *queue.QueueManager, credentialManager *credentials.Manager, wpm *workerpool.WorkerPoolManager) *chi.Mux {
+	b := handler.NewBaseHandler(dbManager)
+	h := NewHandlers(b, serviceContainer, providerCache, queueManager, credentialManager, wpm)
+
+	r := chi.NewRouter()
+
+	// Set up routes
+	r.Post("/", h.CreateTopic)
+	r.Get("/", h.GetTopics)
+	r.Get("/{id}", h.GetTopic)
+	r.Delete("/{id}", h.DeleteTopic)
+	r.Post("/{id}/subscribe", h.SubscribeTopic)
+	r.Post("/{id}/unsubscribe", h.UnsubscribeTopic)
+	r.Get("/{id}/subscribers", h.GetTopicSubscribers)
+	r.Post("/{id}/send", h.SendTopic)
+
+	return r
+}
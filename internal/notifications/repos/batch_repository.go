@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+)
+
+type BatchRepository interface {
+	// CreateBatch stores the batch and its recipients in one transaction
+	CreateBatch(ctx context.Context, batch *domain.NotificationBatch, recipients []*domain.BatchRecipient) error
+	// AddBatchRecipients stores another page of recipients for a batch that was already created
+	AddBatchRecipients(ctx context.Context, batchID string, recipients []*domain.BatchRecipient) error
+	// SetBatchTotal corrects the batch's recipient count once all of its recipients are stored
+	SetBatchTotal(ctx context.Context, batchID string, total int) error
+	GetBatch(ctx context.Context, id string) (*domain.NotificationBatch, error)
+	// UpdateBatchRecipient stores the outcome of a recipient's send
+	UpdateBatchRecipient(ctx context.Context, recipient *domain.BatchRecipient) error
+	// GetBatchRecipients returns up to limit recipients after the given position, in request order
+	GetBatchRecipients(ctx context.Context, batchID string, afterPosition, limit int) ([]*domain.BatchRecipient, error)
+	// CountBatchRecipients returns the number of recipients in each status
+	CountBatchRecipients(ctx context.Context, batchID string) (map[domain.NotificationStatus]int, error)
+	// ClaimBatchRecipients claims up to limit pending recipients, oldest batches first, until
+	// claimUntil. Recipients another worker holds a claim on at now are skipped.
+	ClaimBatchRecipients(ctx context.Context, now, claimUntil time.Time, limit int) ([]*domain.BatchRecipient, error)
+	// ReleaseBatchRecipients drops the claim on recipients that were not sent, so they are claimed again
+	ReleaseBatchRecipients(ctx context.Context, recipients []*domain.BatchRecipient) error
+}
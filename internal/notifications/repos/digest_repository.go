@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+)
+
+type DigestRepository interface {
+	// AddDigestEvent appends the event to the user's open digest for the key, opening
+	// digest if there is none, and returns the open digest
+	AddDigestEvent(ctx context.Context, digest *domain.Digest, event *domain.DigestEvent) (*domain.Digest, error)
+	GetDigest(ctx context.Context, id string) (*domain.Digest, error)
+	// GetDueDigests returns up to limit open digests whose window ended at or before the given time
+	GetDueDigests(ctx context.Context, before time.Time, limit int) ([]*domain.Digest, error)
+	// GetDigestEvents returns the digest's events in the order they arrived
+	GetDigestEvents(ctx context.Context, digestID string) ([]*domain.DigestEvent, error)
+	// ClaimDigest moves an open digest to sending so later events open a new digest
+	ClaimDigest(ctx context.Context, id string) error
+	// CompleteDigest stores the outcome of sending a digest
+	CompleteDigest(ctx context.Context, digest *domain.Digest) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"getnoti.com/internal/notifications/domain"
+)
+
+type FrequencyCounterRepository interface {
+	// ReserveFrequencyCounters counts one send for the user against every counter,
+	// or against none when a counter has reached its limit. It returns the full
+	// counter, or nil when the send was counted.
+	ReserveFrequencyCounters(ctx context.Context, userID string, counters []domain.FrequencyCounter) (*domain.FrequencyCounter, error)
+	// ReleaseFrequencyCounters gives back one send reserved against every counter,
+	// for a send that was counted but never delivered
+	ReleaseFrequencyCounters(ctx context.Context, userID string, counters []domain.FrequencyCounter) error
+}
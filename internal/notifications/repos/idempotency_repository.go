@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+)
+
+type IdempotencyRepository interface {
+	// CreateIdempotencyKey stores a new key and reports false if the key already exists
+	CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) (bool, error)
+	GetIdempotencyKey(ctx context.Context, key string) (*domain.IdempotencyKey, error)
+	// CompleteIdempotencyKey stores the response to replay for the key
+	CompleteIdempotencyKey(ctx context.Context, key string, response []byte) error
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+	// DeleteStaleIdempotencyKey removes an unfinished key only while its request
+	// hash and creation time still match stale, and reports whether it did
+	DeleteStaleIdempotencyKey(ctx context.Context, stale *domain.IdempotencyKey) (bool, error)
+	// DeleteExpiredIdempotencyKeys removes keys that expired before the given time
+	DeleteExpiredIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,17 @@
+package repos
+
+import "errors"
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	// ErrStatusConflict means the notification changed status before the update was applied
+	ErrStatusConflict         = errors.New("notification status changed concurrently")
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	ErrBatchNotFound          = errors.New("batch not found")
+	ErrTopicNotFound          = errors.New("topic not found")
+	ErrTopicKeyExists         = errors.New("a topic with this key already exists")
+	ErrDigestNotFound         = errors.New("digest not found")
+	// ErrDigestNotOpen means the digest was claimed for sending by another worker
+	ErrDigestNotOpen     = errors.New("digest is no longer open")
+	ErrInboxItemNotFound = errors.New("inbox item not found")
+)
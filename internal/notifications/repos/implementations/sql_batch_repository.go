@@ -0,0 +1,261 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	"getnoti.com/pkg/db"
+)
+
+const batchRecipientColumns = `batch_id, position, user_id, variables, notification_id, status, error, updated_at`
+
+type sqlBatchRepository struct {
+	db db.Database
+}
+
+// NewBatchRepository creates a new instance of sqlBatchRepository
+func NewBatchRepository(db db.Database) notificationRepos.BatchRepository {
+	return &sqlBatchRepository{db: db}
+}
+
+// CreateBatch inserts the batch and all of its recipients
+func (r *sqlBatchRepository) CreateBatch(ctx context.Context, batch *domain.NotificationBatch, recipients []*domain.BatchRecipient) error {
+	now := time.Now()
+	if batch.CreatedAt.IsZero() {
+		batch.CreatedAt = now
+	}
+	batch.UpdatedAt = now
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO notification_batches (id, tenant_id, type, channel, template_id, topic_id, total_recipients, request, created_at, updated_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = tx.Exec(ctx, query, batch.ID, batch.TenantID, nullString(batch.Type), batch.Channel, nullString(batch.TemplateID), nullString(batch.TopicID), batch.TotalRecipients, batch.Request, batch.CreatedAt, batch.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	if err := insertBatchRecipients(ctx, tx, batch.ID, recipients, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// AddBatchRecipients inserts another page of recipients in one transaction
+func (r *sqlBatchRepository) AddBatchRecipients(ctx context.Context, batchID string, recipients []*domain.BatchRecipient) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertBatchRecipients(ctx, tx, batchID, recipients, time.Now()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func insertBatchRecipients(ctx context.Context, tx db.Transaction, batchID string, recipients []*domain.BatchRecipient, now time.Time) error {
+	query := `INSERT INTO notification_batch_recipients (batch_id, position, user_id, variables, notification_id, status, error, updated_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	for _, recipient := range recipients {
+		variables, err := json.Marshal(recipient.Variables)
+		if err != nil {
+			return fmt.Errorf("failed to marshal recipient variables: %w", err)
+		}
+		recipient.BatchID = batchID
+		recipient.UpdatedAt = now
+		_, err = tx.Exec(ctx, query, recipient.BatchID, recipient.Position, recipient.UserID, variables, nullString(recipient.NotificationID), recipient.Status, nullString(recipient.Error), recipient.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create batch recipient: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetBatchTotal records the number of recipients the batch ended up with
+func (r *sqlBatchRepository) SetBatchTotal(ctx context.Context, batchID string, total int) error {
+	query := `UPDATE notification_batches SET total_recipients = ?, updated_at = ? WHERE id = ?`
+	result, err := r.db.Exec(ctx, query, total, time.Now(), batchID)
+	if err != nil {
+		return fmt.Errorf("failed to update batch total: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update batch total: %w", err)
+	}
+	if rows == 0 {
+		return ErrBatchNotFound
+	}
+	return nil
+}
+
+// GetBatch retrieves a batch by its ID
+func (r *sqlBatchRepository) GetBatch(ctx context.Context, id string) (*domain.NotificationBatch, error) {
+	query := `SELECT id, tenant_id, type, channel, template_id, topic_id, total_recipients, request, created_at, updated_at FROM notification_batches WHERE id = ?`
+	batch := &domain.NotificationBatch{}
+	var batchType, templateID, topicID sql.NullString
+	err := r.db.QueryRow(ctx, query, id).Scan(&batch.ID, &batch.TenantID, &batchType, &batch.Channel, &templateID, &topicID, &batch.TotalRecipients, &batch.Request, &batch.CreatedAt, &batch.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+	batch.Type = batchType.String
+	batch.TemplateID = templateID.String
+	batch.TopicID = topicID.String
+	return batch, nil
+}
+
+// UpdateBatchRecipient records the notification and status of a recipient's send
+func (r *sqlBatchRepository) UpdateBatchRecipient(ctx context.Context, recipient *domain.BatchRecipient) error {
+	recipient.UpdatedAt = time.Now()
+
+	query := `UPDATE notification_batch_recipients SET notification_id = ?, status = ?, error = ?, updated_at = ? WHERE batch_id = ? AND position = ?`
+	_, err := r.db.Exec(ctx, query, nullString(recipient.NotificationID), recipient.Status, nullString(recipient.Error), recipient.UpdatedAt, recipient.BatchID, recipient.Position)
+	if err != nil {
+		return fmt.Errorf("failed to update batch recipient: %w", err)
+	}
+	return nil
+}
+
+// GetBatchRecipients returns a page of recipients ordered by their position in the request
+func (r *sqlBatchRepository) GetBatchRecipients(ctx context.Context, batchID string, afterPosition, limit int) ([]*domain.BatchRecipient, error) {
+	query := `SELECT ` + batchRecipientColumns + `
+              FROM notification_batch_recipients WHERE batch_id = ? AND position > ? ORDER BY position ASC LIMIT ?`
+	rows, err := r.db.Query(ctx, query, batchID, afterPosition, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch recipients: %w", err)
+	}
+	defer rows.Close()
+
+	recipients := []*domain.BatchRecipient{}
+	for rows.Next() {
+		recipient, err := scanBatchRecipient(rows)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate batch recipients: %w", err)
+	}
+	return recipients, nil
+}
+
+// scanBatchRecipient reads a recipient selected with batchRecipientColumns
+func scanBatchRecipient(scanner interface{ Scan(dest ...any) error }) (*domain.BatchRecipient, error) {
+	recipient := &domain.BatchRecipient{}
+	var variables []byte
+	var notificationID, errorMessage sql.NullString
+	if err := scanner.Scan(&recipient.BatchID, &recipient.Position, &recipient.UserID, &variables, &notificationID, &recipient.Status, &errorMessage, &recipient.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan batch recipient: %w", err)
+	}
+	if len(variables) > 0 {
+		if err := json.Unmarshal(variables, &recipient.Variables); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal recipient variables: %w", err)
+		}
+	}
+	recipient.NotificationID = notificationID.String
+	recipient.Error = errorMessage.String
+	return recipient, nil
+}
+
+// CountBatchRecipients counts a batch's recipients by status
+func (r *sqlBatchRepository) CountBatchRecipients(ctx context.Context, batchID string) (map[domain.NotificationStatus]int, error) {
+	query := `SELECT status, COUNT(*) FROM notification_batch_recipients WHERE batch_id = ? GROUP BY status`
+	rows, err := r.db.Query(ctx, query, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count batch recipients: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[domain.NotificationStatus]int)
+	for rows.Next() {
+		var status domain.NotificationStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan batch recipient count: %w", err)
+		}
+		counts[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate batch recipient counts: %w", err)
+	}
+	return counts, nil
+}
+
+// ClaimBatchRecipients reads candidate recipients and claims each one with a
+// conditional update, so two workers never claim the same recipient
+func (r *sqlBatchRepository) ClaimBatchRecipients(ctx context.Context, now, claimUntil time.Time, limit int) ([]*domain.BatchRecipient, error) {
+	query := `SELECT ` + batchRecipientColumns + `
+              FROM notification_batch_recipients
+              WHERE status = ? AND (claimed_until IS NULL OR claimed_until < ?)
+              ORDER BY updated_at ASC, batch_id ASC, position ASC LIMIT ?`
+	rows, err := r.db.Query(ctx, query, domain.RecipientStatusPending, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unclaimed batch recipients: %w", err)
+	}
+	candidates := []*domain.BatchRecipient{}
+	for rows.Next() {
+		recipient, err := scanBatchRecipient(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		candidates = append(candidates, recipient)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate unclaimed batch recipients: %w", err)
+	}
+
+	query = `UPDATE notification_batch_recipients SET claimed_until = ?
+             WHERE batch_id = ? AND position = ? AND status = ? AND (claimed_until IS NULL OR claimed_until < ?)`
+	claimed := make([]*domain.BatchRecipient, 0, len(candidates))
+	for _, recipient := range candidates {
+		result, err := r.db.Exec(ctx, query, claimUntil, recipient.BatchID, recipient.Position, domain.RecipientStatusPending, now)
+		if err != nil {
+			return claimed, fmt.Errorf("failed to claim batch recipient: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return claimed, fmt.Errorf("failed to claim batch recipient: %w", err)
+		}
+		// Another worker claimed or sent the recipient after it was read
+		if rows == 1 {
+			claimed = append(claimed, recipient)
+		}
+	}
+	return claimed, nil
+}
+
+// ReleaseBatchRecipients clears the claim on recipients that are still pending
+func (r *sqlBatchRepository) ReleaseBatchRecipients(ctx context.Context, recipients []*domain.BatchRecipient) error {
+	query := `UPDATE notification_batch_recipients SET claimed_until = NULL WHERE batch_id = ? AND position = ? AND status = ?`
+	for _, recipient := range recipients {
+		if _, err := r.db.Exec(ctx, query, recipient.BatchID, recipient.Position, domain.RecipientStatusPending); err != nil {
+			return fmt.Errorf("failed to release batch recipient: %w", err)
+		}
+	}
+	return nil
+}
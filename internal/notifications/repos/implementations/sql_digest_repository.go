@@ -0,0 +1,191 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	"getnoti.com/pkg/db"
+)
+
+type sqlDigestRepository struct {
+	db db.Database
+}
+
+// NewDigestRepository creates a new instance of sqlDigestRepository
+func NewDigestRepository(db db.Database) notificationRepos.DigestRepository {
+	return &sqlDigestRepository{db: db}
+}
+
+// digestColumns is the column list read by scanDigest
+const digestColumns = `id, user_id, digest_key, channel, template_id, category, status, send_at, event_count, notification_id, error, created_at, updated_at`
+
+// AddDigestEvent appends the event to the user's open digest for the key in one
+// transaction. When the user has no open digest, digest is opened with the
+// event as its first; its window, channel and template apply until it is sent.
+func (r *sqlDigestRepository) AddDigestEvent(ctx context.Context, digest *domain.Digest, event *domain.DigestEvent) (*domain.Digest, error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal digest event: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	digest.Status = domain.DigestStatusOpen
+	digest.CreatedAt = now
+	digest.UpdatedAt = now
+
+	query := `INSERT INTO digests (id, user_id, digest_key, channel, template_id, category, status, send_at, event_count, created_at, updated_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0, ?, ?) ON CONFLICT (user_id, digest_key) WHERE status = 'open' DO NOTHING`
+	if _, err := tx.Exec(ctx, query, digest.ID, digest.UserID, digest.Key, digest.Channel, digest.TemplateID, nullString(digest.Category), digest.Status, digest.SendAt, digest.CreatedAt, digest.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to open digest: %w", err)
+	}
+
+	query = `UPDATE digests SET event_count = event_count + 1, updated_at = ? WHERE user_id = ? AND digest_key = ? AND status = ?`
+	if _, err := tx.Exec(ctx, query, now, digest.UserID, digest.Key, domain.DigestStatusOpen); err != nil {
+		return nil, fmt.Errorf("failed to update digest: %w", err)
+	}
+
+	query = `SELECT ` + digestColumns + ` FROM digests WHERE user_id = ? AND digest_key = ? AND status = ?`
+	open, err := scanDigest(tx.QueryRow(ctx, query, digest.UserID, digest.Key, domain.DigestStatusOpen))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open digest: %w", err)
+	}
+	open.TenantID = digest.TenantID
+
+	event.DigestID = open.ID
+	event.CreatedAt = now
+	query = `INSERT INTO digest_events (id, digest_id, data, created_at) VALUES (?, ?, ?, ?)`
+	if _, err := tx.Exec(ctx, query, event.ID, event.DigestID, data, event.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to add digest event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return open, nil
+}
+
+// GetDigest retrieves a digest by its ID
+func (r *sqlDigestRepository) GetDigest(ctx context.Context, id string) (*domain.Digest, error) {
+	query := `SELECT ` + digestColumns + ` FROM digests WHERE id = ?`
+	digest, err := scanDigest(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDigestNotFound
+		}
+		return nil, fmt.Errorf("failed to get digest: %w", err)
+	}
+	return digest, nil
+}
+
+// GetDueDigests returns open digests whose window has ended, earliest first
+func (r *sqlDigestRepository) GetDueDigests(ctx context.Context, before time.Time, limit int) ([]*domain.Digest, error) {
+	query := `SELECT ` + digestColumns + ` FROM digests
+              WHERE status = ? AND send_at <= ? ORDER BY send_at ASC, id ASC LIMIT ?`
+	rows, err := r.db.Query(ctx, query, domain.DigestStatusOpen, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due digests: %w", err)
+	}
+	defer rows.Close()
+
+	digests := []*domain.Digest{}
+	for rows.Next() {
+		digest, err := scanDigest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan digest: %w", err)
+		}
+		digests = append(digests, digest)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate digests: %w", err)
+	}
+	return digests, nil
+}
+
+// GetDigestEvents returns the digest's events, oldest first
+func (r *sqlDigestRepository) GetDigestEvents(ctx context.Context, digestID string) ([]*domain.DigestEvent, error) {
+	query := `SELECT id, digest_id, data, created_at FROM digest_events WHERE digest_id = ? ORDER BY created_at ASC, id ASC`
+	rows, err := r.db.Query(ctx, query, digestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*domain.DigestEvent{}
+	for rows.Next() {
+		event := &domain.DigestEvent{}
+		var data []byte
+		if err := rows.Scan(&event.ID, &event.DigestID, &data, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan digest event: %w", err)
+		}
+		if err := json.Unmarshal(data, &event.Data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal digest event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate digest events: %w", err)
+	}
+	return events, nil
+}
+
+// ClaimDigest closes an open digest for sending. Digests that were already
+// claimed are left alone and ErrDigestNotOpen is returned.
+func (r *sqlDigestRepository) ClaimDigest(ctx context.Context, id string) error {
+	query := `UPDATE digests SET status = ?, updated_at = ? WHERE id = ? AND status = ?`
+	result, err := r.db.Exec(ctx, query, domain.DigestStatusSending, time.Now(), id, domain.DigestStatusOpen)
+	if err != nil {
+		return fmt.Errorf("failed to claim digest: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("%w: %s", ErrDigestNotOpen, id)
+	}
+	return nil
+}
+
+// CompleteDigest stores the digest's final status and the notification that delivered it
+func (r *sqlDigestRepository) CompleteDigest(ctx context.Context, digest *domain.Digest) error {
+	digest.UpdatedAt = time.Now()
+
+	query := `UPDATE digests SET status = ?, notification_id = ?, error = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.Exec(ctx, query, digest.Status, nullString(digest.NotificationID), nullString(digest.Error), digest.UpdatedAt, digest.ID)
+	if err != nil {
+		return fmt.Errorf("failed to complete digest: %w", err)
+	}
+	return nil
+}
+
+func scanDigest(scanner interface{}) (*domain.Digest, error) {
+	digest := &domain.Digest{}
+	var category, notificationID, digestError sql.NullString
+	dest := []interface{}{&digest.ID, &digest.UserID, &digest.Key, &digest.Channel, &digest.TemplateID, &category, &digest.Status, &digest.SendAt, &digest.EventCount, &notificationID, &digestError, &digest.CreatedAt, &digest.UpdatedAt}
+
+	var err error
+	switch s := scanner.(type) {
+	case *sql.Row:
+		err = s.Scan(dest...)
+	case *sql.Rows:
+		err = s.Scan(dest...)
+	default:
+		return nil, fmt.Errorf("unsupported scanner type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	digest.Category = category.String
+	digest.NotificationID = notificationID.String
+	digest.Error = digestError.String
+	return digest, nil
+}
@@ -0,0 +1,107 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	"getnoti.com/pkg/db"
+)
+
+type sqlFrequencyCounterRepository struct {
+	db db.Database
+}
+
+// NewFrequencyCounterRepository creates a new instance of sqlFrequencyCounterRepository
+func NewFrequencyCounterRepository(db db.Database) notificationRepos.FrequencyCounterRepository {
+	return &sqlFrequencyCounterRepository{db: db}
+}
+
+// ReserveFrequencyCounters increments every counter in one transaction. Each
+// increment only applies below the counter's limit, so concurrent sends from
+// other instances can never push a window past it; when one counter is full the
+// transaction is rolled back and no counter changes. Earlier windows of the
+// same caps are removed as new ones are reserved.
+func (r *sqlFrequencyCounterRepository) ReserveFrequencyCounters(ctx context.Context, userID string, counters []domain.FrequencyCounter) (*domain.FrequencyCounter, error) {
+	if len(counters) == 0 {
+		return nil, nil
+	}
+
+	// Lock counters in a fixed order so concurrent reservations cannot deadlock
+	ordered := make([]domain.FrequencyCounter, len(counters))
+	copy(ordered, counters)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].CapKey < ordered[j].CapKey })
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := range ordered {
+		counter := ordered[i]
+		windowStart := counter.WindowStart.UTC()
+
+		query := `DELETE FROM frequency_counters WHERE user_id = ? AND cap_key = ? AND window_start < ?`
+		if _, err := tx.Exec(ctx, query, userID, counter.CapKey, windowStart); err != nil {
+			return nil, fmt.Errorf("failed to remove expired frequency counters: %w", err)
+		}
+
+		query = `INSERT INTO frequency_counters (user_id, cap_key, window_start, count) VALUES (?, ?, ?, 0)
+                 ON CONFLICT (user_id, cap_key, window_start) DO NOTHING`
+		if _, err := tx.Exec(ctx, query, userID, counter.CapKey, windowStart); err != nil {
+			return nil, fmt.Errorf("failed to open frequency counter: %w", err)
+		}
+
+		query = `UPDATE frequency_counters SET count = count + 1 WHERE user_id = ? AND cap_key = ? AND window_start = ? AND count < ?`
+		result, err := tx.Exec(ctx, query, userID, counter.CapKey, windowStart, counter.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve frequency counter: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve frequency counter: %w", err)
+		}
+		if rows == 0 {
+			return &counter, nil
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil, nil
+}
+
+// ReleaseFrequencyCounters decrements every counter in one transaction. Counters
+// never drop below zero, and a window that has since been removed is left alone.
+func (r *sqlFrequencyCounterRepository) ReleaseFrequencyCounters(ctx context.Context, userID string, counters []domain.FrequencyCounter) error {
+	if len(counters) == 0 {
+		return nil
+	}
+
+	// Lock counters in the same order as reservations
+	ordered := make([]domain.FrequencyCounter, len(counters))
+	copy(ordered, counters)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].CapKey < ordered[j].CapKey })
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, counter := range ordered {
+		query := `UPDATE frequency_counters SET count = count - 1 WHERE user_id = ? AND cap_key = ? AND window_start = ? AND count > 0`
+		if _, err := tx.Exec(ctx, query, userID, counter.CapKey, counter.WindowStart.UTC()); err != nil {
+			return fmt.Errorf("failed to release frequency counter: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	"getnoti.com/pkg/db"
+)
+
+type sqlIdempotencyRepository struct {
+	db db.Database
+}
+
+// NewIdempotencyRepository creates a new instance of sqlIdempotencyRepository
+func NewIdempotencyRepository(db db.Database) notificationRepos.IdempotencyRepository {
+	return &sqlIdempotencyRepository{db: db}
+}
+
+// CreateIdempotencyKey inserts the key unless it already exists
+func (r *sqlIdempotencyRepository) CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) (bool, error) {
+	query := `INSERT INTO idempotency_keys (idempotency_key, request_hash, response, created_at, expires_at)
+              VALUES (?, ?, NULL, ?, ?) ON CONFLICT (idempotency_key) DO NOTHING`
+	result, err := r.db.Exec(ctx, query, key.Key, key.RequestHash, key.CreatedAt, key.ExpiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create idempotency key: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to create idempotency key: %w", err)
+	}
+	return rows == 1, nil
+}
+
+// GetIdempotencyKey retrieves a key
+func (r *sqlIdempotencyRepository) GetIdempotencyKey(ctx context.Context, key string) (*domain.IdempotencyKey, error) {
+	query := `SELECT idempotency_key, request_hash, response, created_at, expires_at FROM idempotency_keys WHERE idempotency_key = ?`
+	record := &domain.IdempotencyKey{}
+	var response []byte
+	err := r.db.QueryRow(ctx, query, key).Scan(&record.Key, &record.RequestHash, &response, &record.CreatedAt, &record.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrIdempotencyKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	record.Response = response
+	return record, nil
+}
+
+// CompleteIdempotencyKey stores the response for the key
+func (r *sqlIdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, key string, response []byte) error {
+	query := `UPDATE idempotency_keys SET response = ? WHERE idempotency_key = ?`
+	_, err := r.db.Exec(ctx, query, response, key)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteIdempotencyKey removes a key
+func (r *sqlIdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE idempotency_key = ?`
+	_, err := r.db.Exec(ctx, query, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteStaleIdempotencyKey removes an unfinished key that has not changed since
+// it was read, so only one of several concurrent retries can take it over
+func (r *sqlIdempotencyRepository) DeleteStaleIdempotencyKey(ctx context.Context, stale *domain.IdempotencyKey) (bool, error) {
+	query := `DELETE FROM idempotency_keys
+              WHERE idempotency_key = ? AND request_hash = ? AND created_at = ? AND response IS NULL`
+	result, err := r.db.Exec(ctx, query, stale.Key, stale.RequestHash, stale.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete stale idempotency key: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete stale idempotency key: %w", err)
+	}
+	return rows == 1, nil
+}
+
+// DeleteExpiredIdempotencyKeys removes keys past their retention window
+func (r *sqlIdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= ?`
+	result, err := r.db.Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,171 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	"getnoti.com/pkg/db"
+)
+
+type sqlInboxRepository struct {
+	db db.Database
+}
+
+// NewInboxRepository creates a new instance of sqlInboxRepository
+func NewInboxRepository(db db.Database) notificationRepos.InboxRepository {
+	return &sqlInboxRepository{db: db}
+}
+
+// CreateInboxItem stores a new item in the user's inbox
+func (r *sqlInboxRepository) CreateInboxItem(ctx context.Context, item *domain.InboxItem) error {
+	var data []byte
+	if len(item.Data) > 0 {
+		var err error
+		if data, err = json.Marshal(item.Data); err != nil {
+			return fmt.Errorf("failed to marshal inbox item data: %w", err)
+		}
+	}
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+
+	query := `INSERT INTO inbox_items (id, user_id, notification_id, category, title, body, data, created_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := r.db.Exec(ctx, query,
+		item.ID,
+		item.UserID,
+		nullString(item.NotificationID),
+		nullString(item.Category),
+		nullString(item.Title),
+		item.Body,
+		data,
+		item.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create inbox item: %w", err)
+	}
+	return nil
+}
+
+// GetInboxItems returns the user's items matching the filter, newest first.
+// Pages continue after filter.After using the (created_at, id) keyset, so
+// items arriving while the user pages do not shift later pages.
+func (r *sqlInboxRepository) GetInboxItems(ctx context.Context, filter notificationRepos.InboxFilter) ([]*domain.InboxItem, error) {
+	query := `SELECT id, user_id, notification_id, category, title, body, data, read_at, archived_at, created_at
+              FROM inbox_items WHERE user_id = ?`
+	args := []interface{}{filter.UserID}
+
+	switch filter.Status {
+	case domain.InboxStatusUnread:
+		query += " AND archived_at IS NULL AND read_at IS NULL"
+	case domain.InboxStatusRead:
+		query += " AND archived_at IS NULL AND read_at IS NOT NULL"
+	case domain.InboxStatusArchived:
+		query += " AND archived_at IS NOT NULL"
+	default:
+		query += " AND archived_at IS NULL"
+	}
+	if filter.After != nil {
+		query += " AND (created_at < ? OR (created_at = ? AND id < ?))"
+		args = append(args, filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID)
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inbox items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*domain.InboxItem{}
+	for rows.Next() {
+		item := &domain.InboxItem{}
+		var notificationID, category, title sql.NullString
+		var readAt, archivedAt sql.NullTime
+		var data []byte
+		err := rows.Scan(&item.ID, &item.UserID, &notificationID, &category, &title, &item.Body, &data, &readAt, &archivedAt, &item.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan inbox item: %w", err)
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &item.Data); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal inbox item data: %w", err)
+			}
+		}
+		item.NotificationID = notificationID.String
+		item.Category = category.String
+		item.Title = title.String
+		if readAt.Valid {
+			item.ReadAt = &readAt.Time
+		}
+		if archivedAt.Valid {
+			item.ArchivedAt = &archivedAt.Time
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate inbox items: %w", err)
+	}
+	return items, nil
+}
+
+// MarkInboxItemRead marks one of the user's items read
+func (r *sqlInboxRepository) MarkInboxItemRead(ctx context.Context, userID, id string, at time.Time) error {
+	query := `UPDATE inbox_items SET read_at = COALESCE(read_at, ?) WHERE id = ? AND user_id = ?`
+	return r.updateInboxItem(ctx, query, at, id, userID)
+}
+
+// MarkAllInboxItemsRead marks every unread item of the user read
+func (r *sqlInboxRepository) MarkAllInboxItemsRead(ctx context.Context, userID string, at time.Time) (int, error) {
+	query := `UPDATE inbox_items SET read_at = ? WHERE user_id = ? AND read_at IS NULL AND archived_at IS NULL`
+	result, err := r.db.Exec(ctx, query, at, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark inbox items read: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark inbox items read: %w", err)
+	}
+	return int(rows), nil
+}
+
+// ArchiveInboxItem removes one of the user's items from the feed
+func (r *sqlInboxRepository) ArchiveInboxItem(ctx context.Context, userID, id string, at time.Time) error {
+	query := `UPDATE inbox_items SET archived_at = COALESCE(archived_at, ?) WHERE id = ? AND user_id = ?`
+	return r.updateInboxItem(ctx, query, at, id, userID)
+}
+
+// CountUnreadInboxItems counts the user's unread items that are not archived
+func (r *sqlInboxRepository) CountUnreadInboxItems(ctx context.Context, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM inbox_items WHERE user_id = ? AND read_at IS NULL AND archived_at IS NULL`
+	var count int
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread inbox items: %w", err)
+	}
+	return count, nil
+}
+
+// updateInboxItem runs an update of a single item and reports a missing item
+func (r *sqlInboxRepository) updateInboxItem(ctx context.Context, query string, at time.Time, id, userID string) error {
+	result, err := r.db.Exec(ctx, query, at, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update inbox item: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update inbox item: %w", err)
+	}
+	if rows == 0 {
+		return ErrInboxItemNotFound
+	}
+	return nil
+}
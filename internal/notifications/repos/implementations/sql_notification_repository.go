@@ -2,8 +2,12 @@ package repos
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"getnoti.com/internal/notifications/domain"
 	notificationRepos "getnoti.com/internal/notifications/repos"
@@ -26,32 +30,98 @@ func (r *sqlNotificationRepository) CreateNotification(ctx context.Context, noti
 		return fmt.Errorf("failed to marshal variables: %w", err)
 	}
 
-	query := `INSERT INTO notifications (id, tenant_id, user_id, type, channel, template_id, status, content, variables) 
-              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err = r.db.Exec(ctx, query, notification.ID, notification.TenantID, notification.UserID, notification.Type, notification.Channel, notification.TemplateID, notification.Status, notification.Content, variables)
+	now := time.Now()
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = now
+	}
+	notification.UpdatedAt = now
+
+	query := `INSERT INTO notifications (id, tenant_id, user_id, type, category, override_preferences, urgent, channel, template_id, template_version, locale, status, content, provider_id, variables, scheduled_for, created_at, updated_at) 
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = r.db.Exec(ctx, query, notification.ID, notification.TenantID, notification.UserID, notification.Type, nullString(notification.Category), notification.OverridePreferences, notification.Urgent, notification.Channel, notification.TemplateID, nullInt(notification.TemplateVersion), nullString(notification.Locale), notification.Status, notification.Content, nullString(notification.ProviderID), variables, nullTime(notification.ScheduledFor), notification.CreatedAt, notification.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create notification: %w", err)
 	}
 	return nil
 }
 
+// notificationColumns is the column list read by scanNotification
+const notificationColumns = `id, tenant_id, user_id, type, category, override_preferences, urgent, channel, template_id, template_version, locale, status, content, provider_id, variables, scheduled_for, created_at, updated_at`
+
 // GetNotificationByID retrieves a notification by its ID
 func (r *sqlNotificationRepository) GetNotificationByID(ctx context.Context, id string) (*domain.Notification, error) {
-	query := `SELECT id, tenant_id, user_id, type, channel, template_id, status, content, variables FROM notifications WHERE id = ?`
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE id = ?`
 	row := r.db.QueryRow(ctx, query, id)
-	notification := &domain.Notification{}
-	var variables []byte
-	err := row.Scan(&notification.ID, &notification.TenantID, &notification.UserID, &notification.Type, &notification.Channel, &notification.TemplateID, &notification.Status, &notification.Content, &variables)
+	notification, err := scanNotification(row)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotificationNotFound
+		}
 		return nil, fmt.Errorf("failed to get notification: %w", err)
 	}
+	return notification, nil
+}
+
+// ListNotifications returns the notifications matching the filter, newest
+// first. Pages continue after filter.After using the (created_at, id) keyset,
+// so results stay stable while new notifications are written.
+func (r *sqlNotificationRepository) ListNotifications(ctx context.Context, filter notificationRepos.NotificationFilter) ([]*domain.Notification, error) {
+	var conditions []string
+	var args []interface{}
 
-	err = json.Unmarshal(variables, &notification.Variables)
+	for _, f := range []struct{ column, value string }{
+		{"user_id", filter.UserID},
+		{"channel", filter.Channel},
+		{"status", filter.Status},
+		{"template_id", filter.TemplateID},
+		{"provider_id", filter.ProviderID},
+	} {
+		if f.value != "" {
+			conditions = append(conditions, f.column+" = ?")
+			args = append(args, f.value)
+		}
+	}
+	if !filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.CreatedTo)
+	}
+	if filter.After != nil {
+		conditions = append(conditions, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID)
+	}
+
+	query := `SELECT ` + notificationColumns + ` FROM notifications`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
 	}
+	defer rows.Close()
 
-	return notification, nil
+	notifications := []*domain.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notifications: %w", err)
+	}
+	return notifications, nil
 }
 
 // UpdateNotification updates an existing notification in the database
@@ -61,14 +131,133 @@ func (r *sqlNotificationRepository) UpdateNotification(ctx context.Context, noti
 		return fmt.Errorf("failed to marshal variables: %w", err)
 	}
 
-	query := `UPDATE notifications SET tenant_id = ?, user_id = ?, type = ?, channel = ?, template_id = ?, status = ?, content = ?, variables = ? WHERE id = ?`
-	_, err = r.db.Exec(ctx, query, notification.TenantID, notification.UserID, notification.Type, notification.Channel, notification.TemplateID, notification.Status, notification.Content, variables, notification.ID)
+	notification.UpdatedAt = time.Now()
+
+	query := `UPDATE notifications SET tenant_id = ?, user_id = ?, type = ?, category = ?, override_preferences = ?, urgent = ?, channel = ?, template_id = ?, template_version = ?, locale = ?, status = ?, content = ?, provider_id = ?, variables = ?, scheduled_for = ?, updated_at = ? WHERE id = ?`
+	_, err = r.db.Exec(ctx, query, notification.TenantID, notification.UserID, notification.Type, nullString(notification.Category), notification.OverridePreferences, notification.Urgent, notification.Channel, notification.TemplateID, nullInt(notification.TemplateVersion), nullString(notification.Locale), notification.Status, notification.Content, nullString(notification.ProviderID), variables, nullTime(notification.ScheduledFor), notification.UpdatedAt, notification.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update notification: %w", err)
 	}
 	return nil
 }
 
+// GetDueScheduledNotifications returns scheduled notifications whose send time
+// is at or before the given time, earliest first. Released notifications whose
+// claim expired by then without leaving pending are returned too, so a release
+// interrupted by a crash is retried.
+func (r *sqlNotificationRepository) GetDueScheduledNotifications(ctx context.Context, before time.Time, limit int) ([]*domain.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications
+              WHERE (status = ? AND scheduled_for <= ?) OR (status = ? AND claimed_until < ?)
+              ORDER BY scheduled_for ASC, id ASC LIMIT ?`
+	rows, err := r.db.Query(ctx, query, domain.StatusScheduled, before, domain.StatusPending, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due scheduled notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []*domain.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// ClaimNotification moves a scheduled notification to pending, claimed until
+// claimUntil, and records the attempt. A pending notification can only be
+// claimed again once its claim expired before now. Notifications that were
+// cancelled or claimed elsewhere are left alone and ErrStatusConflict is returned.
+func (r *sqlNotificationRepository) ClaimNotification(ctx context.Context, attempt *domain.NotificationAttempt, now, claimUntil time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE notifications SET status = ?, claimed_until = ?, updated_at = ?
+              WHERE id = ? AND (status = ? OR (status = ? AND claimed_until < ?))`
+	result, err := tx.Exec(ctx, query, domain.StatusPending, claimUntil, attempt.CreatedAt, attempt.NotificationID, domain.StatusScheduled, domain.StatusPending, now)
+	if err != nil {
+		return fmt.Errorf("failed to claim notification: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("%w: %s is no longer %s or already claimed", ErrStatusConflict, attempt.NotificationID, domain.StatusScheduled)
+	}
+
+	if err := insertNotificationAttempt(ctx, tx, attempt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RescheduleNotification moves a scheduled notification to a new send time and
+// records the attempt. Notifications that were already released or cancelled
+// are left alone and ErrStatusConflict is returned.
+func (r *sqlNotificationRepository) RescheduleNotification(ctx context.Context, attempt *domain.NotificationAttempt, scheduledFor time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE notifications SET scheduled_for = ?, updated_at = ? WHERE id = ? AND status = ?`
+	result, err := tx.Exec(ctx, query, scheduledFor, attempt.CreatedAt, attempt.NotificationID, domain.StatusScheduled)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule notification: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("%w: %s is no longer %s", ErrStatusConflict, attempt.NotificationID, domain.StatusScheduled)
+	}
+
+	if err := insertNotificationAttempt(ctx, tx, attempt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// DeferNotification moves a pending notification back to scheduled until
+// scheduledFor and records the attempt, so the scheduler releases it again.
+// Notifications that have left pending are left alone and ErrStatusConflict is returned.
+func (r *sqlNotificationRepository) DeferNotification(ctx context.Context, attempt *domain.NotificationAttempt, scheduledFor time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE notifications SET status = ?, scheduled_for = ?, updated_at = ? WHERE id = ? AND status = ?`
+	result, err := tx.Exec(ctx, query, domain.StatusScheduled, scheduledFor, attempt.CreatedAt, attempt.NotificationID, domain.StatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to defer notification: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("%w: %s is no longer %s", ErrStatusConflict, attempt.NotificationID, domain.StatusPending)
+	}
+
+	if err := insertNotificationAttempt(ctx, tx, attempt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // DeleteNotification deletes a notification from the database
 func (r *sqlNotificationRepository) DeleteNotification(ctx context.Context, id string) error {
 	query := `DELETE FROM notifications WHERE id = ?`
@@ -78,3 +267,122 @@ func (r *sqlNotificationRepository) DeleteNotification(ctx context.Context, id s
 	}
 	return nil
 }
+
+// UpdateNotificationStatus moves the notification to the attempt's status and
+// records the attempt in one transaction. The update only applies while the
+// notification is still in the from status, so concurrent workers cannot
+// overwrite each other's transitions.
+func (r *sqlNotificationRepository) UpdateNotificationStatus(ctx context.Context, from domain.NotificationStatus, attempt *domain.NotificationAttempt) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE notifications SET status = ?, provider_id = COALESCE(?, provider_id), updated_at = ? WHERE id = ? AND status = ?`
+	result, err := tx.Exec(ctx, query, attempt.Status, nullString(attempt.ProviderID), attempt.CreatedAt, attempt.NotificationID, from)
+	if err != nil {
+		return fmt.Errorf("failed to update notification status: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("%w: %s is no longer %s", ErrStatusConflict, attempt.NotificationID, from)
+	}
+
+	if err := insertNotificationAttempt(ctx, tx, attempt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CreateNotificationAttempt records an attempt without changing the notification status
+func (r *sqlNotificationRepository) CreateNotificationAttempt(ctx context.Context, attempt *domain.NotificationAttempt) error {
+	return insertNotificationAttempt(ctx, r.db, attempt)
+}
+
+// GetNotificationAttempts returns a notification's attempts, oldest first
+func (r *sqlNotificationRepository) GetNotificationAttempts(ctx context.Context, notificationID string) ([]*domain.NotificationAttempt, error) {
+	query := `SELECT id, notification_id, status, provider_id, provider_message_id, reason, created_at
+              FROM notification_attempts WHERE notification_id = ? ORDER BY created_at ASC`
+	rows, err := r.db.Query(ctx, query, notificationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []*domain.NotificationAttempt{}
+	for rows.Next() {
+		attempt := &domain.NotificationAttempt{}
+		var providerID, providerMessageID, reason sql.NullString
+		if err := rows.Scan(&attempt.ID, &attempt.NotificationID, &attempt.Status, &providerID, &providerMessageID, &reason, &attempt.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification attempt: %w", err)
+		}
+		attempt.ProviderID = providerID.String
+		attempt.ProviderMessageID = providerMessageID.String
+		attempt.Reason = reason.String
+		attempts = append(attempts, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate notification attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// insertNotificationAttempt stores an attempt using either the database or a transaction
+func insertNotificationAttempt(ctx context.Context, exec interface {
+	Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}, attempt *domain.NotificationAttempt) error {
+	query := `INSERT INTO notification_attempts (id, notification_id, status, provider_id, provider_message_id, reason, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	_, err := exec.Exec(ctx, query, attempt.ID, attempt.NotificationID, attempt.Status, nullString(attempt.ProviderID), nullString(attempt.ProviderMessageID), nullString(attempt.Reason), attempt.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create notification attempt: %w", err)
+	}
+	return nil
+}
+
+func scanNotification(scanner interface{ Scan(...any) error }) (*domain.Notification, error) {
+	notification := &domain.Notification{}
+	var variables []byte
+	var providerID, locale, category sql.NullString
+	var templateVersion sql.NullInt64
+	var scheduledFor sql.NullTime
+	dest := []interface{}{&notification.ID, &notification.TenantID, &notification.UserID, &notification.Type, &category, &notification.OverridePreferences, &notification.Urgent, &notification.Channel, &notification.TemplateID, &templateVersion, &locale, &notification.Status, &notification.Content, &providerID, &variables, &scheduledFor, &notification.CreatedAt, &notification.UpdatedAt}
+
+	if err := scanner.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	notification.ProviderID = providerID.String
+	notification.TemplateVersion = int(templateVersion.Int64)
+	notification.Locale = locale.String
+	notification.Category = category.String
+	if scheduledFor.Valid {
+		notification.ScheduledFor = &scheduledFor.Time
+	}
+
+	if err := json.Unmarshal(variables, &notification.Variables); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
+	}
+	return notification, nil
+}
+
+// nullString maps empty strings to NULL for nullable columns
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullInt maps zero to NULL for nullable integer columns
+func nullInt(n int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(n), Valid: n != 0}
+}
+
+// nullTime maps a nil time to NULL for nullable timestamp columns
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
@@ -0,0 +1,198 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	"getnoti.com/pkg/db"
+)
+
+type sqlTopicRepository struct {
+	db db.Database
+}
+
+// NewTopicRepository creates a new instance of sqlTopicRepository
+func NewTopicRepository(db db.Database) notificationRepos.TopicRepository {
+	return &sqlTopicRepository{db: db}
+}
+
+// CreateTopic inserts the topic unless its key is already used
+func (r *sqlTopicRepository) CreateTopic(ctx context.Context, topic *domain.Topic) error {
+	now := time.Now()
+	topic.CreatedAt = now
+	topic.UpdatedAt = now
+
+	query := `INSERT INTO topics (id, topic_key, name, description, created_at, updated_at)
+              VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (topic_key) DO NOTHING`
+	result, err := r.db.Exec(ctx, query, topic.ID, topic.Key, topic.Name, nullString(topic.Description), topic.CreatedAt, topic.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create topic: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("%w: %s", ErrTopicKeyExists, topic.Key)
+	}
+	return nil
+}
+
+// GetTopicByID retrieves a topic by its ID
+func (r *sqlTopicRepository) GetTopicByID(ctx context.Context, id string) (*domain.Topic, error) {
+	query := `SELECT id, topic_key, name, description, created_at, updated_at FROM topics WHERE id = ?`
+	topic, err := scanTopic(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTopicNotFound
+		}
+		return nil, fmt.Errorf("failed to get topic: %w", err)
+	}
+	return topic, nil
+}
+
+// GetTopics returns all topics ordered by key
+func (r *sqlTopicRepository) GetTopics(ctx context.Context) ([]*domain.Topic, error) {
+	query := `SELECT id, topic_key, name, description, created_at, updated_at FROM topics ORDER BY topic_key`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topics: %w", err)
+	}
+	defer rows.Close()
+
+	topics := []*domain.Topic{}
+	for rows.Next() {
+		topic, err := scanTopic(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan topic: %w", err)
+		}
+		topics = append(topics, topic)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate topics: %w", err)
+	}
+	return topics, nil
+}
+
+// DeleteTopic deletes a topic; its subscriptions are removed with it
+func (r *sqlTopicRepository) DeleteTopic(ctx context.Context, id string) error {
+	query := `DELETE FROM topics WHERE id = ?`
+	result, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete topic: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return ErrTopicNotFound
+	}
+	return nil
+}
+
+// AddSubscribers subscribes the users in one transaction
+func (r *sqlTopicRepository) AddSubscribers(ctx context.Context, topicID string, userIDs []string) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	query := `INSERT INTO topic_subscriptions (topic_id, user_id, created_at) VALUES (?, ?, ?) ON CONFLICT (topic_id, user_id) DO NOTHING`
+	added := 0
+	for _, userID := range userIDs {
+		result, err := tx.Exec(ctx, query, topicID, userID, now)
+		if err != nil {
+			return 0, fmt.Errorf("failed to add topic subscriber: %w", err)
+		}
+		if rows, err := result.RowsAffected(); err == nil {
+			added += int(rows)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return added, nil
+}
+
+// RemoveSubscribers unsubscribes the users in one transaction
+func (r *sqlTopicRepository) RemoveSubscribers(ctx context.Context, topicID string, userIDs []string) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `DELETE FROM topic_subscriptions WHERE topic_id = ? AND user_id = ?`
+	removed := 0
+	for _, userID := range userIDs {
+		result, err := tx.Exec(ctx, query, topicID, userID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to remove topic subscriber: %w", err)
+		}
+		if rows, err := result.RowsAffected(); err == nil {
+			removed += int(rows)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return removed, nil
+}
+
+// GetSubscribers returns a page of a topic's subscriptions using the user ID as the keyset
+func (r *sqlTopicRepository) GetSubscribers(ctx context.Context, topicID, afterUserID string, limit int) ([]*domain.TopicSubscription, error) {
+	query := `SELECT topic_id, user_id, created_at FROM topic_subscriptions
+              WHERE topic_id = ? AND user_id > ? ORDER BY user_id ASC LIMIT ?`
+	rows, err := r.db.Query(ctx, query, topicID, afterUserID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topic subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []*domain.TopicSubscription{}
+	for rows.Next() {
+		subscription := &domain.TopicSubscription{}
+		if err := rows.Scan(&subscription.TopicID, &subscription.UserID, &subscription.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan topic subscriber: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate topic subscribers: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// CountSubscribers returns the number of users subscribed to a topic
+func (r *sqlTopicRepository) CountSubscribers(ctx context.Context, topicID string) (int, error) {
+	query := `SELECT COUNT(*) FROM topic_subscriptions WHERE topic_id = ?`
+	var count int
+	if err := r.db.QueryRow(ctx, query, topicID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count topic subscribers: %w", err)
+	}
+	return count, nil
+}
+
+func scanTopic(scanner interface{}) (*domain.Topic, error) {
+	topic := &domain.Topic{}
+	var description sql.NullString
+	dest := []interface{}{&topic.ID, &topic.Key, &topic.Name, &description, &topic.CreatedAt, &topic.UpdatedAt}
+
+	var err error
+	switch s := scanner.(type) {
+	case *sql.Row:
+		err = s.Scan(dest...)
+	case *sql.Rows:
+		err = s.Scan(dest...)
+	default:
+		return nil, fmt.Errorf("unsupported scanner type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	topic.Description = description.String
+	return topic, nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+)
+
+// InboxCursor is the position of the last inbox item on a page
+type InboxCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// InboxFilter selects a page of a user's inbox
+type InboxFilter struct {
+	UserID string
+	Status domain.InboxStatus
+	// After continues a listing from the previous page
+	After *InboxCursor
+	Limit int
+}
+
+type InboxRepository interface {
+	CreateInboxItem(ctx context.Context, item *domain.InboxItem) error
+	// GetInboxItems returns the items matching the filter, newest first
+	GetInboxItems(ctx context.Context, filter InboxFilter) ([]*domain.InboxItem, error)
+	// MarkInboxItemRead marks one of the user's items read; reading an item twice keeps the first read time
+	MarkInboxItemRead(ctx context.Context, userID, id string, at time.Time) error
+	// MarkAllInboxItemsRead marks every unread item of the user read and returns how many changed
+	MarkAllInboxItemsRead(ctx context.Context, userID string, at time.Time) (int, error)
+	// ArchiveInboxItem removes one of the user's items from the feed
+	ArchiveInboxItem(ctx context.Context, userID, id string, at time.Time) error
+	// CountUnreadInboxItems counts the user's unread items that are not archived
+	CountUnreadInboxItems(ctx context.Context, userID string) (int, error)
+}
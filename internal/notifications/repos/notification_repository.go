@@ -1,14 +1,55 @@
-
 package repository
 
 import (
-    "context"
-    "getnoti.com/internal/notifications/domain"
+	"context"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
 )
 
+// NotificationCursor is the position of the last notification on a page
+type NotificationCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// NotificationFilter selects notifications to list. Empty fields match everything.
+type NotificationFilter struct {
+	UserID     string
+	Channel    string
+	Status     string
+	TemplateID string
+	ProviderID string
+	// CreatedFrom is inclusive and CreatedTo exclusive
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// After continues a listing from the previous page
+	After *NotificationCursor
+	Limit int
+}
+
 type NotificationRepository interface {
-    CreateNotification(ctx context.Context, notification *domain.Notification) error
-    GetNotificationByID(ctx context.Context, id string) (*domain.Notification, error)
-    UpdateNotification(ctx context.Context, notification *domain.Notification) error
-    DeleteNotification(ctx context.Context, id string) error
+	CreateNotification(ctx context.Context, notification *domain.Notification) error
+	GetNotificationByID(ctx context.Context, id string) (*domain.Notification, error)
+	// ListNotifications returns matching notifications, newest first
+	ListNotifications(ctx context.Context, filter NotificationFilter) ([]*domain.Notification, error)
+	UpdateNotification(ctx context.Context, notification *domain.Notification) error
+	// GetDueScheduledNotifications returns up to limit scheduled notifications due at or before the given time,
+	// along with released notifications still pending after their claim expired
+	GetDueScheduledNotifications(ctx context.Context, before time.Time, limit int) ([]*domain.Notification, error)
+	// ClaimNotification moves a scheduled notification, or a pending one whose claim expired before now,
+	// to pending claimed until claimUntil, and stores the attempt
+	ClaimNotification(ctx context.Context, attempt *domain.NotificationAttempt, now, claimUntil time.Time) error
+	// RescheduleNotification moves a notification that is still scheduled to a new send time and stores the attempt
+	RescheduleNotification(ctx context.Context, attempt *domain.NotificationAttempt, scheduledFor time.Time) error
+	// DeferNotification moves a notification that is still pending back to scheduled until scheduledFor and stores the attempt
+	DeferNotification(ctx context.Context, attempt *domain.NotificationAttempt, scheduledFor time.Time) error
+	DeleteNotification(ctx context.Context, id string) error
+	// UpdateNotificationStatus applies a status transition recorded by attempt, provided
+	// the notification is still in the from status, and stores the attempt
+	UpdateNotificationStatus(ctx context.Context, from domain.NotificationStatus, attempt *domain.NotificationAttempt) error
+	// CreateNotificationAttempt stores an attempt that did not change the status, such as a provider failover
+	CreateNotificationAttempt(ctx context.Context, attempt *domain.NotificationAttempt) error
+	// GetNotificationAttempts returns the attempts for a notification, oldest first
+	GetNotificationAttempts(ctx context.Context, notificationID string) ([]*domain.NotificationAttempt, error)
 }
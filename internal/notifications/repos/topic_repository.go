@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"getnoti.com/internal/notifications/domain"
+)
+
+type TopicRepository interface {
+	// CreateTopic stores a new topic; a key that is already taken returns ErrTopicKeyExists
+	CreateTopic(ctx context.Context, topic *domain.Topic) error
+	GetTopicByID(ctx context.Context, id string) (*domain.Topic, error)
+	GetTopics(ctx context.Context) ([]*domain.Topic, error)
+	DeleteTopic(ctx context.Context, id string) error
+	// AddSubscribers subscribes the users, skipping existing members, and returns how many were added
+	AddSubscribers(ctx context.Context, topicID string, userIDs []string) (int, error)
+	// RemoveSubscribers unsubscribes the users and returns how many were removed
+	RemoveSubscribers(ctx context.Context, topicID string, userIDs []string) (int, error)
+	// GetSubscribers returns up to limit subscriptions ordered by user ID, starting after afterUserID
+	GetSubscribers(ctx context.Context, topicID, afterUserID string, limit int) ([]*domain.TopicSubscription, error)
+	// CountSubscribers returns the number of users subscribed to the topic
+	CountSubscribers(ctx context.Context, topicID string) (int, error)
+}
@@ -0,0 +1,110 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	repos "getnoti.com/internal/notifications/repos"
+	"getnoti.com/internal/shared/utils"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	tenantServices "getnoti.com/internal/tenants/services"
+	"getnoti.com/pkg/logger"
+)
+
+var ErrInvalidDigestItem = errors.New("digest items need a user, key, channel and template")
+
+// DigestService collects events into per-user digests. A digest's window comes
+// from the user's digest settings, or from the caller's, as for workflow digest steps.
+type DigestService struct {
+	userPreferenceService *tenantServices.UserPreferenceService
+	logger                logger.Logger
+	repositoryFactory     interface {
+		GetDigestRepositoryForTenant(tenantID string) (repos.DigestRepository, error)
+	}
+}
+
+// NewDigestService creates a new digest service
+func NewDigestService(
+	userPreferenceService *tenantServices.UserPreferenceService,
+	logger logger.Logger,
+	repositoryFactory interface {
+		GetDigestRepositoryForTenant(tenantID string) (repos.DigestRepository, error)
+	},
+) *DigestService {
+	return &DigestService{
+		userPreferenceService: userPreferenceService,
+		logger:                logger,
+		repositoryFactory:     repositoryFactory,
+	}
+}
+
+// DigestItem is an event to collect into a user's digest
+type DigestItem struct {
+	TenantID string
+	UserID   string
+	// Key groups the user's events into one digest
+	Key     string
+	Channel string
+	// TemplateID renders the digest, which lists the events as digest.items
+	TemplateID string
+	Category   string
+	Data       map[string]interface{}
+}
+
+// UserSettings returns the user's digest settings for a category and whether
+// the user collects that category into digests
+func (s *DigestService) UserSettings(ctx context.Context, tenantID, userID, category string) (tenantDomain.DigestSettings, bool, error) {
+	return s.userPreferenceService.DigestSettings(ctx, userID, tenantID, category)
+}
+
+// Add collects the item into the user's open digest for its key. When the item
+// opens a new digest, the digest is sent at the settings' next delivery time in
+// the user's timezone.
+func (s *DigestService) Add(ctx context.Context, item DigestItem, settings tenantDomain.DigestSettings) (*domain.Digest, error) {
+	if item.UserID == "" || item.Key == "" || item.Channel == "" || item.TemplateID == "" {
+		return nil, ErrInvalidDigestItem
+	}
+
+	repo, err := s.repositoryFactory.GetDigestRepositoryForTenant(item.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest repository: %w", err)
+	}
+
+	loc := s.userPreferenceService.Location(ctx, item.UserID, item.TenantID)
+	digest := &domain.Digest{
+		ID:         utils.GenerateUUID(),
+		TenantID:   item.TenantID,
+		UserID:     item.UserID,
+		Key:        item.Key,
+		Channel:    item.Channel,
+		TemplateID: item.TemplateID,
+		Category:   item.Category,
+		SendAt:     settings.NextDelivery(time.Now(), loc),
+	}
+	event := &domain.DigestEvent{
+		ID:   utils.GenerateUUID(),
+		Data: item.Data,
+	}
+	if event.Data == nil {
+		event.Data = map[string]interface{}{}
+	}
+
+	open, err := repo.AddDigestEvent(ctx, digest, event)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to add event to digest",
+			logger.String("tenant_id", item.TenantID),
+			logger.String("user_id", item.UserID),
+			logger.String("digest_key", item.Key),
+			logger.Err(err))
+		return nil, err
+	}
+
+	s.logger.DebugContext(ctx, "Added event to digest",
+		logger.String("tenant_id", item.TenantID),
+		logger.String("digest_id", open.ID),
+		logger.Int("event_count", open.EventCount))
+	return open, nil
+}
@@ -0,0 +1,168 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	repos "getnoti.com/internal/notifications/repos"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	tenantRepos "getnoti.com/internal/tenants/repos"
+	"getnoti.com/pkg/logger"
+)
+
+// FrequencyCapService enforces the tenant's per-user frequency caps. Counters
+// live in the tenant database, so every API instance enforces the same caps.
+type FrequencyCapService struct {
+	logger            logger.Logger
+	repositoryFactory interface {
+		GetTenantPreferenceRepositoryForTenant(tenantID string) (tenantRepos.TenantPreferenceRepository, error)
+		GetFrequencyCounterRepositoryForTenant(tenantID string) (repos.FrequencyCounterRepository, error)
+	}
+}
+
+// NewFrequencyCapService creates a new frequency cap service
+func NewFrequencyCapService(
+	logger logger.Logger,
+	repositoryFactory interface {
+		GetTenantPreferenceRepositoryForTenant(tenantID string) (tenantRepos.TenantPreferenceRepository, error)
+		GetFrequencyCounterRepositoryForTenant(tenantID string) (repos.FrequencyCounterRepository, error)
+	},
+) *FrequencyCapService {
+	return &FrequencyCapService{
+		logger:            logger,
+		repositoryFactory: repositoryFactory,
+	}
+}
+
+// CapDecision is the outcome of checking a send against the tenant's frequency caps
+type CapDecision struct {
+	Allowed bool
+	// Policy is the full cap's policy
+	Policy tenantDomain.CapPolicy
+	// Until is when the full cap's window ends
+	Until time.Time
+	// Reason explains which cap was reached
+	Reason string
+}
+
+// Reserve checks a send to the user against every tenant cap matching the
+// channel and category. An allowed send is counted against all of them; a
+// capped send is counted against none. A counted send that is never delivered
+// is given back with Release.
+func (s *FrequencyCapService) Reserve(ctx context.Context, tenantID, userID, channel, category string, at time.Time) (CapDecision, error) {
+	allowed := CapDecision{Allowed: true}
+	if userID == "" {
+		return allowed, nil
+	}
+
+	caps, err := s.matchingCaps(ctx, tenantID, channel, category)
+	if err != nil || len(caps) == 0 {
+		return allowed, err
+	}
+	counters := capCounters(caps, at)
+
+	counterRepo, err := s.repositoryFactory.GetFrequencyCounterRepositoryForTenant(tenantID)
+	if err != nil {
+		return allowed, fmt.Errorf("failed to get frequency counter repository: %w", err)
+	}
+	full, err := counterRepo.ReserveFrequencyCounters(ctx, userID, counters)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to reserve frequency counters",
+			logger.String("tenant_id", tenantID),
+			logger.String("user_id", userID),
+			logger.Err(err))
+		return allowed, err
+	}
+	if full == nil {
+		return allowed, nil
+	}
+
+	frequencyCap := caps[full.CapKey]
+	_, windowEnd := frequencyCap.Window(at)
+	reason := fmt.Sprintf("frequency cap of %s reached", frequencyCap.Describe())
+	if frequencyCap.Policy == tenantDomain.CapPolicyDefer {
+		reason += "; deferred until the window ends"
+	}
+
+	s.logger.DebugContext(ctx, "Frequency cap reached",
+		logger.String("tenant_id", tenantID),
+		logger.String("user_id", userID),
+		logger.String("cap", full.CapKey),
+		logger.String("policy", string(frequencyCap.Policy)))
+
+	return CapDecision{
+		Policy: frequencyCap.Policy,
+		Until:  windowEnd,
+		Reason: reason,
+	}, nil
+}
+
+// Release gives back a send Reserve counted at the given time, for a
+// notification that could not be rendered or that every provider rejected
+func (s *FrequencyCapService) Release(ctx context.Context, tenantID, userID, channel, category string, reservedAt time.Time) error {
+	if userID == "" {
+		return nil
+	}
+
+	caps, err := s.matchingCaps(ctx, tenantID, channel, category)
+	if err != nil || len(caps) == 0 {
+		return err
+	}
+
+	counterRepo, err := s.repositoryFactory.GetFrequencyCounterRepositoryForTenant(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get frequency counter repository: %w", err)
+	}
+	if err := counterRepo.ReleaseFrequencyCounters(ctx, userID, capCounters(caps, reservedAt)); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to release frequency counters",
+			logger.String("tenant_id", tenantID),
+			logger.String("user_id", userID),
+			logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+// matchingCaps returns the tenant caps matching the channel and category, keyed
+// by their counters
+func (s *FrequencyCapService) matchingCaps(ctx context.Context, tenantID, channel, category string) (map[string]tenantDomain.FrequencyCap, error) {
+	tenantPrefRepo, err := s.repositoryFactory.GetTenantPreferenceRepositoryForTenant(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant preference repository: %w", err)
+	}
+	tenantPreference, err := tenantPrefRepo.GetTenantPreferenceByTenantID(ctx, tenantID)
+	if err != nil {
+		// Tenants without preferences have no caps
+		return nil, nil
+	}
+
+	caps := make(map[string]tenantDomain.FrequencyCap)
+	for _, frequencyCap := range tenantPreference.FrequencyCaps {
+		if frequencyCap.Validate() != nil || !frequencyCap.Matches(channel, category) {
+			continue
+		}
+		key := frequencyCap.Key()
+		if existing, exists := caps[key]; exists && existing.Limit <= frequencyCap.Limit {
+			// Caps sharing a scope and window share counters; the tightest applies
+			continue
+		}
+		caps[key] = frequencyCap
+	}
+	return caps, nil
+}
+
+// capCounters returns the counters of the caps' windows containing at
+func capCounters(caps map[string]tenantDomain.FrequencyCap, at time.Time) []domain.FrequencyCounter {
+	counters := make([]domain.FrequencyCounter, 0, len(caps))
+	for key, frequencyCap := range caps {
+		windowStart, _ := frequencyCap.Window(at)
+		counters = append(counters, domain.FrequencyCounter{
+			CapKey:      key,
+			WindowStart: windowStart,
+			Limit:       frequencyCap.Limit,
+		})
+	}
+	return counters
+}
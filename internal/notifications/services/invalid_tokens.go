@@ -0,0 +1,39 @@
+package notifications
+
+import (
+	"context"
+)
+
+// InvalidTokensEventType is the webhook event sent when a push provider reports
+// device tokens that are no longer registered
+const InvalidTokensEventType = "device.tokens_invalid"
+
+// InvalidTokenHandler prunes device tokens a provider reported as no longer
+// registered, so later sends stop using them
+type InvalidTokenHandler interface {
+	PruneTokens(ctx context.Context, tenantID, userID, providerID string, tokens []string) error
+}
+
+// WebhookTokenPruner hands invalid tokens to the tenant's webhooks. Tenants
+// keep their own device registries, so they remove the tokens there.
+type WebhookTokenPruner struct {
+	dispatcher interface {
+		DispatchEvent(ctx context.Context, tenantID, eventType string, eventData interface{}) error
+	}
+}
+
+// NewWebhookTokenPruner creates a token pruner that dispatches the tokens as a webhook event
+func NewWebhookTokenPruner(dispatcher interface {
+	DispatchEvent(ctx context.Context, tenantID, eventType string, eventData interface{}) error
+}) *WebhookTokenPruner {
+	return &WebhookTokenPruner{dispatcher: dispatcher}
+}
+
+// PruneTokens sends the tokens to the tenant's webhooks subscribed to InvalidTokensEventType
+func (p *WebhookTokenPruner) PruneTokens(ctx context.Context, tenantID, userID, providerID string, tokens []string) error {
+	return p.dispatcher.DispatchEvent(ctx, tenantID, InvalidTokensEventType, map[string]interface{}{
+		"user_id":     userID,
+		"provider_id": providerID,
+		"tokens":      tokens,
+	})
+}
@@ -7,6 +7,8 @@ import (
 
 	"getnoti.com/internal/notifications/domain"
 	repos "getnoti.com/internal/notifications/repos"
+	"getnoti.com/internal/providers/dtos"
+	"getnoti.com/internal/shared/utils"
 	tenantServices "getnoti.com/internal/tenants/services"
 	"getnoti.com/pkg/db"
 	"getnoti.com/pkg/logger"
@@ -21,7 +23,14 @@ type NotificationService struct {
 	connectionManager *db.Manager
 	queueManager      *queue.QueueManager
 	workerPoolManager *workerpool.WorkerPoolManager
-	logger            logger.Logger
+	// frequencyCapService gives back the cap slots of queued sends that every provider rejected
+	frequencyCapService *FrequencyCapService
+	// invalidTokenHandler prunes device tokens queued sends found unregistered
+	invalidTokenHandler InvalidTokenHandler
+	logger              logger.Logger
+	repositoryFactory   interface {
+		GetNotificationRepositoryForTenant(tenantID string) (repos.NotificationRepository, error)
+	}
 }
 
 // NewNotificationService creates a new notification service
@@ -31,18 +40,31 @@ func NewNotificationService(
 	connectionManager *db.Manager,
 	queueManager *queue.QueueManager,
 	workerPoolManager *workerpool.WorkerPoolManager,
+	frequencyCapService *FrequencyCapService,
 	logger logger.Logger,
+	repositoryFactory interface {
+		GetNotificationRepositoryForTenant(tenantID string) (repos.NotificationRepository, error)
+	},
 ) *NotificationService {
 	return &NotificationService{
-		notificationRepo:  notificationRepo,
-		tenantService:     tenantService,
-		connectionManager: connectionManager,
-		queueManager:      queueManager,
-		workerPoolManager: workerPoolManager,
-		logger:            logger,
+		notificationRepo:    notificationRepo,
+		tenantService:       tenantService,
+		connectionManager:   connectionManager,
+		queueManager:        queueManager,
+		workerPoolManager:   workerPoolManager,
+		frequencyCapService: frequencyCapService,
+		logger:              logger,
+		repositoryFactory:   repositoryFactory,
 	}
 }
 
+// SetInvalidTokenHandler sets the handler that prunes device tokens providers
+// report as unregistered while delivering queued sends. Without one the
+// tokens are only logged.
+func (s *NotificationService) SetInvalidTokenHandler(handler InvalidTokenHandler) {
+	s.invalidTokenHandler = handler
+}
+
 // SendNotificationRequest represents a notification sending request
 type SendNotificationRequest struct {
 	TenantID     string                 `json:"tenant_id"`
@@ -99,8 +121,17 @@ func (s *NotificationService) SendNotification(ctx context.Context, req SendNoti
 		}
 		notification.Variables = variables
 	}
+	scheduledFor, err := parseScheduledFor(req.ScheduledFor)
+	if err != nil {
+		return nil, err
+	}
+	if scheduledFor != nil {
+		notification.Status = domain.StatusScheduled
+		notification.ScheduledFor = scheduledFor
+	}
 
-	// Save notification to repository	err = s.notificationRepo.CreateNotification(ctx, notification)
+	// Save notification to repository
+	err = s.notificationRepo.CreateNotification(ctx, notification)
 	if err != nil {
 		s.logger.ErrorContext(ctx, "Failed to save notification",
 			logger.String("tenant_id", req.TenantID),
@@ -108,6 +139,20 @@ func (s *NotificationService) SendNotification(ctx context.Context, req SendNoti
 		return nil, fmt.Errorf("failed to save notification: %w", err)
 	}
 
+	// Scheduled notifications are released by the scheduled notification worker
+	if notification.Status == domain.StatusScheduled {
+		s.logger.InfoContext(ctx, "Notification scheduled successfully",
+			logger.String("notification_id", notification.ID),
+			logger.String("tenant_id", req.TenantID),
+			logger.Time("scheduled_for", *scheduledFor))
+
+		return &SendNotificationResponse{
+			NotificationID: notification.ID,
+			Status:         string(domain.StatusScheduled),
+			Message:        "Notification scheduled for " + scheduledFor.UTC().Format(time.RFC3339),
+		}, nil
+	}
+
 	// Queue notification for processing
 	err = s.queueNotification(ctx, notification)
 	if err != nil {
@@ -128,6 +173,22 @@ func (s *NotificationService) SendNotification(ctx context.Context, req SendNoti
 	}, nil
 }
 
+// parseScheduledFor parses an RFC 3339 send time. Empty and past times return nil
+// so the notification is sent immediately.
+func parseScheduledFor(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	scheduledFor, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduled_for %q: %w", value, err)
+	}
+	if !scheduledFor.After(time.Now()) {
+		return nil, nil
+	}
+	return &scheduledFor, nil
+}
+
 // GetNotification retrieves a notification by ID for a tenant
 func (s *NotificationService) GetNotification(ctx context.Context, tenantID, notificationID string) (*domain.Notification, error) {
 	s.logger.DebugContext(ctx, "Getting notification",
@@ -154,6 +215,92 @@ func (s *NotificationService) GetNotification(ctx context.Context, tenantID, not
 	return notification, nil
 }
 
+// RecordDelivery applies a provider outcome to the notification's status and
+// records it in the notification's delivery history
+func (s *NotificationService) RecordDelivery(ctx context.Context, report dtos.DeliveryReport) error {
+	repo, err := s.repositoryFactory.GetNotificationRepositoryForTenant(report.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification repository: %w", err)
+	}
+
+	notification, err := repo.GetNotificationByID(ctx, report.NotificationID)
+	if err != nil {
+		return err
+	}
+
+	from, queuedAt := notification.Status, notification.UpdatedAt
+	if err := notification.Transition(domain.NotificationStatus(report.Status)); err != nil {
+		return err
+	}
+	if report.ProviderID != "" {
+		notification.ProviderID = report.ProviderID
+	}
+
+	attempt := notification.NewAttempt(utils.GenerateUUID(), report.ProviderMessageID, report.Reason)
+	if err := repo.UpdateNotificationStatus(ctx, from, attempt); err != nil {
+		return err
+	}
+
+	// The send was counted against the user's frequency caps when it was queued
+	if notification.Status == domain.StatusFailed && from == domain.StatusQueued && !notification.Urgent && s.frequencyCapService != nil {
+		_ = s.frequencyCapService.Release(ctx, report.TenantID, notification.UserID, notification.Channel, notification.Category, queuedAt)
+	}
+
+	s.pruneInvalidTokens(ctx, report, notification.UserID)
+
+	s.logger.DebugContext(ctx, "Notification status updated",
+		logger.String("tenant_id", report.TenantID),
+		logger.String("notification_id", report.NotificationID),
+		logger.String("from", string(from)),
+		logger.String("status", report.Status))
+
+	return nil
+}
+
+// RecordFailover records a provider that rejected the notification in its
+// delivery history; the status is left for the next provider to settle
+func (s *NotificationService) RecordFailover(ctx context.Context, report dtos.DeliveryReport) error {
+	repo, err := s.repositoryFactory.GetNotificationRepositoryForTenant(report.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification repository: %w", err)
+	}
+
+	notification, err := repo.GetNotificationByID(ctx, report.NotificationID)
+	if err != nil {
+		return err
+	}
+
+	s.pruneInvalidTokens(ctx, report, notification.UserID)
+
+	attempt := notification.NewAttempt(utils.GenerateUUID(), "", report.Reason)
+	attempt.ProviderID = report.ProviderID
+	return repo.CreateNotificationAttempt(ctx, attempt)
+}
+
+// pruneInvalidTokens passes the device tokens a provider reported as
+// unregistered to the invalid token handler
+func (s *NotificationService) pruneInvalidTokens(ctx context.Context, report dtos.DeliveryReport, userID string) {
+	if len(report.InvalidTokens) == 0 {
+		return
+	}
+
+	s.logger.WarnContext(ctx, "Provider reported unregistered device tokens",
+		logger.String("tenant_id", report.TenantID),
+		logger.String("notification_id", report.NotificationID),
+		logger.String("provider_id", report.ProviderID),
+		logger.Any("tokens", report.InvalidTokens))
+
+	if s.invalidTokenHandler == nil {
+		return
+	}
+	if err := s.invalidTokenHandler.PruneTokens(ctx, report.TenantID, userID, report.ProviderID, report.InvalidTokens); err != nil {
+		s.logger.ErrorContext(ctx, "Failed to prune unregistered device tokens",
+			logger.String("tenant_id", report.TenantID),
+			logger.String("notification_id", report.NotificationID),
+			logger.Err(err))
+	}
+}
+
 // queueNotification queues a notification for processing
 func (s *NotificationService) queueNotification(ctx context.Context, notification *domain.Notification) error {
 	// Get queue for tenant
@@ -161,14 +308,14 @@ func (s *NotificationService) queueNotification(ctx context.Context, notificatio
 	if err != nil {
 		return fmt.Errorf("failed to get queue: %w", err)
 	}
-	
+
 	// Queue the notification
 	message := queue.Message{
 		Body:      []byte(notification.Content),
 		Headers:   make(map[string]interface{}),
 		Timestamp: time.Now(),
 	}
-	
+
 	err = notificationQueue.Publish(ctx, "notifications", notification.TenantID, message)
 	if err != nil {
 		return fmt.Errorf("failed to publish notification: %w", err)
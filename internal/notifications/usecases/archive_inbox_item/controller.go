@@ -0,0 +1,17 @@
+package archiveinboxitem
+
+import (
+	"context"
+)
+
+type ArchiveInboxItemController struct {
+	useCase ArchiveInboxItemUseCase
+}
+
+func NewArchiveInboxItemController(useCase ArchiveInboxItemUseCase) *ArchiveInboxItemController {
+	return &ArchiveInboxItemController{useCase: useCase}
+}
+
+func (c *ArchiveInboxItemController) ArchiveInboxItem(ctx context.Context, req ArchiveInboxItemRequest) (ArchiveInboxItemResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
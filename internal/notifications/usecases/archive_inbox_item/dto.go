@@ -0,0 +1,21 @@
+package archiveinboxitem
+
+type ArchiveInboxItemRequest struct {
+	TenantID string
+	UserID   string
+	ItemID   string
+}
+
+func (r *ArchiveInboxItemRequest) SetTenantID(id string) {
+	r.TenantID = id
+}
+
+func (r *ArchiveInboxItemRequest) SetID(id string) {
+	r.UserID = id
+}
+
+type ArchiveInboxItemResponse struct {
+	UnreadCount int
+	Success     bool
+	Message     string
+}
@@ -0,0 +1,7 @@
+package archiveinboxitem
+
+import "errors"
+
+var (
+	ErrUnexpected = errors.New("unexpected error occurred")
+)
@@ -0,0 +1,47 @@
+package archiveinboxitem
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+	"getnoti.com/pkg/sse"
+)
+
+type ArchiveInboxItemUseCase interface {
+	Execute(ctx context.Context, req ArchiveInboxItemRequest) (ArchiveInboxItemResponse, error)
+}
+
+type archiveInboxItemUseCase struct {
+	repository repository.InboxRepository
+	publisher  sse.Publisher
+}
+
+func NewArchiveInboxItemUseCase(repository repository.InboxRepository, publisher sse.Publisher) ArchiveInboxItemUseCase {
+	return &archiveInboxItemUseCase{repository: repository, publisher: publisher}
+}
+
+// Execute archives the item and tells the user's other open clients
+func (uc *archiveInboxItemUseCase) Execute(ctx context.Context, req ArchiveInboxItemRequest) (ArchiveInboxItemResponse, error) {
+	if err := uc.repository.ArchiveInboxItem(ctx, req.UserID, req.ItemID, time.Now()); err != nil {
+		return ArchiveInboxItemResponse{Success: false, Message: err.Error()}, err
+	}
+
+	count, err := uc.repository.CountUnreadInboxItems(ctx, req.UserID)
+	if err != nil {
+		return ArchiveInboxItemResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	event, err := json.Marshal(domain.InboxEvent{Type: domain.InboxEventItemArchived, ItemID: req.ItemID, UnreadCount: count})
+	if err == nil {
+		uc.publisher.Publish(domain.InboxStreamChannel(req.TenantID, req.UserID), event)
+	}
+
+	return ArchiveInboxItemResponse{
+		UnreadCount: count,
+		Success:     true,
+		Message:     "Inbox item archived",
+	}, nil
+}
@@ -0,0 +1,17 @@
+package cancelnotification
+
+import (
+	"context"
+)
+
+type CancelNotificationController struct {
+	useCase CancelNotificationUseCase
+}
+
+func NewCancelNotificationController(useCase CancelNotificationUseCase) *CancelNotificationController {
+	return &CancelNotificationController{useCase: useCase}
+}
+
+func (c *CancelNotificationController) CancelNotification(ctx context.Context, req CancelNotificationRequest) (CancelNotificationResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
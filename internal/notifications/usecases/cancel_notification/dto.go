@@ -0,0 +1,18 @@
+package cancelnotification
+
+type CancelNotificationRequest struct {
+	ID string
+	// Reason is stored in the delivery history
+	Reason string
+}
+
+func (r *CancelNotificationRequest) SetID(id string) {
+	r.ID = id
+}
+
+type CancelNotificationResponse struct {
+	ID      string
+	Status  string
+	Success bool
+	Message string
+}
@@ -0,0 +1,7 @@
+package cancelnotification
+
+import "errors"
+
+var (
+	ErrNotScheduled = errors.New("only scheduled notifications can be cancelled")
+)
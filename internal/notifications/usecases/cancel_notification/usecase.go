@@ -0,0 +1,59 @@
+package cancelnotification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+	repos "getnoti.com/internal/notifications/repos/implementations"
+	"getnoti.com/internal/shared/utils"
+)
+
+const defaultCancelReason = "cancelled before the scheduled send time"
+
+type CancelNotificationUseCase interface {
+	Execute(ctx context.Context, req CancelNotificationRequest) (CancelNotificationResponse, error)
+}
+
+type cancelNotificationUseCase struct {
+	repository repository.NotificationRepository
+}
+
+func NewCancelNotificationUseCase(repository repository.NotificationRepository) CancelNotificationUseCase {
+	return &cancelNotificationUseCase{repository: repository}
+}
+
+func (uc *cancelNotificationUseCase) Execute(ctx context.Context, req CancelNotificationRequest) (CancelNotificationResponse, error) {
+	notification, err := uc.repository.GetNotificationByID(ctx, req.ID)
+	if err != nil {
+		return CancelNotificationResponse{Success: false, Message: err.Error()}, err
+	}
+
+	from := notification.Status
+	if err := notification.Transition(domain.StatusCancelled); err != nil {
+		err = fmt.Errorf("%w: notification is %s", ErrNotScheduled, from)
+		return CancelNotificationResponse{ID: notification.ID, Status: string(from), Success: false, Message: err.Error()}, err
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = defaultCancelReason
+	}
+
+	// The scheduler may release the notification between the read and the update
+	if err := uc.repository.UpdateNotificationStatus(ctx, from, notification.NewAttempt(utils.GenerateUUID(), "", reason)); err != nil {
+		if errors.Is(err, repos.ErrStatusConflict) {
+			err = fmt.Errorf("%w: %w", ErrNotScheduled, err)
+		}
+		return CancelNotificationResponse{ID: notification.ID, Status: string(from), Success: false, Message: err.Error()}, err
+	}
+
+	return CancelNotificationResponse{
+		ID:      notification.ID,
+		Status:  string(notification.Status),
+		Success: true,
+		Message: "Notification cancelled successfully",
+	}, nil
+}
@@ -0,0 +1,17 @@
+package createtopic
+
+import (
+	"context"
+)
+
+type CreateTopicController struct {
+	useCase CreateTopicUseCase
+}
+
+func NewCreateTopicController(useCase CreateTopicUseCase) *CreateTopicController {
+	return &CreateTopicController{useCase: useCase}
+}
+
+func (c *CreateTopicController) CreateTopic(ctx context.Context, req CreateTopicRequest) (CreateTopicResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
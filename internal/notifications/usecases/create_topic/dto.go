@@ -0,0 +1,17 @@
+package createtopic
+
+import (
+	"getnoti.com/internal/notifications/domain"
+)
+
+type CreateTopicRequest struct {
+	Key         string
+	Name        string
+	Description string
+}
+
+type CreateTopicResponse struct {
+	Topic   *domain.Topic `json:",omitempty"`
+	Success bool
+	Message string
+}
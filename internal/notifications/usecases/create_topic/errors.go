@@ -0,0 +1,7 @@
+package createtopic
+
+import "errors"
+
+var (
+	ErrTopicCreationFailed = errors.New("topic creation failed")
+)
@@ -0,0 +1,45 @@
+package createtopic
+
+import (
+	"context"
+	"fmt"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+	"getnoti.com/internal/shared/utils"
+)
+
+type CreateTopicUseCase interface {
+	Execute(ctx context.Context, req CreateTopicRequest) (CreateTopicResponse, error)
+}
+
+type createTopicUseCase struct {
+	repository repository.TopicRepository
+}
+
+func NewCreateTopicUseCase(repository repository.TopicRepository) CreateTopicUseCase {
+	return &createTopicUseCase{repository: repository}
+}
+
+func (uc *createTopicUseCase) Execute(ctx context.Context, req CreateTopicRequest) (CreateTopicResponse, error) {
+	topic := &domain.Topic{
+		ID:          utils.GenerateUUID(),
+		Key:         req.Key,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if err := topic.Validate(); err != nil {
+		return CreateTopicResponse{Success: false, Message: err.Error()}, err
+	}
+
+	if err := uc.repository.CreateTopic(ctx, topic); err != nil {
+		err = fmt.Errorf("%w: %w", ErrTopicCreationFailed, err)
+		return CreateTopicResponse{Success: false, Message: err.Error()}, err
+	}
+
+	return CreateTopicResponse{
+		Topic:   topic,
+		Success: true,
+		Message: "Topic created successfully",
+	}, nil
+}
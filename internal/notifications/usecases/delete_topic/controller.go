@@ -0,0 +1,17 @@
+package deletetopic
+
+import (
+	"context"
+)
+
+type DeleteTopicController struct {
+	useCase DeleteTopicUseCase
+}
+
+func NewDeleteTopicController(useCase DeleteTopicUseCase) *DeleteTopicController {
+	return &DeleteTopicController{useCase: useCase}
+}
+
+func (c *DeleteTopicController) DeleteTopic(ctx context.Context, req DeleteTopicRequest) (DeleteTopicResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
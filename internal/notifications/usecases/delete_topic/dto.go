@@ -0,0 +1,14 @@
+package deletetopic
+
+type DeleteTopicRequest struct {
+	ID string
+}
+
+func (r *DeleteTopicRequest) SetID(id string) {
+	r.ID = id
+}
+
+type DeleteTopicResponse struct {
+	Success bool
+	Message string
+}
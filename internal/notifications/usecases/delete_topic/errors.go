@@ -0,0 +1,7 @@
+package deletetopic
+
+import "errors"
+
+var (
+	ErrUnexpected = errors.New("unexpected error occurred")
+)
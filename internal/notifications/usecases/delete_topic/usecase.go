@@ -0,0 +1,30 @@
+package deletetopic
+
+import (
+	"context"
+
+	"getnoti.com/internal/notifications/repos"
+)
+
+type DeleteTopicUseCase interface {
+	Execute(ctx context.Context, req DeleteTopicRequest) (DeleteTopicResponse, error)
+}
+
+type deleteTopicUseCase struct {
+	repository repository.TopicRepository
+}
+
+func NewDeleteTopicUseCase(repository repository.TopicRepository) DeleteTopicUseCase {
+	return &deleteTopicUseCase{repository: repository}
+}
+
+func (uc *deleteTopicUseCase) Execute(ctx context.Context, req DeleteTopicRequest) (DeleteTopicResponse, error) {
+	if err := uc.repository.DeleteTopic(ctx, req.ID); err != nil {
+		return DeleteTopicResponse{Success: false, Message: err.Error()}, err
+	}
+
+	return DeleteTopicResponse{
+		Success: true,
+		Message: "Topic deleted successfully",
+	}, nil
+}
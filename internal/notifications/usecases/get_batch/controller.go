@@ -0,0 +1,17 @@
+package getbatch
+
+import (
+	"context"
+)
+
+type GetBatchController struct {
+	useCase GetBatchUseCase
+}
+
+func NewGetBatchController(useCase GetBatchUseCase) *GetBatchController {
+	return &GetBatchController{useCase: useCase}
+}
+
+func (c *GetBatchController) GetBatch(ctx context.Context, req GetBatchRequest) (GetBatchResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,28 @@
+package getbatch
+
+import (
+	"getnoti.com/internal/notifications/domain"
+)
+
+type GetBatchRequest struct {
+	ID string
+	// Cursor continues the recipient list from a previous response's NextCursor
+	Cursor string
+	Limit  int
+}
+
+func (r *GetBatchRequest) SetID(id string) {
+	r.ID = id
+}
+
+type GetBatchResponse struct {
+	Batch  domain.NotificationBatch
+	Status string
+	// Counts is the number of recipients in each status
+	Counts     map[domain.NotificationStatus]int
+	Recipients []*domain.BatchRecipient
+	// NextCursor is empty on the last page of recipients
+	NextCursor string `json:",omitempty"`
+	Success    bool
+	Message    string
+}
@@ -0,0 +1,8 @@
+package getbatch
+
+import "errors"
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrUnexpected    = errors.New("unexpected error occurred")
+)
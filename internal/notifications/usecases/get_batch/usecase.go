@@ -0,0 +1,89 @@
+package getbatch
+
+import (
+	"context"
+	"strconv"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type GetBatchUseCase interface {
+	Execute(ctx context.Context, req GetBatchRequest) (GetBatchResponse, error)
+}
+
+type getBatchUseCase struct {
+	repository repository.BatchRepository
+}
+
+func NewGetBatchUseCase(repository repository.BatchRepository) GetBatchUseCase {
+	return &getBatchUseCase{repository: repository}
+}
+
+// Execute returns the batch with its status counts and one page of recipients
+func (uc *getBatchUseCase) Execute(ctx context.Context, req GetBatchRequest) (GetBatchResponse, error) {
+	after := -1
+	if req.Cursor != "" {
+		position, err := strconv.Atoi(req.Cursor)
+		if err != nil || position < 0 {
+			return GetBatchResponse{Success: false, Message: ErrInvalidCursor.Error()}, ErrInvalidCursor
+		}
+		after = position
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	batch, err := uc.repository.GetBatch(ctx, req.ID)
+	if err != nil {
+		return GetBatchResponse{Success: false, Message: err.Error()}, err
+	}
+
+	counts, err := uc.repository.CountBatchRecipients(ctx, batch.ID)
+	if err != nil {
+		return GetBatchResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	// Fetch one extra row to know whether there is another page
+	recipients, err := uc.repository.GetBatchRecipients(ctx, batch.ID, after, limit+1)
+	if err != nil {
+		return GetBatchResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	nextCursor := ""
+	if len(recipients) > limit {
+		recipients = recipients[:limit]
+		nextCursor = strconv.Itoa(recipients[limit-1].Position)
+	}
+
+	// Recipients of a topic batch are stored page by page, so the batch is still
+	// processing until every expected recipient is stored
+	stored := 0
+	for _, count := range counts {
+		stored += count
+	}
+	status := domain.BatchStatusCompleted
+	if counts[domain.RecipientStatusPending] > 0 || stored < batch.TotalRecipients {
+		status = domain.BatchStatusProcessing
+	}
+
+	return GetBatchResponse{
+		Batch:      *batch,
+		Status:     string(status),
+		Counts:     counts,
+		Recipients: recipients,
+		NextCursor: nextCursor,
+		Success:    true,
+		Message:    "Batch retrieved successfully",
+	}, nil
+}
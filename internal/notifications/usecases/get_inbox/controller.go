@@ -0,0 +1,17 @@
+package getinbox
+
+import (
+	"context"
+)
+
+type GetInboxController struct {
+	useCase GetInboxUseCase
+}
+
+func NewGetInboxController(useCase GetInboxUseCase) *GetInboxController {
+	return &GetInboxController{useCase: useCase}
+}
+
+func (c *GetInboxController) GetInbox(ctx context.Context, req GetInboxRequest) (GetInboxResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,27 @@
+package getinbox
+
+import (
+	"getnoti.com/internal/notifications/domain"
+)
+
+type GetInboxRequest struct {
+	UserID string
+	// Status is all (the default), unread, read or archived
+	Status string
+	// Cursor is the NextCursor of the previous page
+	Cursor string
+	Limit  int
+}
+
+func (r *GetInboxRequest) SetID(id string) {
+	r.UserID = id
+}
+
+type GetInboxResponse struct {
+	Items       []*domain.InboxItem
+	UnreadCount int
+	// NextCursor fetches the next page; empty on the last page
+	NextCursor string `json:",omitempty"`
+	Success    bool
+	Message    string
+}
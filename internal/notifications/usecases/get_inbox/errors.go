@@ -0,0 +1,9 @@
+package getinbox
+
+import "errors"
+
+var (
+	ErrInvalidStatus = errors.New("invalid inbox status")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrUnexpected    = errors.New("unexpected error occurred")
+)
@@ -0,0 +1,119 @@
+package getinbox
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type GetInboxUseCase interface {
+	Execute(ctx context.Context, req GetInboxRequest) (GetInboxResponse, error)
+}
+
+type getInboxUseCase struct {
+	repository repository.InboxRepository
+}
+
+func NewGetInboxUseCase(repository repository.InboxRepository) GetInboxUseCase {
+	return &getInboxUseCase{repository: repository}
+}
+
+// Execute returns one page of the user's inbox with the user's unread count
+func (uc *getInboxUseCase) Execute(ctx context.Context, req GetInboxRequest) (GetInboxResponse, error) {
+	filter, err := buildFilter(req)
+	if err != nil {
+		return GetInboxResponse{Success: false, Message: err.Error()}, err
+	}
+
+	// Fetch one extra row to know whether there is another page
+	limit := filter.Limit
+	filter.Limit = limit + 1
+
+	items, err := uc.repository.GetInboxItems(ctx, filter)
+	if err != nil {
+		return GetInboxResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	unreadCount, err := uc.repository.CountUnreadInboxItems(ctx, req.UserID)
+	if err != nil {
+		return GetInboxResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	nextCursor := ""
+	if len(items) > limit {
+		items = items[:limit]
+		last := items[limit-1]
+		nextCursor = encodeCursor(repository.InboxCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return GetInboxResponse{
+		Items:       items,
+		UnreadCount: unreadCount,
+		NextCursor:  nextCursor,
+		Success:     true,
+		Message:     "Inbox retrieved successfully",
+	}, nil
+}
+
+func buildFilter(req GetInboxRequest) (repository.InboxFilter, error) {
+	filter := repository.InboxFilter{
+		UserID: req.UserID,
+		Status: domain.InboxStatusAll,
+		Limit:  req.Limit,
+	}
+
+	if req.Status != "" {
+		filter.Status = domain.InboxStatus(req.Status)
+		if !filter.Status.IsValid() {
+			return filter, fmt.Errorf("%w: %q", ErrInvalidStatus, req.Status)
+		}
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &cursor
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLimit
+	}
+	if filter.Limit > maxLimit {
+		filter.Limit = maxLimit
+	}
+	return filter, nil
+}
+
+// encodeCursor makes an opaque cursor from the last item on a page
+func encodeCursor(cursor repository.InboxCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(value string) (repository.InboxCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return repository.InboxCursor{}, ErrInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return repository.InboxCursor{}, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return repository.InboxCursor{}, ErrInvalidCursor
+	}
+	return repository.InboxCursor{CreatedAt: t, ID: id}, nil
+}
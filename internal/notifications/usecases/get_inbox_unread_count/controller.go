@@ -0,0 +1,17 @@
+package getinboxunreadcount
+
+import (
+	"context"
+)
+
+type GetInboxUnreadCountController struct {
+	useCase GetInboxUnreadCountUseCase
+}
+
+func NewGetInboxUnreadCountController(useCase GetInboxUnreadCountUseCase) *GetInboxUnreadCountController {
+	return &GetInboxUnreadCountController{useCase: useCase}
+}
+
+func (c *GetInboxUnreadCountController) GetInboxUnreadCount(ctx context.Context, req GetInboxUnreadCountRequest) (GetInboxUnreadCountResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,15 @@
+package getinboxunreadcount
+
+type GetInboxUnreadCountRequest struct {
+	UserID string
+}
+
+func (r *GetInboxUnreadCountRequest) SetID(id string) {
+	r.UserID = id
+}
+
+type GetInboxUnreadCountResponse struct {
+	UnreadCount int
+	Success     bool
+	Message     string
+}
@@ -0,0 +1,7 @@
+package getinboxunreadcount
+
+import "errors"
+
+var (
+	ErrUnexpected = errors.New("unexpected error occurred")
+)
@@ -0,0 +1,32 @@
+package getinboxunreadcount
+
+import (
+	"context"
+
+	"getnoti.com/internal/notifications/repos"
+)
+
+type GetInboxUnreadCountUseCase interface {
+	Execute(ctx context.Context, req GetInboxUnreadCountRequest) (GetInboxUnreadCountResponse, error)
+}
+
+type getInboxUnreadCountUseCase struct {
+	repository repository.InboxRepository
+}
+
+func NewGetInboxUnreadCountUseCase(repository repository.InboxRepository) GetInboxUnreadCountUseCase {
+	return &getInboxUnreadCountUseCase{repository: repository}
+}
+
+func (uc *getInboxUnreadCountUseCase) Execute(ctx context.Context, req GetInboxUnreadCountRequest) (GetInboxUnreadCountResponse, error) {
+	count, err := uc.repository.CountUnreadInboxItems(ctx, req.UserID)
+	if err != nil {
+		return GetInboxUnreadCountResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	return GetInboxUnreadCountResponse{
+		UnreadCount: count,
+		Success:     true,
+		Message:     "Unread count retrieved successfully",
+	}, nil
+}
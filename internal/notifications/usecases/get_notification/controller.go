@@ -0,0 +1,17 @@
+package getnotification
+
+import (
+	"context"
+)
+
+type GetNotificationController struct {
+	useCase GetNotificationUseCase
+}
+
+func NewGetNotificationController(useCase GetNotificationUseCase) *GetNotificationController {
+	return &GetNotificationController{useCase: useCase}
+}
+
+func (c *GetNotificationController) GetNotification(ctx context.Context, req GetNotificationRequest) (GetNotificationResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,21 @@
+package getnotification
+
+import (
+	"getnoti.com/internal/notifications/domain"
+)
+
+type GetNotificationRequest struct {
+	ID string
+}
+
+func (r *GetNotificationRequest) SetID(id string) {
+	r.ID = id
+}
+
+type GetNotificationResponse struct {
+	Notification domain.Notification
+	// Attempts is the delivery history, oldest first
+	Attempts []*domain.NotificationAttempt
+	Success  bool
+	Message  string
+}
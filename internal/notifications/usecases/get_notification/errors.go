@@ -0,0 +1,7 @@
+package getnotification
+
+import "errors"
+
+var (
+	ErrUnexpected = errors.New("unexpected error occurred")
+)
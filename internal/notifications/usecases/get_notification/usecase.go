@@ -0,0 +1,38 @@
+package getnotification
+
+import (
+	"context"
+
+	"getnoti.com/internal/notifications/repos"
+)
+
+type GetNotificationUseCase interface {
+	Execute(ctx context.Context, req GetNotificationRequest) (GetNotificationResponse, error)
+}
+
+type getNotificationUseCase struct {
+	repository repository.NotificationRepository
+}
+
+func NewGetNotificationUseCase(repository repository.NotificationRepository) GetNotificationUseCase {
+	return &getNotificationUseCase{repository: repository}
+}
+
+func (uc *getNotificationUseCase) Execute(ctx context.Context, req GetNotificationRequest) (GetNotificationResponse, error) {
+	notification, err := uc.repository.GetNotificationByID(ctx, req.ID)
+	if err != nil {
+		return GetNotificationResponse{Success: false, Message: err.Error()}, err
+	}
+
+	attempts, err := uc.repository.GetNotificationAttempts(ctx, notification.ID)
+	if err != nil {
+		return GetNotificationResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	return GetNotificationResponse{
+		Notification: *notification,
+		Attempts:     attempts,
+		Success:      true,
+		Message:      "Notification retrieved successfully",
+	}, nil
+}
@@ -0,0 +1,17 @@
+package gettopic
+
+import (
+	"context"
+)
+
+type GetTopicController struct {
+	useCase GetTopicUseCase
+}
+
+func NewGetTopicController(useCase GetTopicUseCase) *GetTopicController {
+	return &GetTopicController{useCase: useCase}
+}
+
+func (c *GetTopicController) GetTopic(ctx context.Context, req GetTopicRequest) (GetTopicResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,19 @@
+package gettopic
+
+import (
+	"getnoti.com/internal/notifications/domain"
+)
+
+type GetTopicRequest struct {
+	ID string
+}
+
+func (r *GetTopicRequest) SetID(id string) {
+	r.ID = id
+}
+
+type GetTopicResponse struct {
+	Topic   *domain.Topic `json:",omitempty"`
+	Success bool
+	Message string
+}
@@ -0,0 +1,7 @@
+package gettopic
+
+import "errors"
+
+var (
+	ErrUnexpected = errors.New("unexpected error occurred")
+)
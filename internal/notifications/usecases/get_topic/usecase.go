@@ -0,0 +1,32 @@
+package gettopic
+
+import (
+	"context"
+
+	"getnoti.com/internal/notifications/repos"
+)
+
+type GetTopicUseCase interface {
+	Execute(ctx context.Context, req GetTopicRequest) (GetTopicResponse, error)
+}
+
+type getTopicUseCase struct {
+	repository repository.TopicRepository
+}
+
+func NewGetTopicUseCase(repository repository.TopicRepository) GetTopicUseCase {
+	return &getTopicUseCase{repository: repository}
+}
+
+func (uc *getTopicUseCase) Execute(ctx context.Context, req GetTopicRequest) (GetTopicResponse, error) {
+	topic, err := uc.repository.GetTopicByID(ctx, req.ID)
+	if err != nil {
+		return GetTopicResponse{Success: false, Message: err.Error()}, err
+	}
+
+	return GetTopicResponse{
+		Topic:   topic,
+		Success: true,
+		Message: "Topic retrieved successfully",
+	}, nil
+}
@@ -0,0 +1,17 @@
+package gettopicsubscribers
+
+import (
+	"context"
+)
+
+type GetTopicSubscribersController struct {
+	useCase GetTopicSubscribersUseCase
+}
+
+func NewGetTopicSubscribersController(useCase GetTopicSubscribersUseCase) *GetTopicSubscribersController {
+	return &GetTopicSubscribersController{useCase: useCase}
+}
+
+func (c *GetTopicSubscribersController) GetTopicSubscribers(ctx context.Context, req GetTopicSubscribersRequest) (GetTopicSubscribersResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,24 @@
+package gettopicsubscribers
+
+import (
+	"getnoti.com/internal/notifications/domain"
+)
+
+type GetTopicSubscribersRequest struct {
+	ID string
+	// Cursor continues the listing from a previous response's NextCursor
+	Cursor string
+	Limit  int
+}
+
+func (r *GetTopicSubscribersRequest) SetID(id string) {
+	r.ID = id
+}
+
+type GetTopicSubscribersResponse struct {
+	Subscribers []*domain.TopicSubscription
+	// NextCursor is empty on the last page
+	NextCursor string `json:",omitempty"`
+	Success    bool
+	Message    string
+}
@@ -0,0 +1,69 @@
+package gettopicsubscribers
+
+import (
+	"context"
+	"encoding/base64"
+
+	"getnoti.com/internal/notifications/repos"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+type GetTopicSubscribersUseCase interface {
+	Execute(ctx context.Context, req GetTopicSubscribersRequest) (GetTopicSubscribersResponse, error)
+}
+
+type getTopicSubscribersUseCase struct {
+	repository repository.TopicRepository
+}
+
+func NewGetTopicSubscribersUseCase(repository repository.TopicRepository) GetTopicSubscribersUseCase {
+	return &getTopicSubscribersUseCase{repository: repository}
+}
+
+func (uc *getTopicSubscribersUseCase) Execute(ctx context.Context, req GetTopicSubscribersRequest) (GetTopicSubscribersResponse, error) {
+	// The cursor is the last user ID of the previous page
+	after := ""
+	if req.Cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(req.Cursor)
+		if err != nil || len(decoded) == 0 {
+			return GetTopicSubscribersResponse{Success: false, Message: ErrInvalidCursor.Error()}, ErrInvalidCursor
+		}
+		after = string(decoded)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	topic, err := uc.repository.GetTopicByID(ctx, req.ID)
+	if err != nil {
+		return GetTopicSubscribersResponse{Success: false, Message: err.Error()}, err
+	}
+
+	// Fetch one extra row to know whether there is another page
+	subscribers, err := uc.repository.GetSubscribers(ctx, topic.ID, after, limit+1)
+	if err != nil {
+		return GetTopicSubscribersResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	nextCursor := ""
+	if len(subscribers) > limit {
+		subscribers = subscribers[:limit]
+		nextCursor = base64.RawURLEncoding.EncodeToString([]byte(subscribers[limit-1].UserID))
+	}
+
+	return GetTopicSubscribersResponse{
+		Subscribers: subscribers,
+		NextCursor:  nextCursor,
+		Success:     true,
+		Message:     "Topic subscribers retrieved successfully",
+	}, nil
+}
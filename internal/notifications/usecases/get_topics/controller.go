@@ -0,0 +1,17 @@
+package gettopics
+
+import (
+	"context"
+)
+
+type GetTopicsController struct {
+	useCase GetTopicsUseCase
+}
+
+func NewGetTopicsController(useCase GetTopicsUseCase) *GetTopicsController {
+	return &GetTopicsController{useCase: useCase}
+}
+
+func (c *GetTopicsController) GetTopics(ctx context.Context, req GetTopicsRequest) (GetTopicsResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,13 @@
+package gettopics
+
+import (
+	"getnoti.com/internal/notifications/domain"
+)
+
+type GetTopicsRequest struct{}
+
+type GetTopicsResponse struct {
+	Topics  []*domain.Topic
+	Success bool
+	Message string
+}
@@ -0,0 +1,7 @@
+package gettopics
+
+import "errors"
+
+var (
+	ErrUnexpected = errors.New("unexpected error occurred")
+)
@@ -0,0 +1,32 @@
+package gettopics
+
+import (
+	"context"
+
+	"getnoti.com/internal/notifications/repos"
+)
+
+type GetTopicsUseCase interface {
+	Execute(ctx context.Context, req GetTopicsRequest) (GetTopicsResponse, error)
+}
+
+type getTopicsUseCase struct {
+	repository repository.TopicRepository
+}
+
+func NewGetTopicsUseCase(repository repository.TopicRepository) GetTopicsUseCase {
+	return &getTopicsUseCase{repository: repository}
+}
+
+func (uc *getTopicsUseCase) Execute(ctx context.Context, req GetTopicsRequest) (GetTopicsResponse, error) {
+	topics, err := uc.repository.GetTopics(ctx)
+	if err != nil {
+		return GetTopicsResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	return GetTopicsResponse{
+		Topics:  topics,
+		Success: true,
+		Message: "Topics retrieved successfully",
+	}, nil
+}
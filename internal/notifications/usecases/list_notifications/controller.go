@@ -0,0 +1,17 @@
+package listnotifications
+
+import (
+	"context"
+)
+
+type ListNotificationsController struct {
+	useCase ListNotificationsUseCase
+}
+
+func NewListNotificationsController(useCase ListNotificationsUseCase) *ListNotificationsController {
+	return &ListNotificationsController{useCase: useCase}
+}
+
+func (c *ListNotificationsController) ListNotifications(ctx context.Context, req ListNotificationsRequest) (ListNotificationsResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
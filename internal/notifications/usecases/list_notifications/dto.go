@@ -0,0 +1,27 @@
+package listnotifications
+
+import (
+	"getnoti.com/internal/notifications/domain"
+)
+
+type ListNotificationsRequest struct {
+	UserID     string
+	Channel    string
+	Status     string
+	TemplateID string
+	ProviderID string
+	// From and To bound created_at as RFC 3339 timestamps; From is inclusive, To exclusive
+	From string
+	To   string
+	// Cursor is the NextCursor of the previous page
+	Cursor string
+	Limit  int
+}
+
+type ListNotificationsResponse struct {
+	Notifications []*domain.Notification
+	// NextCursor fetches the next page; empty on the last page
+	NextCursor string `json:",omitempty"`
+	Success    bool
+	Message    string
+}
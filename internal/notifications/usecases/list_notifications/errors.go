@@ -0,0 +1,9 @@
+package listnotifications
+
+import "errors"
+
+var (
+	ErrInvalidFilter = errors.New("invalid notification filter")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrUnexpected    = errors.New("unexpected error occurred")
+)
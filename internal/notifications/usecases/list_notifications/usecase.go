@@ -0,0 +1,134 @@
+package listnotifications
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type ListNotificationsUseCase interface {
+	Execute(ctx context.Context, req ListNotificationsRequest) (ListNotificationsResponse, error)
+}
+
+type listNotificationsUseCase struct {
+	repository repository.NotificationRepository
+}
+
+func NewListNotificationsUseCase(repository repository.NotificationRepository) ListNotificationsUseCase {
+	return &listNotificationsUseCase{repository: repository}
+}
+
+func (uc *listNotificationsUseCase) Execute(ctx context.Context, req ListNotificationsRequest) (ListNotificationsResponse, error) {
+	filter, err := buildFilter(req)
+	if err != nil {
+		return ListNotificationsResponse{Success: false, Message: err.Error()}, err
+	}
+
+	// Fetch one extra row to know whether there is another page
+	limit := filter.Limit
+	filter.Limit = limit + 1
+
+	notifications, err := uc.repository.ListNotifications(ctx, filter)
+	if err != nil {
+		return ListNotificationsResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	nextCursor := ""
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		last := notifications[limit-1]
+		nextCursor = encodeCursor(repository.NotificationCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return ListNotificationsResponse{
+		Notifications: notifications,
+		NextCursor:    nextCursor,
+		Success:       true,
+		Message:       "Notifications retrieved successfully",
+	}, nil
+}
+
+func buildFilter(req ListNotificationsRequest) (repository.NotificationFilter, error) {
+	filter := repository.NotificationFilter{
+		UserID:     req.UserID,
+		Channel:    req.Channel,
+		Status:     req.Status,
+		TemplateID: req.TemplateID,
+		ProviderID: req.ProviderID,
+		Limit:      req.Limit,
+	}
+
+	if filter.Status != "" && !domain.NotificationStatus(filter.Status).IsValid() {
+		return filter, fmt.Errorf("%w: unknown status %q", ErrInvalidFilter, filter.Status)
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseTime("from", req.From); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = parseTime("to", req.To); err != nil {
+		return filter, err
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		return filter, fmt.Errorf("%w: from must be before to", ErrInvalidFilter)
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &cursor
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLimit
+	}
+	if filter.Limit > maxLimit {
+		filter.Limit = maxLimit
+	}
+	return filter, nil
+}
+
+func parseTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be an RFC 3339 timestamp", ErrInvalidFilter, name)
+	}
+	return t, nil
+}
+
+// encodeCursor makes an opaque cursor from the last notification on a page
+func encodeCursor(cursor repository.NotificationCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(value string) (repository.NotificationCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return repository.NotificationCursor{}, ErrInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return repository.NotificationCursor{}, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return repository.NotificationCursor{}, ErrInvalidCursor
+	}
+	return repository.NotificationCursor{CreatedAt: t, ID: id}, nil
+}
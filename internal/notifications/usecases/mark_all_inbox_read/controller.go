@@ -0,0 +1,17 @@
+package markallinboxread
+
+import (
+	"context"
+)
+
+type MarkAllInboxReadController struct {
+	useCase MarkAllInboxReadUseCase
+}
+
+func NewMarkAllInboxReadController(useCase MarkAllInboxReadUseCase) *MarkAllInboxReadController {
+	return &MarkAllInboxReadController{useCase: useCase}
+}
+
+func (c *MarkAllInboxReadController) MarkAllInboxRead(ctx context.Context, req MarkAllInboxReadRequest) (MarkAllInboxReadResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,22 @@
+package markallinboxread
+
+type MarkAllInboxReadRequest struct {
+	TenantID string
+	UserID   string
+}
+
+func (r *MarkAllInboxReadRequest) SetTenantID(id string) {
+	r.TenantID = id
+}
+
+func (r *MarkAllInboxReadRequest) SetID(id string) {
+	r.UserID = id
+}
+
+type MarkAllInboxReadResponse struct {
+	// Updated is the number of items that were unread
+	Updated     int
+	UnreadCount int
+	Success     bool
+	Message     string
+}
@@ -0,0 +1,7 @@
+package markallinboxread
+
+import "errors"
+
+var (
+	ErrUnexpected = errors.New("unexpected error occurred")
+)
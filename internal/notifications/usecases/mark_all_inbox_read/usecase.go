@@ -0,0 +1,52 @@
+package markallinboxread
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+	"getnoti.com/pkg/sse"
+)
+
+type MarkAllInboxReadUseCase interface {
+	Execute(ctx context.Context, req MarkAllInboxReadRequest) (MarkAllInboxReadResponse, error)
+}
+
+type markAllInboxReadUseCase struct {
+	repository repository.InboxRepository
+	publisher  sse.Publisher
+}
+
+func NewMarkAllInboxReadUseCase(repository repository.InboxRepository, publisher sse.Publisher) MarkAllInboxReadUseCase {
+	return &markAllInboxReadUseCase{repository: repository, publisher: publisher}
+}
+
+// Execute marks every unread item read and tells the user's other open clients
+func (uc *markAllInboxReadUseCase) Execute(ctx context.Context, req MarkAllInboxReadRequest) (MarkAllInboxReadResponse, error) {
+	updated, err := uc.repository.MarkAllInboxItemsRead(ctx, req.UserID, time.Now())
+	if err != nil {
+		return MarkAllInboxReadResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	// Items delivered while marking stay unread
+	count, err := uc.repository.CountUnreadInboxItems(ctx, req.UserID)
+	if err != nil {
+		return MarkAllInboxReadResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	if updated > 0 {
+		event, err := json.Marshal(domain.InboxEvent{Type: domain.InboxEventAllRead, UnreadCount: count})
+		if err == nil {
+			uc.publisher.Publish(domain.InboxStreamChannel(req.TenantID, req.UserID), event)
+		}
+	}
+
+	return MarkAllInboxReadResponse{
+		Updated:     updated,
+		UnreadCount: count,
+		Success:     true,
+		Message:     "Inbox marked read",
+	}, nil
+}
@@ -0,0 +1,17 @@
+package markinboxitemread
+
+import (
+	"context"
+)
+
+type MarkInboxItemReadController struct {
+	useCase MarkInboxItemReadUseCase
+}
+
+func NewMarkInboxItemReadController(useCase MarkInboxItemReadUseCase) *MarkInboxItemReadController {
+	return &MarkInboxItemReadController{useCase: useCase}
+}
+
+func (c *MarkInboxItemReadController) MarkInboxItemRead(ctx context.Context, req MarkInboxItemReadRequest) (MarkInboxItemReadResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
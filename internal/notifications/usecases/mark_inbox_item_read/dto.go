@@ -0,0 +1,21 @@
+package markinboxitemread
+
+type MarkInboxItemReadRequest struct {
+	TenantID string
+	UserID   string
+	ItemID   string
+}
+
+func (r *MarkInboxItemReadRequest) SetTenantID(id string) {
+	r.TenantID = id
+}
+
+func (r *MarkInboxItemReadRequest) SetID(id string) {
+	r.UserID = id
+}
+
+type MarkInboxItemReadResponse struct {
+	UnreadCount int
+	Success     bool
+	Message     string
+}
@@ -0,0 +1,7 @@
+package markinboxitemread
+
+import "errors"
+
+var (
+	ErrUnexpected = errors.New("unexpected error occurred")
+)
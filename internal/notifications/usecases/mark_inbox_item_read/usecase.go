@@ -0,0 +1,47 @@
+package markinboxitemread
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+	"getnoti.com/pkg/sse"
+)
+
+type MarkInboxItemReadUseCase interface {
+	Execute(ctx context.Context, req MarkInboxItemReadRequest) (MarkInboxItemReadResponse, error)
+}
+
+type markInboxItemReadUseCase struct {
+	repository repository.InboxRepository
+	publisher  sse.Publisher
+}
+
+func NewMarkInboxItemReadUseCase(repository repository.InboxRepository, publisher sse.Publisher) MarkInboxItemReadUseCase {
+	return &markInboxItemReadUseCase{repository: repository, publisher: publisher}
+}
+
+// Execute marks the item read and tells the user's other open clients
+func (uc *markInboxItemReadUseCase) Execute(ctx context.Context, req MarkInboxItemReadRequest) (MarkInboxItemReadResponse, error) {
+	if err := uc.repository.MarkInboxItemRead(ctx, req.UserID, req.ItemID, time.Now()); err != nil {
+		return MarkInboxItemReadResponse{Success: false, Message: err.Error()}, err
+	}
+
+	count, err := uc.repository.CountUnreadInboxItems(ctx, req.UserID)
+	if err != nil {
+		return MarkInboxItemReadResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	event, err := json.Marshal(domain.InboxEvent{Type: domain.InboxEventItemRead, ItemID: req.ItemID, UnreadCount: count})
+	if err == nil {
+		uc.publisher.Publish(domain.InboxStreamChannel(req.TenantID, req.UserID), event)
+	}
+
+	return MarkInboxItemReadResponse{
+		UnreadCount: count,
+		Success:     true,
+		Message:     "Inbox item marked read",
+	}, nil
+}
@@ -0,0 +1,17 @@
+package reschedulenotification
+
+import (
+	"context"
+)
+
+type RescheduleNotificationController struct {
+	useCase RescheduleNotificationUseCase
+}
+
+func NewRescheduleNotificationController(useCase RescheduleNotificationUseCase) *RescheduleNotificationController {
+	return &RescheduleNotificationController{useCase: useCase}
+}
+
+func (c *RescheduleNotificationController) RescheduleNotification(ctx context.Context, req RescheduleNotificationRequest) (RescheduleNotificationResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,21 @@
+package reschedulenotification
+
+import "time"
+
+type RescheduleNotificationRequest struct {
+	ID string
+	// ScheduledFor is the new send time and must be in the future
+	ScheduledFor time.Time
+}
+
+func (r *RescheduleNotificationRequest) SetID(id string) {
+	r.ID = id
+}
+
+type RescheduleNotificationResponse struct {
+	ID           string
+	Status       string
+	ScheduledFor *time.Time `json:",omitempty"`
+	Success      bool
+	Message      string
+}
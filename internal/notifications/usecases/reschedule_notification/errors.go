@@ -0,0 +1,8 @@
+package reschedulenotification
+
+import "errors"
+
+var (
+	ErrNotScheduled         = errors.New("only scheduled notifications can be rescheduled")
+	ErrInvalidScheduledTime = errors.New("scheduled time must be in the future")
+)
@@ -0,0 +1,56 @@
+package reschedulenotification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+	repos "getnoti.com/internal/notifications/repos/implementations"
+	"getnoti.com/internal/shared/utils"
+)
+
+type RescheduleNotificationUseCase interface {
+	Execute(ctx context.Context, req RescheduleNotificationRequest) (RescheduleNotificationResponse, error)
+}
+
+type rescheduleNotificationUseCase struct {
+	repository repository.NotificationRepository
+}
+
+func NewRescheduleNotificationUseCase(repository repository.NotificationRepository) RescheduleNotificationUseCase {
+	return &rescheduleNotificationUseCase{repository: repository}
+}
+
+func (uc *rescheduleNotificationUseCase) Execute(ctx context.Context, req RescheduleNotificationRequest) (RescheduleNotificationResponse, error) {
+	if !req.ScheduledFor.After(time.Now()) {
+		return RescheduleNotificationResponse{Success: false, Message: ErrInvalidScheduledTime.Error()}, ErrInvalidScheduledTime
+	}
+
+	notification, err := uc.repository.GetNotificationByID(ctx, req.ID)
+	if err != nil {
+		return RescheduleNotificationResponse{Success: false, Message: err.Error()}, err
+	}
+	if notification.Status != domain.StatusScheduled {
+		err := fmt.Errorf("%w: notification is %s", ErrNotScheduled, notification.Status)
+		return RescheduleNotificationResponse{ID: notification.ID, Status: string(notification.Status), Success: false, Message: err.Error()}, err
+	}
+
+	attempt := notification.NewAttempt(utils.GenerateUUID(), "", "rescheduled for "+req.ScheduledFor.UTC().Format(time.RFC3339))
+	if err := uc.repository.RescheduleNotification(ctx, attempt, req.ScheduledFor); err != nil {
+		if errors.Is(err, repos.ErrStatusConflict) {
+			err = fmt.Errorf("%w: %w", ErrNotScheduled, err)
+		}
+		return RescheduleNotificationResponse{ID: notification.ID, Status: string(notification.Status), Success: false, Message: err.Error()}, err
+	}
+
+	return RescheduleNotificationResponse{
+		ID:           notification.ID,
+		Status:       string(notification.Status),
+		ScheduledFor: &req.ScheduledFor,
+		Success:      true,
+		Message:      "Notification rescheduled successfully",
+	}, nil
+}
@@ -0,0 +1,17 @@
+package sendbatch
+
+import (
+	"context"
+)
+
+type SendBatchController struct {
+	useCase SendBatchUseCase
+}
+
+func NewSendBatchController(useCase SendBatchUseCase) *SendBatchController {
+	return &SendBatchController{useCase: useCase}
+}
+
+func (c *SendBatchController) SendBatch(ctx context.Context, req SendBatchRequest) (SendBatchResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,47 @@
+package sendbatch
+
+import "time"
+
+// SendBatchRequest sends the same notification to every recipient
+type SendBatchRequest struct {
+	TenantID   string
+	Type       string
+	Channel    string
+	TemplateID string
+	// TemplateVersion pins a template version; zero uses the published version
+	TemplateVersion int
+	Content         string
+	ProviderID      string
+	// Variables apply to every recipient; a recipient variable with the same key wins
+	Variables    []TemplateVariable
+	ScheduledFor *time.Time `json:",omitempty"`
+	// Category selects recipients' category preferences; it defaults to Type
+	Category string
+	// OverridePreferences sends even to recipients who opted out
+	OverridePreferences bool
+	// Urgent delivers during recipients' quiet hours instead of deferring
+	Urgent     bool
+	Recipients []Recipient
+}
+
+func (r *SendBatchRequest) SetTenantID(tenantID string) {
+	r.TenantID = tenantID
+}
+
+type Recipient struct {
+	UserID    string
+	Variables []TemplateVariable
+}
+
+type TemplateVariable struct {
+	Key   string
+	Value string
+}
+
+type SendBatchResponse struct {
+	BatchID         string
+	Status          string
+	TotalRecipients int
+	Success         bool
+	Message         string
+}
@@ -0,0 +1,15 @@
+package sendbatch
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoRecipients        = errors.New("batch must have at least one recipient")
+	ErrTooManyRecipients   = fmt.Errorf("batch cannot have more than %d recipients", MaxBatchRecipients)
+	ErrInvalidRecipient    = errors.New("every recipient needs a user ID")
+	ErrChannelRequired     = errors.New("channel is required")
+	ErrBatchCreationFailed = errors.New("batch creation failed")
+	ErrMissingBatchRequest = errors.New("batch has no readable send request")
+)
@@ -0,0 +1,293 @@
+package sendbatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+	sendnotification "getnoti.com/internal/notifications/usecases/send_notification"
+	"getnoti.com/internal/shared/utils"
+)
+
+const (
+	// MaxBatchRecipients is the largest batch accepted in one request
+	MaxBatchRecipients = 10000
+	// batchChunkSize is the number of recipients each worker pool job sends
+	batchChunkSize = 50
+)
+
+type SendBatchUseCase interface {
+	Execute(ctx context.Context, req SendBatchRequest) (SendBatchResponse, error)
+	// DispatchPages starts a batch for recipients that are resolved elsewhere a page at a
+	// time, such as the members of a topic, so they are never all held in memory. total is
+	// the expected number of recipients.
+	DispatchPages(ctx context.Context, batch *domain.NotificationBatch, req SendBatchRequest, total int, nextPage RecipientPager) (SendBatchResponse, error)
+}
+
+// RecipientPager returns the next page of pending recipients, numbered on from the
+// previous page, and an empty page once there are no more
+type RecipientPager func(ctx context.Context) ([]*domain.BatchRecipient, error)
+
+// Notifier is told when a batch has recipients waiting to be sent
+type Notifier interface {
+	Notify()
+}
+
+type sendBatchUseCase struct {
+	repository repository.BatchRepository
+	notifier   Notifier
+}
+
+func NewSendBatchUseCase(repository repository.BatchRepository, notifier Notifier) SendBatchUseCase {
+	return &sendBatchUseCase{
+		repository: repository,
+		notifier:   notifier,
+	}
+}
+
+// Execute stores the batch and its recipients; the batch worker then sends the
+// pending recipients in chunks. It returns as soon as the batch is accepted;
+// progress is reported per recipient by the batch status endpoint.
+func (uc *sendBatchUseCase) Execute(ctx context.Context, req SendBatchRequest) (SendBatchResponse, error) {
+	if err := validateRequest(req); err != nil {
+		return SendBatchResponse{Success: false, Message: err.Error()}, err
+	}
+
+	recipients := make([]*domain.BatchRecipient, len(req.Recipients))
+	for i, recipient := range req.Recipients {
+		recipients[i] = &domain.BatchRecipient{
+			Position:  i,
+			UserID:    recipient.UserID,
+			Variables: toDomainVariables(recipient.Variables),
+			Status:    domain.RecipientStatusPending,
+		}
+	}
+
+	return uc.dispatch(ctx, NewBatch(req), req, recipients)
+}
+
+// NewBatch creates the batch record for a request
+func NewBatch(req SendBatchRequest) *domain.NotificationBatch {
+	return &domain.NotificationBatch{
+		ID:         utils.GenerateUUID(),
+		TenantID:   req.TenantID,
+		Type:       req.Type,
+		Channel:    req.Channel,
+		TemplateID: req.TemplateID,
+	}
+}
+
+// dispatch stores the batch with the request its recipients are sent with. The
+// recipients are the durable record of the work, so a batch carries on from its
+// pending recipients after a restart.
+func (uc *sendBatchUseCase) dispatch(ctx context.Context, batch *domain.NotificationBatch, req SendBatchRequest, recipients []*domain.BatchRecipient) (SendBatchResponse, error) {
+	request, err := encodeRequest(req)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrBatchCreationFailed, err)
+		return SendBatchResponse{Success: false, Message: err.Error()}, err
+	}
+	batch.Request = request
+	batch.TotalRecipients = len(recipients)
+	if err := uc.repository.CreateBatch(ctx, batch, recipients); err != nil {
+		err = fmt.Errorf("%w: %w", ErrBatchCreationFailed, err)
+		return SendBatchResponse{Success: false, Message: err.Error()}, err
+	}
+
+	status := domain.BatchStatusCompleted
+	for _, recipient := range recipients {
+		if !recipient.IsProcessed() {
+			status = domain.BatchStatusProcessing
+			uc.notifier.Notify()
+			break
+		}
+	}
+
+	return SendBatchResponse{
+		BatchID:         batch.ID,
+		Status:          string(status),
+		TotalRecipients: batch.TotalRecipients,
+		Success:         true,
+		Message:         "Batch accepted for processing",
+	}, nil
+}
+
+// DispatchPages stores the batch first and then each page of recipients as it is
+// read. The worker starts sending the first pages while later ones are stored.
+func (uc *sendBatchUseCase) DispatchPages(ctx context.Context, batch *domain.NotificationBatch, req SendBatchRequest, total int, nextPage RecipientPager) (SendBatchResponse, error) {
+	request, err := encodeRequest(req)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrBatchCreationFailed, err)
+		return SendBatchResponse{Success: false, Message: err.Error()}, err
+	}
+	batch.Request = request
+	batch.TotalRecipients = total
+	if err := uc.repository.CreateBatch(ctx, batch, nil); err != nil {
+		err = fmt.Errorf("%w: %w", ErrBatchCreationFailed, err)
+		return SendBatchResponse{Success: false, Message: err.Error()}, err
+	}
+
+	stored := 0
+	for {
+		recipients, err := nextPage(ctx)
+		if err == nil && len(recipients) > 0 {
+			err = uc.repository.AddBatchRecipients(ctx, batch.ID, recipients)
+		}
+		if err != nil {
+			// Recipients already stored are still sent, so the batch is closed at what it has
+			uc.closeBatch(ctx, batch, stored)
+			err = fmt.Errorf("%w: %w", ErrBatchCreationFailed, err)
+			return SendBatchResponse{BatchID: batch.ID, TotalRecipients: stored, Success: false, Message: err.Error()}, err
+		}
+		if len(recipients) == 0 {
+			break
+		}
+		stored += len(recipients)
+		uc.notifier.Notify()
+	}
+
+	// Subscriptions may have changed since the recipients were counted
+	uc.closeBatch(ctx, batch, stored)
+
+	status := domain.BatchStatusProcessing
+	if stored == 0 {
+		status = domain.BatchStatusCompleted
+	}
+
+	return SendBatchResponse{
+		BatchID:         batch.ID,
+		Status:          string(status),
+		TotalRecipients: batch.TotalRecipients,
+		Success:         true,
+		Message:         "Batch accepted for processing",
+	}, nil
+}
+
+// closeBatch records the number of recipients the batch ended up with
+func (uc *sendBatchUseCase) closeBatch(ctx context.Context, batch *domain.NotificationBatch, stored int) {
+	if stored == batch.TotalRecipients {
+		return
+	}
+	if err := uc.repository.SetBatchTotal(ctx, batch.ID, stored); err == nil {
+		batch.TotalRecipients = stored
+	}
+}
+
+// encodeRequest stores the part of the request shared by every recipient
+func encodeRequest(req SendBatchRequest) ([]byte, error) {
+	req.Recipients = nil
+	return json.Marshal(req)
+}
+
+// decodeRequest reads the request a batch's recipients are sent with
+func decodeRequest(batch *domain.NotificationBatch) (SendBatchRequest, error) {
+	var req SendBatchRequest
+	if len(batch.Request) == 0 {
+		return req, ErrMissingBatchRequest
+	}
+	if err := json.Unmarshal(batch.Request, &req); err != nil {
+		return req, fmt.Errorf("%w: %w", ErrMissingBatchRequest, err)
+	}
+	return req, nil
+}
+
+func validateRequest(req SendBatchRequest) error {
+	if req.Channel == "" {
+		return ErrChannelRequired
+	}
+	if len(req.Recipients) == 0 {
+		return ErrNoRecipients
+	}
+	if len(req.Recipients) > MaxBatchRecipients {
+		return ErrTooManyRecipients
+	}
+	for i, recipient := range req.Recipients {
+		if recipient.UserID == "" {
+			return fmt.Errorf("%w: recipient %d", ErrInvalidRecipient, i)
+		}
+	}
+	return nil
+}
+
+// batchJob sends a chunk of a batch's recipients on the worker pool
+type batchJob struct {
+	sendUseCase *sendnotification.SendNotificationUseCase
+	repository  repository.BatchRepository
+	request     SendBatchRequest
+	recipients  []*domain.BatchRecipient
+}
+
+func (j *batchJob) Process(ctx context.Context) error {
+	var errs []error
+	for _, recipient := range j.recipients {
+		resp, err := j.sendUseCase.Execute(ctx, j.sendRequest(recipient))
+
+		recipient.NotificationID = resp.ID
+		recipient.Status = domain.NotificationStatus(resp.Status)
+		recipient.Error = resp.Reason
+		if err != nil {
+			recipient.Error = err.Error()
+			// A send that stopped before dispatch has no outcome of its own
+			if recipient.Status == "" || !recipient.IsProcessed() {
+				recipient.Status = domain.StatusFailed
+			}
+		}
+
+		if err := j.repository.UpdateBatchRecipient(ctx, recipient); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sendRequest builds the recipient's send, with its variables layered over the batch variables
+func (j *batchJob) sendRequest(recipient *domain.BatchRecipient) sendnotification.SendNotificationRequest {
+	variables := make([]sendnotification.TemplateVariable, 0, len(j.request.Variables)+len(recipient.Variables))
+	overridden := make(map[string]bool, len(recipient.Variables))
+	for _, v := range recipient.Variables {
+		overridden[v.Key] = true
+	}
+	for _, v := range j.request.Variables {
+		if !overridden[v.Key] {
+			variables = append(variables, sendnotification.TemplateVariable{Key: v.Key, Value: v.Value})
+		}
+	}
+	for _, v := range recipient.Variables {
+		variables = append(variables, sendnotification.TemplateVariable{Key: v.Key, Value: v.Value})
+	}
+
+	return sendnotification.SendNotificationRequest{
+		TenantID:            j.request.TenantID,
+		UserID:              recipient.UserID,
+		Type:                j.request.Type,
+		Channel:             j.request.Channel,
+		TemplateID:          j.request.TemplateID,
+		TemplateVersion:     j.request.TemplateVersion,
+		Content:             j.request.Content,
+		ProviderID:          j.request.ProviderID,
+		Variables:           variables,
+		ScheduledFor:        j.request.ScheduledFor,
+		Category:            j.request.Category,
+		OverridePreferences: j.request.OverridePreferences,
+		Urgent:              j.request.Urgent,
+	}
+}
+
+// failRecipients records the same failure for every recipient
+func failRecipients(ctx context.Context, repository repository.BatchRepository, recipients []*domain.BatchRecipient, reason string) {
+	for _, recipient := range recipients {
+		recipient.Status = domain.StatusFailed
+		recipient.Error = reason
+		_ = repository.UpdateBatchRecipient(ctx, recipient)
+	}
+}
+
+func toDomainVariables(variables []TemplateVariable) []domain.TemplateVariable {
+	result := make([]domain.TemplateVariable, len(variables))
+	for i, v := range variables {
+		result[i] = domain.TemplateVariable{Key: v.Key, Value: v.Value}
+	}
+	return result
+}
@@ -0,0 +1,304 @@
+package sendbatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	notificationServices "getnoti.com/internal/notifications/services"
+	sendnotification "getnoti.com/internal/notifications/usecases/send_notification"
+	providerRepos "getnoti.com/internal/providers/repos"
+	providerServices "getnoti.com/internal/providers/services"
+	templateServices "getnoti.com/internal/templates/services"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	tenantRepos "getnoti.com/internal/tenants/repos"
+	tenantServices "getnoti.com/internal/tenants/services"
+	"getnoti.com/pkg/cache"
+	"getnoti.com/pkg/logger"
+	"getnoti.com/pkg/workerpool"
+)
+
+// BatchWorker sends the pending recipients of batch sends. Recipients live in
+// each tenant's database and are claimed while they are sent, so batches carry
+// on after a restart; a claim that never finishes expires and the recipient is
+// claimed again. The worker only claims chunks while the worker pool has room,
+// and the rest of a batch waits in the database for the next poll.
+type BatchWorker struct {
+	tenants interface {
+		GetAllTenants(ctx context.Context) ([]tenantDomain.Tenant, error)
+	}
+	repositoryFactory interface {
+		GetBatchRepositoryForTenant(tenantID string) (notificationRepos.BatchRepository, error)
+		GetNotificationRepositoryForTenant(tenantID string) (notificationRepos.NotificationRepository, error)
+		GetProviderRepositoryForTenant(tenantID string) (providerRepos.ProviderRepository, error)
+		GetUserRepositoryForTenant(tenantID string) (tenantRepos.UserRepository, error)
+	}
+	providerService       *providerServices.ProviderService
+	templateService       *templateServices.TemplateService
+	preferencesCache      *cache.GenericCache
+	userPreferenceService *tenantServices.UserPreferenceService
+	digestService         *notificationServices.DigestService
+	frequencyCapService   *notificationServices.FrequencyCapService
+	workerPool            *workerpool.WorkerPool
+	logger                logger.Logger
+	stopCh                chan struct{}
+	notifyCh              chan struct{}
+	pollInterval          time.Duration
+	claimTimeout          time.Duration
+	maxQueuedChunks       int
+	chunksPerTenant       int
+}
+
+// NewBatchWorker creates a worker that polls for pending batch recipients every pollInterval
+func NewBatchWorker(
+	tenants interface {
+		GetAllTenants(ctx context.Context) ([]tenantDomain.Tenant, error)
+	},
+	repositoryFactory interface {
+		GetBatchRepositoryForTenant(tenantID string) (notificationRepos.BatchRepository, error)
+		GetNotificationRepositoryForTenant(tenantID string) (notificationRepos.NotificationRepository, error)
+		GetProviderRepositoryForTenant(tenantID string) (providerRepos.ProviderRepository, error)
+		GetUserRepositoryForTenant(tenantID string) (tenantRepos.UserRepository, error)
+	},
+	providerService *providerServices.ProviderService,
+	templateService *templateServices.TemplateService,
+	preferencesCache *cache.GenericCache,
+	userPreferenceService *tenantServices.UserPreferenceService,
+	digestService *notificationServices.DigestService,
+	frequencyCapService *notificationServices.FrequencyCapService,
+	workerPool *workerpool.WorkerPool,
+	logger logger.Logger,
+	pollInterval time.Duration,
+) *BatchWorker {
+	return &BatchWorker{
+		tenants:               tenants,
+		repositoryFactory:     repositoryFactory,
+		providerService:       providerService,
+		templateService:       templateService,
+		preferencesCache:      preferencesCache,
+		userPreferenceService: userPreferenceService,
+		digestService:         digestService,
+		frequencyCapService:   frequencyCapService,
+		workerPool:            workerPool,
+		logger:                logger,
+		stopCh:                make(chan struct{}),
+		notifyCh:              make(chan struct{}, 1),
+		pollInterval:          pollInterval,
+		claimTimeout:          15 * time.Minute, // Claimed chunks that are not sent by then are sent again
+		maxQueuedChunks:       100,              // Stop claiming while this many chunks wait in the pool
+		chunksPerTenant:       20,               // Claim at most 20 chunks per tenant each poll
+	}
+}
+
+// Start begins polling for pending recipients in the background. The first
+// poll runs right away so batches interrupted by a restart carry on.
+func (w *BatchWorker) Start(ctx context.Context) error {
+	w.logger.Info("Starting batch worker",
+		logger.Duration("poll_interval", w.pollInterval))
+
+	w.Notify()
+	go w.poll(ctx)
+
+	return nil
+}
+
+// Stop stops the worker
+func (w *BatchWorker) Stop() {
+	w.logger.Info("Stopping batch worker")
+	close(w.stopCh)
+}
+
+// Notify wakes the worker to send a batch that was just stored, without
+// waiting for the next poll
+func (w *BatchWorker) Notify() {
+	select {
+	case w.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (w *BatchWorker) poll(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-w.notifyCh:
+		case <-w.stopCh:
+			w.logger.Info("Stopped polling for batch recipients")
+			return
+		case <-ctx.Done():
+			w.logger.Info("Context done, stopped polling for batch recipients")
+			return
+		}
+
+		if err := w.processPendingRecipients(ctx); err != nil {
+			w.logger.Error("Error processing batch recipients", logger.Err(err))
+		}
+	}
+}
+
+// processPendingRecipients submits every tenant's pending recipients to the worker pool
+func (w *BatchWorker) processPendingRecipients(ctx context.Context) error {
+	tenants, err := w.tenants.GetAllTenants(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenants: %w", err)
+	}
+
+	for _, tenant := range tenants {
+		full, err := w.processTenant(ctx, tenant.ID)
+		if err != nil {
+			w.logger.Error("Failed to process batch recipients for tenant",
+				logger.String("tenant_id", tenant.ID),
+				logger.Err(err))
+		}
+		if full {
+			// The remaining recipients stay pending and are claimed on a later poll
+			w.logger.Debug("Batch worker pool is busy, deferring batch recipients")
+			return nil
+		}
+	}
+	return nil
+}
+
+// processTenant claims the tenant's pending recipients a chunk at a time and
+// submits them. It reports whether it stopped because the pool is full.
+func (w *BatchWorker) processTenant(ctx context.Context, tenantID string) (bool, error) {
+	batchRepo, err := w.repositoryFactory.GetBatchRepositoryForTenant(tenantID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get batch repository: %w", err)
+	}
+
+	var sendUseCase *sendnotification.SendNotificationUseCase
+	requests := make(map[string]SendBatchRequest)
+	for i := 0; i < w.chunksPerTenant; i++ {
+		if w.poolFull() {
+			return true, nil
+		}
+
+		now := time.Now()
+		recipients, err := batchRepo.ClaimBatchRecipients(ctx, now, now.Add(w.claimTimeout), batchChunkSize)
+		if err != nil {
+			w.release(ctx, batchRepo, recipients)
+			return false, err
+		}
+		if len(recipients) == 0 {
+			return false, nil
+		}
+
+		if sendUseCase == nil {
+			sendUseCase, err = w.newSendUseCase(tenantID)
+			if err != nil {
+				w.release(ctx, batchRepo, recipients)
+				return false, err
+			}
+		}
+
+		chunks := groupByBatch(recipients)
+		for j, chunk := range chunks {
+			request, err := w.batchRequest(ctx, batchRepo, requests, chunk[0].BatchID)
+			if errors.Is(err, ErrMissingBatchRequest) {
+				// Without its request the batch can never be sent
+				failRecipients(ctx, batchRepo, chunk, err.Error())
+				continue
+			}
+			if err != nil {
+				for _, rest := range chunks[j:] {
+					w.release(ctx, batchRepo, rest)
+				}
+				return false, err
+			}
+
+			job := &batchJob{
+				sendUseCase: sendUseCase,
+				repository:  batchRepo,
+				request:     request,
+				recipients:  chunk,
+			}
+			if err := w.workerPool.Submit(job); err != nil {
+				for _, rest := range chunks[j:] {
+					w.release(ctx, batchRepo, rest)
+				}
+				return true, nil
+			}
+		}
+
+		w.logger.Debug("Submitted batch recipients",
+			logger.String("tenant_id", tenantID),
+			logger.Int("count", len(recipients)))
+
+		if len(recipients) < batchChunkSize {
+			return false, nil
+		}
+	}
+	return false, nil
+}
+
+func (w *BatchWorker) newSendUseCase(tenantID string) (*sendnotification.SendNotificationUseCase, error) {
+	notificationRepo, err := w.repositoryFactory.GetNotificationRepositoryForTenant(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification repository: %w", err)
+	}
+	providerRepo, err := w.repositoryFactory.GetProviderRepositoryForTenant(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider repository: %w", err)
+	}
+	userRepo, err := w.repositoryFactory.GetUserRepositoryForTenant(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user repository: %w", err)
+	}
+	return sendnotification.NewSendNotificationUseCase(w.providerService, w.templateService, providerRepo, notificationRepo, userRepo, w.preferencesCache, w.userPreferenceService, w.digestService, w.frequencyCapService), nil
+}
+
+// batchRequest reads the batch's send request, caching it for the rest of the poll
+func (w *BatchWorker) batchRequest(ctx context.Context, batchRepo notificationRepos.BatchRepository, requests map[string]SendBatchRequest, batchID string) (SendBatchRequest, error) {
+	if request, ok := requests[batchID]; ok {
+		return request, nil
+	}
+	batch, err := batchRepo.GetBatch(ctx, batchID)
+	if err != nil {
+		return SendBatchRequest{}, err
+	}
+	request, err := decodeRequest(batch)
+	if err != nil {
+		return SendBatchRequest{}, err
+	}
+	requests[batchID] = request
+	return request, nil
+}
+
+// poolFull reports whether enough chunks are already waiting in the pool
+func (w *BatchWorker) poolFull() bool {
+	return w.workerPool.GetMetrics().JobsInQueue >= int64(w.maxQueuedChunks)
+}
+
+// release hands claimed recipients back so the next poll claims them again
+func (w *BatchWorker) release(ctx context.Context, batchRepo notificationRepos.BatchRepository, recipients []*domain.BatchRecipient) {
+	if len(recipients) == 0 {
+		return
+	}
+	if err := batchRepo.ReleaseBatchRecipients(ctx, recipients); err != nil {
+		// The claims expire on their own, after which the recipients are claimed again
+		w.logger.Warn("Failed to release batch recipients", logger.Err(err))
+	}
+}
+
+// groupByBatch splits claimed recipients into one chunk per batch
+func groupByBatch(recipients []*domain.BatchRecipient) [][]*domain.BatchRecipient {
+	var chunks [][]*domain.BatchRecipient
+	index := make(map[string]int)
+	for _, recipient := range recipients {
+		i, ok := index[recipient.BatchID]
+		if !ok {
+			i = len(chunks)
+			index[recipient.BatchID] = i
+			chunks = append(chunks, nil)
+		}
+		chunks[i] = append(chunks[i], recipient)
+	}
+	return chunks
+}
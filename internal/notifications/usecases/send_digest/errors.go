@@ -0,0 +1,7 @@
+package senddigest
+
+import "errors"
+
+var (
+	ErrNoDigestEvents = errors.New("digest has no events")
+)
@@ -0,0 +1,97 @@
+package senddigest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+	sendnotification "getnoti.com/internal/notifications/usecases/send_notification"
+)
+
+// digestType is the notification type of a sent digest
+const digestType = "digest"
+
+type SendDigestUseCase interface {
+	Execute(ctx context.Context, digest *domain.Digest) (sendnotification.SendNotificationResponse, error)
+}
+
+type sendDigestUseCase struct {
+	repository  repository.DigestRepository
+	sendUseCase *sendnotification.SendNotificationUseCase
+}
+
+func NewSendDigestUseCase(repository repository.DigestRepository, sendUseCase *sendnotification.SendNotificationUseCase) SendDigestUseCase {
+	return &sendDigestUseCase{
+		repository:  repository,
+		sendUseCase: sendUseCase,
+	}
+}
+
+// Execute claims an open digest and sends its events as one notification. The
+// digest template receives digest.key, digest.count and the events' data as
+// the list digest.items. Events arriving once the digest is claimed open the
+// user's next digest.
+func (uc *sendDigestUseCase) Execute(ctx context.Context, digest *domain.Digest) (sendnotification.SendNotificationResponse, error) {
+	if err := uc.repository.ClaimDigest(ctx, digest.ID); err != nil {
+		return sendnotification.SendNotificationResponse{Status: string(digest.Status), Error: err.Error()}, err
+	}
+	digest.Status = domain.DigestStatusSending
+
+	req, err := uc.sendRequest(ctx, digest)
+	if err != nil {
+		uc.complete(ctx, digest, "", err)
+		return sendnotification.SendNotificationResponse{Status: string(digest.Status), Error: err.Error()}, err
+	}
+
+	resp, err := uc.sendUseCase.Execute(ctx, req)
+	uc.complete(ctx, digest, resp.ID, err)
+	return resp, err
+}
+
+// sendRequest builds the digest notification from the digest's events
+func (uc *sendDigestUseCase) sendRequest(ctx context.Context, digest *domain.Digest) (sendnotification.SendNotificationRequest, error) {
+	events, err := uc.repository.GetDigestEvents(ctx, digest.ID)
+	if err != nil {
+		return sendnotification.SendNotificationRequest{}, err
+	}
+	if len(events) == 0 {
+		return sendnotification.SendNotificationRequest{}, ErrNoDigestEvents
+	}
+
+	items := make([]map[string]interface{}, len(events))
+	for i, event := range events {
+		items[i] = event.Data
+	}
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return sendnotification.SendNotificationRequest{}, fmt.Errorf("failed to encode digest items: %w", err)
+	}
+
+	return sendnotification.SendNotificationRequest{
+		TenantID:   digest.TenantID,
+		UserID:     digest.UserID,
+		Type:       digestType,
+		Category:   digest.Category,
+		Channel:    digest.Channel,
+		TemplateID: digest.TemplateID,
+		Variables: []sendnotification.TemplateVariable{
+			{Key: "digest.key", Value: digest.Key},
+			{Key: "digest.count", Value: strconv.Itoa(len(events))},
+			{Key: "digest.items", Value: string(encoded)},
+		},
+	}, nil
+}
+
+// complete records the digest notification, or why the digest could not be sent
+func (uc *sendDigestUseCase) complete(ctx context.Context, digest *domain.Digest, notificationID string, sendErr error) {
+	digest.NotificationID = notificationID
+	digest.Status = domain.DigestStatusSent
+	if sendErr != nil {
+		digest.Status = domain.DigestStatusFailed
+		digest.Error = sendErr.Error()
+	}
+	_ = uc.repository.CompleteDigest(ctx, digest)
+}
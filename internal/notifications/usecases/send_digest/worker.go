@@ -0,0 +1,205 @@
+package senddigest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	repos "getnoti.com/internal/notifications/repos/implementations"
+	notificationServices "getnoti.com/internal/notifications/services"
+	sendnotification "getnoti.com/internal/notifications/usecases/send_notification"
+	providerRepos "getnoti.com/internal/providers/repos"
+	providerServices "getnoti.com/internal/providers/services"
+	templateServices "getnoti.com/internal/templates/services"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	tenantRepos "getnoti.com/internal/tenants/repos"
+	tenantServices "getnoti.com/internal/tenants/services"
+	"getnoti.com/pkg/cache"
+	"getnoti.com/pkg/logger"
+	"getnoti.com/pkg/workerpool"
+)
+
+// DigestWorker sends digests once their window ends. Digests live in each
+// tenant's database, so open windows survive restarts; the worker polls every
+// tenant and submits the due digests to the worker pool.
+type DigestWorker struct {
+	tenants interface {
+		GetAllTenants(ctx context.Context) ([]tenantDomain.Tenant, error)
+	}
+	repositoryFactory interface {
+		GetDigestRepositoryForTenant(tenantID string) (notificationRepos.DigestRepository, error)
+		GetNotificationRepositoryForTenant(tenantID string) (notificationRepos.NotificationRepository, error)
+		GetProviderRepositoryForTenant(tenantID string) (providerRepos.ProviderRepository, error)
+		GetUserRepositoryForTenant(tenantID string) (tenantRepos.UserRepository, error)
+	}
+	providerService       *providerServices.ProviderService
+	templateService       *templateServices.TemplateService
+	preferencesCache      *cache.GenericCache
+	userPreferenceService *tenantServices.UserPreferenceService
+	frequencyCapService   *notificationServices.FrequencyCapService
+	workerPool            *workerpool.WorkerPool
+	logger                logger.Logger
+	stopCh                chan struct{}
+	pollInterval          time.Duration
+	batchSize             int
+}
+
+// NewDigestWorker creates a worker that polls for due digests every pollInterval
+func NewDigestWorker(
+	tenants interface {
+		GetAllTenants(ctx context.Context) ([]tenantDomain.Tenant, error)
+	},
+	repositoryFactory interface {
+		GetDigestRepositoryForTenant(tenantID string) (notificationRepos.DigestRepository, error)
+		GetNotificationRepositoryForTenant(tenantID string) (notificationRepos.NotificationRepository, error)
+		GetProviderRepositoryForTenant(tenantID string) (providerRepos.ProviderRepository, error)
+		GetUserRepositoryForTenant(tenantID string) (tenantRepos.UserRepository, error)
+	},
+	providerService *providerServices.ProviderService,
+	templateService *templateServices.TemplateService,
+	preferencesCache *cache.GenericCache,
+	userPreferenceService *tenantServices.UserPreferenceService,
+	frequencyCapService *notificationServices.FrequencyCapService,
+	workerPool *workerpool.WorkerPool,
+	logger logger.Logger,
+	pollInterval time.Duration,
+) *DigestWorker {
+	return &DigestWorker{
+		tenants:               tenants,
+		repositoryFactory:     repositoryFactory,
+		providerService:       providerService,
+		templateService:       templateService,
+		preferencesCache:      preferencesCache,
+		userPreferenceService: userPreferenceService,
+		frequencyCapService:   frequencyCapService,
+		workerPool:            workerPool,
+		logger:                logger,
+		stopCh:                make(chan struct{}),
+		pollInterval:          pollInterval,
+		batchSize:             100, // Send at most 100 digests per tenant each poll
+	}
+}
+
+// Start begins polling for due digests in the background
+func (w *DigestWorker) Start(ctx context.Context) error {
+	w.logger.Info("Starting digest worker",
+		logger.Duration("poll_interval", w.pollInterval))
+
+	go w.poll(ctx)
+
+	return nil
+}
+
+// Stop stops the worker
+func (w *DigestWorker) Stop() {
+	w.logger.Info("Stopping digest worker")
+	close(w.stopCh)
+}
+
+func (w *DigestWorker) poll(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.processDueDigests(ctx); err != nil {
+				w.logger.Error("Error processing due digests", logger.Err(err))
+			}
+		case <-w.stopCh:
+			w.logger.Info("Stopped polling for due digests")
+			return
+		case <-ctx.Done():
+			w.logger.Info("Context done, stopped polling for due digests")
+			return
+		}
+	}
+}
+
+// processDueDigests submits every tenant's due digests to the worker pool
+func (w *DigestWorker) processDueDigests(ctx context.Context) error {
+	tenants, err := w.tenants.GetAllTenants(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenants: %w", err)
+	}
+
+	now := time.Now()
+	for _, tenant := range tenants {
+		if err := w.processTenant(ctx, tenant.ID, now); err != nil {
+			w.logger.Error("Failed to process digests for tenant",
+				logger.String("tenant_id", tenant.ID),
+				logger.Err(err))
+		}
+	}
+	return nil
+}
+
+func (w *DigestWorker) processTenant(ctx context.Context, tenantID string, now time.Time) error {
+	digestRepo, err := w.repositoryFactory.GetDigestRepositoryForTenant(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get digest repository: %w", err)
+	}
+
+	digests, err := digestRepo.GetDueDigests(ctx, now, w.batchSize)
+	if err != nil {
+		return err
+	}
+	if len(digests) == 0 {
+		return nil
+	}
+
+	notificationRepo, err := w.repositoryFactory.GetNotificationRepositoryForTenant(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification repository: %w", err)
+	}
+	providerRepo, err := w.repositoryFactory.GetProviderRepositoryForTenant(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get provider repository: %w", err)
+	}
+	userRepo, err := w.repositoryFactory.GetUserRepositoryForTenant(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get user repository: %w", err)
+	}
+
+	// Digest notifications carry no digest key, so they are sent rather than digested again
+	sendUseCase := sendnotification.NewSendNotificationUseCase(w.providerService, w.templateService, providerRepo, notificationRepo, userRepo, w.preferencesCache, w.userPreferenceService, nil, w.frequencyCapService)
+	useCase := NewSendDigestUseCase(digestRepo, sendUseCase)
+	for _, digest := range digests {
+		digest.TenantID = tenantID
+		job := &digestJob{useCase: useCase, digest: digest, logger: w.logger}
+		if err := w.workerPool.Submit(job); err != nil {
+			// The digest stays open and is picked up again on the next poll
+			return fmt.Errorf("failed to submit digest %s: %w", digest.ID, err)
+		}
+	}
+
+	w.logger.Debug("Submitted due digests",
+		logger.String("tenant_id", tenantID),
+		logger.Int("count", len(digests)))
+	return nil
+}
+
+// digestJob sends one due digest on the worker pool
+type digestJob struct {
+	useCase SendDigestUseCase
+	digest  *domain.Digest
+	logger  logger.Logger
+}
+
+func (j *digestJob) Process(ctx context.Context) error {
+	_, err := j.useCase.Execute(ctx, j.digest)
+	if errors.Is(err, repos.ErrDigestNotOpen) {
+		// Already claimed by an earlier poll
+		return nil
+	}
+	if err != nil {
+		j.logger.Error("Failed to send digest",
+			logger.String("tenant_id", j.digest.TenantID),
+			logger.String("digest_id", j.digest.ID),
+			logger.Err(err))
+	}
+	return err
+}
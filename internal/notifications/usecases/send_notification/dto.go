@@ -1,23 +1,59 @@
 package sendnotification
 
+import "time"
+
 type SendNotificationRequest struct {
-    TenantID    string
-    UserID      string
-    Type        string
-    Channel     string
-    TemplateID  string
-    Content     string
-    ProviderID  string
-    Variables   []TemplateVariable
+	TenantID   string
+	UserID     string
+	Type       string
+	Channel    string
+	TemplateID string
+	// TemplateVersion pins a template version; zero uses the published version
+	TemplateVersion int
+	Content         string
+	ProviderID      string
+	Variables       []TemplateVariable
+	// ScheduledFor delays the send until the given time; past times send immediately
+	ScheduledFor *time.Time `json:",omitempty"`
+	// Category selects the user's category preferences; it defaults to Type
+	Category string
+	// OverridePreferences sends even if the user opted out, for critical or transactional notifications
+	OverridePreferences bool
+	// Urgent delivers during the user's quiet hours instead of deferring until they end
+	Urgent bool
+	// DigestKey collects the notification into the user's digest for the key when
+	// they have digests enabled for its category; the template then renders the digest
+	DigestKey string `json:",omitempty"`
 }
 
+// StatusDigested reports a notification collected into a digest instead of sent
+const StatusDigested = "digested"
+
 type SendNotificationResponse struct {
-    ID      string
-    Status  string
-    Error   string 
+	ID              string
+	Status          string
+	TemplateVersion int        `json:",omitempty"`
+	Locale          string     `json:",omitempty"`
+	ScheduledFor    *time.Time `json:",omitempty"`
+	// Reason explains why a suppressed notification was not sent
+	Reason string `json:",omitempty"`
+	// DigestID is the digest a digested notification was collected into
+	DigestID string `json:",omitempty"`
+	Error    string
+	Attempts []ProviderAttempt
+}
+
+// ProviderAttempt records the outcome of dispatching through a single provider
+type ProviderAttempt struct {
+	ProviderID  string
+	Success     bool
+	Message     string
+	AttemptedAt time.Time
+	// InvalidTokens are device tokens the provider reported as unregistered
+	InvalidTokens []string
 }
 
 type TemplateVariable struct {
-    Key   string
-    Value string
+	Key   string
+	Value string
 }
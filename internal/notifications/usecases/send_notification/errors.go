@@ -1,10 +1,14 @@
-
 package sendnotification
 
 import "errors"
 
 var (
-    ErrNotificationCreationFailed = errors.New("notification creation failed")
-    ErrNotificationNotFound       = errors.New("notification not found")
-    ErrUnexpected                 = errors.New("unexpected error occurred")
+	ErrNotificationCreationFailed = errors.New("notification creation failed")
+	ErrNotificationNotFound       = errors.New("notification not found")
+	ErrUnexpected                 = errors.New("unexpected error occurred")
+	ErrAllProvidersFailed         = errors.New("all providers failed to send notification")
+	ErrNoReceiverAddress          = errors.New("user has no address for the channel")
+	ErrInvalidIdempotencyKey      = errors.New("idempotency key must be at most 255 characters")
+	ErrIdempotencyKeyMismatch     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress   = errors.New("a request with this idempotency key is still in progress")
 )
@@ -0,0 +1,153 @@
+package sendnotification
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	repos "getnoti.com/internal/notifications/repos/implementations"
+)
+
+const (
+	// DefaultIdempotencyRetention is how long a key replays its original response
+	DefaultIdempotencyRetention = 24 * time.Hour
+	// idempotencyLockTimeout is how long an unfinished request holds its key
+	// before a retry may assume it was abandoned and take the key over
+	idempotencyLockTimeout  = time.Minute
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotentSendNotificationUseCase sends a notification at most once per idempotency key
+type IdempotentSendNotificationUseCase struct {
+	useCase    *SendNotificationUseCase
+	repository notificationRepos.IdempotencyRepository
+	retention  time.Duration
+}
+
+func NewIdempotentSendNotificationUseCase(useCase *SendNotificationUseCase, repository notificationRepos.IdempotencyRepository, retention time.Duration) *IdempotentSendNotificationUseCase {
+	return &IdempotentSendNotificationUseCase{
+		useCase:    useCase,
+		repository: repository,
+		retention:  retention,
+	}
+}
+
+// Execute sends the notification unless the key was already used. A retry with
+// the same request returns the original response with replayed set; a different
+// request under the same key fails with ErrIdempotencyKeyMismatch. Without a key
+// the send is not deduplicated.
+func (u *IdempotentSendNotificationUseCase) Execute(ctx context.Context, key string, req SendNotificationRequest) (resp SendNotificationResponse, replayed bool, err error) {
+	if key == "" {
+		resp, err = u.useCase.Execute(ctx, req)
+		return resp, false, err
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return SendNotificationResponse{}, false, ErrInvalidIdempotencyKey
+	}
+
+	hash, err := hashRequest(req)
+	if err != nil {
+		return SendNotificationResponse{}, false, err
+	}
+
+	existing, err := u.reserve(ctx, key, hash)
+	if err != nil {
+		return SendNotificationResponse{}, false, err
+	}
+	if existing != nil {
+		if err := json.Unmarshal(existing.Response, &resp); err != nil {
+			return SendNotificationResponse{}, false, fmt.Errorf("failed to decode stored response: %w", err)
+		}
+		return resp, true, nil
+	}
+
+	resp, err = u.useCase.Execute(ctx, req)
+	if err != nil {
+		// Failed sends are not remembered so the client can retry them
+		_ = u.repository.DeleteIdempotencyKey(ctx, key)
+		return resp, false, err
+	}
+
+	body, err := json.Marshal(resp)
+	if err == nil {
+		err = u.repository.CompleteIdempotencyKey(ctx, key, body)
+	}
+	if err != nil {
+		// The notification was sent; release the key rather than block retries forever
+		_ = u.repository.DeleteIdempotencyKey(ctx, key)
+	}
+	return resp, false, nil
+}
+
+// reserve claims the key for this request. It returns the stored key when a
+// completed request with the same hash can be replayed.
+func (u *IdempotentSendNotificationUseCase) reserve(ctx context.Context, key, hash string) (*domain.IdempotencyKey, error) {
+	now := time.Now()
+
+	// Expired keys are purged first so they can be reused
+	if _, err := u.repository.DeleteExpiredIdempotencyKeys(ctx, now); err != nil {
+		return nil, err
+	}
+
+	record := &domain.IdempotencyKey{
+		Key:         key,
+		RequestHash: hash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(u.retention),
+	}
+	created, err := u.repository.CreateIdempotencyKey(ctx, record)
+	if err != nil || created {
+		return nil, err
+	}
+
+	existing, err := u.repository.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, repos.ErrIdempotencyKeyNotFound) {
+			return nil, ErrIdempotencyKeyInProgress
+		}
+		return nil, err
+	}
+	if existing.RequestHash != hash {
+		return nil, ErrIdempotencyKeyMismatch
+	}
+	if existing.IsComplete() {
+		return existing, nil
+	}
+	if now.Sub(existing.CreatedAt) < idempotencyLockTimeout {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+
+	// The original request never finished; take the key over. Only the retry
+	// that removes the row it read may do so; the others see it in progress.
+	deleted, err := u.repository.DeleteStaleIdempotencyKey(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+	created, err = u.repository.CreateIdempotencyKey(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+	return nil, nil
+}
+
+// hashRequest fingerprints the request body so a reused key can be detected
+func hashRequest(req SendNotificationRequest) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,198 @@
+package sendnotification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	repos "getnoti.com/internal/notifications/repos/implementations"
+	notificationServices "getnoti.com/internal/notifications/services"
+	providerRepos "getnoti.com/internal/providers/repos"
+	providerServices "getnoti.com/internal/providers/services"
+	templateServices "getnoti.com/internal/templates/services"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	tenantRepos "getnoti.com/internal/tenants/repos"
+	tenantServices "getnoti.com/internal/tenants/services"
+	"getnoti.com/pkg/cache"
+	"getnoti.com/pkg/logger"
+	"getnoti.com/pkg/workerpool"
+)
+
+// ScheduledNotificationWorker releases scheduled notifications once their send
+// time arrives. Schedules live in each tenant's notifications table, so nothing
+// is lost across restarts; the worker polls every tenant and submits the due
+// notifications to the worker pool. A release interrupted before the
+// notification was queued is picked up again once its claim expires.
+type ScheduledNotificationWorker struct {
+	tenants interface {
+		GetAllTenants(ctx context.Context) ([]tenantDomain.Tenant, error)
+	}
+	repositoryFactory interface {
+		GetNotificationRepositoryForTenant(tenantID string) (notificationRepos.NotificationRepository, error)
+		GetProviderRepositoryForTenant(tenantID string) (providerRepos.ProviderRepository, error)
+		GetUserRepositoryForTenant(tenantID string) (tenantRepos.UserRepository, error)
+	}
+	providerService       *providerServices.ProviderService
+	templateService       *templateServices.TemplateService
+	preferencesCache      *cache.GenericCache
+	userPreferenceService *tenantServices.UserPreferenceService
+	frequencyCapService   *notificationServices.FrequencyCapService
+	workerPool            *workerpool.WorkerPool
+	logger                logger.Logger
+	stopCh                chan struct{}
+	pollInterval          time.Duration
+	batchSize             int
+}
+
+// NewScheduledNotificationWorker creates a worker that polls for due notifications every pollInterval
+func NewScheduledNotificationWorker(
+	tenants interface {
+		GetAllTenants(ctx context.Context) ([]tenantDomain.Tenant, error)
+	},
+	repositoryFactory interface {
+		GetNotificationRepositoryForTenant(tenantID string) (notificationRepos.NotificationRepository, error)
+		GetProviderRepositoryForTenant(tenantID string) (providerRepos.ProviderRepository, error)
+		GetUserRepositoryForTenant(tenantID string) (tenantRepos.UserRepository, error)
+	},
+	providerService *providerServices.ProviderService,
+	templateService *templateServices.TemplateService,
+	preferencesCache *cache.GenericCache,
+	userPreferenceService *tenantServices.UserPreferenceService,
+	frequencyCapService *notificationServices.FrequencyCapService,
+	workerPool *workerpool.WorkerPool,
+	logger logger.Logger,
+	pollInterval time.Duration,
+) *ScheduledNotificationWorker {
+	return &ScheduledNotificationWorker{
+		tenants:               tenants,
+		repositoryFactory:     repositoryFactory,
+		providerService:       providerService,
+		templateService:       templateService,
+		preferencesCache:      preferencesCache,
+		userPreferenceService: userPreferenceService,
+		frequencyCapService:   frequencyCapService,
+		workerPool:            workerPool,
+		logger:                logger,
+		stopCh:                make(chan struct{}),
+		pollInterval:          pollInterval,
+		batchSize:             100, // Release at most 100 notifications per tenant each poll
+	}
+}
+
+// Start begins polling for due notifications in the background
+func (w *ScheduledNotificationWorker) Start(ctx context.Context) error {
+	w.logger.Info("Starting scheduled notification worker",
+		logger.Duration("poll_interval", w.pollInterval))
+
+	go w.poll(ctx)
+
+	return nil
+}
+
+// Stop stops the worker
+func (w *ScheduledNotificationWorker) Stop() {
+	w.logger.Info("Stopping scheduled notification worker")
+	close(w.stopCh)
+}
+
+func (w *ScheduledNotificationWorker) poll(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.processDueNotifications(ctx); err != nil {
+				w.logger.Error("Error processing scheduled notifications", logger.Err(err))
+			}
+		case <-w.stopCh:
+			w.logger.Info("Stopped polling for scheduled notifications")
+			return
+		case <-ctx.Done():
+			w.logger.Info("Context done, stopped polling for scheduled notifications")
+			return
+		}
+	}
+}
+
+// processDueNotifications submits every tenant's due notifications to the worker pool
+func (w *ScheduledNotificationWorker) processDueNotifications(ctx context.Context) error {
+	tenants, err := w.tenants.GetAllTenants(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenants: %w", err)
+	}
+
+	now := time.Now()
+	for _, tenant := range tenants {
+		if err := w.processTenant(ctx, tenant.ID, now); err != nil {
+			w.logger.Error("Failed to process scheduled notifications for tenant",
+				logger.String("tenant_id", tenant.ID),
+				logger.Err(err))
+		}
+	}
+	return nil
+}
+
+func (w *ScheduledNotificationWorker) processTenant(ctx context.Context, tenantID string, now time.Time) error {
+	notificationRepo, err := w.repositoryFactory.GetNotificationRepositoryForTenant(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification repository: %w", err)
+	}
+
+	notifications, err := notificationRepo.GetDueScheduledNotifications(ctx, now, w.batchSize)
+	if err != nil {
+		return err
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	providerRepo, err := w.repositoryFactory.GetProviderRepositoryForTenant(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get provider repository: %w", err)
+	}
+	userRepo, err := w.repositoryFactory.GetUserRepositoryForTenant(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get user repository: %w", err)
+	}
+
+	// Scheduled notifications were created past the digest check, so no digest service is needed
+	useCase := NewSendNotificationUseCase(w.providerService, w.templateService, providerRepo, notificationRepo, userRepo, w.preferencesCache, w.userPreferenceService, nil, w.frequencyCapService)
+	for _, notification := range notifications {
+		job := &scheduledNotificationJob{useCase: useCase, notification: notification, logger: w.logger}
+		if err := w.workerPool.Submit(job); err != nil {
+			// The notification stays scheduled and is picked up again on the next poll
+			return fmt.Errorf("failed to submit scheduled notification %s: %w", notification.ID, err)
+		}
+	}
+
+	w.logger.Debug("Submitted scheduled notifications",
+		logger.String("tenant_id", tenantID),
+		logger.Int("count", len(notifications)))
+	return nil
+}
+
+// scheduledNotificationJob sends one due notification on the worker pool
+type scheduledNotificationJob struct {
+	useCase      *SendNotificationUseCase
+	notification *domain.Notification
+	logger       logger.Logger
+}
+
+func (j *scheduledNotificationJob) Process(ctx context.Context) error {
+	_, err := j.useCase.Release(ctx, j.notification)
+	if errors.Is(err, repos.ErrStatusConflict) {
+		// Cancelled, or already released by an earlier poll
+		return nil
+	}
+	if err != nil {
+		j.logger.Error("Failed to send scheduled notification",
+			logger.String("tenant_id", j.notification.TenantID),
+			logger.String("notification_id", j.notification.ID),
+			logger.Err(err))
+	}
+	return err
+}
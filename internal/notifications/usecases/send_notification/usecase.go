@@ -2,119 +2,585 @@ package sendnotification
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"getnoti.com/internal/notifications/domain"
 	notificationRepos "getnoti.com/internal/notifications/repos"
+	notificationServices "getnoti.com/internal/notifications/services"
 	providerDomain "getnoti.com/internal/providers/domain"
 	"getnoti.com/internal/providers/dtos"
 	providerRepos "getnoti.com/internal/providers/repos"
 	providerServices "getnoti.com/internal/providers/services"
 	"getnoti.com/internal/shared/utils"
 	templateServices "getnoti.com/internal/templates/services"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	tenantRepos "getnoti.com/internal/tenants/repos"
+	tenantServices "getnoti.com/internal/tenants/services"
 
 	"getnoti.com/pkg/cache"
 )
 
+// quietHoursReason is recorded when a notification is deferred until the user's quiet hours end
+const quietHoursReason = "deferred until the end of the user's quiet hours"
+
+// releaseClaimTimeout is how long a released notification stays claimed; one
+// still pending after that was interrupted and the scheduler releases it again
+const releaseClaimTimeout = 15 * time.Minute
+
 type SendNotificationUseCase struct {
 	providerService        *providerServices.ProviderService
 	templateService        *templateServices.TemplateService
 	providerRepo           providerRepos.ProviderRepository
 	notificationRepository notificationRepos.NotificationRepository
+	userRepository         tenantRepos.UserRepository
 	preferencesCache       *cache.GenericCache
+	userPreferenceService  *tenantServices.UserPreferenceService
+	digestService          *notificationServices.DigestService
+	frequencyCapService    *notificationServices.FrequencyCapService
 }
 
 func NewSendNotificationUseCase(
-	providerService *providerServices.ProviderService, 
-	templateService *templateServices.TemplateService, 
-	providerRepo providerRepos.ProviderRepository, 
-	notificationRepository notificationRepos.NotificationRepository, 
+	providerService *providerServices.ProviderService,
+	templateService *templateServices.TemplateService,
+	providerRepo providerRepos.ProviderRepository,
+	notificationRepository notificationRepos.NotificationRepository,
+	userRepository tenantRepos.UserRepository,
 	preferencesCache *cache.GenericCache,
+	userPreferenceService *tenantServices.UserPreferenceService,
+	digestService *notificationServices.DigestService,
+	frequencyCapService *notificationServices.FrequencyCapService,
 ) *SendNotificationUseCase {
 	return &SendNotificationUseCase{
 		providerService:        providerService,
 		templateService:        templateService,
 		providerRepo:           providerRepo,
 		notificationRepository: notificationRepository,
+		userRepository:         userRepository,
 		preferencesCache:       preferencesCache,
+		userPreferenceService:  userPreferenceService,
+		digestService:          digestService,
+		frequencyCapService:    frequencyCapService,
 	}
 }
 
 func (u *SendNotificationUseCase) Execute(ctx context.Context, req SendNotificationRequest) (SendNotificationResponse, error) {
-	providerID, err := u.getProviderID(ctx, req, u.preferencesCache)
+	// Channels are stored and matched lowercase; providers map them to their own names
+	req.Channel = string(tenantDomain.NormalizeChannel(req.Channel))
+
+	// Users who digest the category receive the notification in their next digest
+	if digest, err := u.collectIntoDigest(ctx, req); err != nil {
+		return SendNotificationResponse{
+			Status: string(domain.StatusFailed),
+			Error:  "failed to add notification to digest: " + err.Error(),
+		}, err
+	} else if digest != nil {
+		return SendNotificationResponse{
+			Status:       StatusDigested,
+			ScheduledFor: &digest.SendAt,
+			DigestID:     digest.ID,
+		}, nil
+	}
+
+	providerIDs, err := u.getProviderChain(ctx, req, u.preferencesCache)
 	if err != nil {
 		return SendNotificationResponse{
-			Status: "failed",
+			Status: string(domain.StatusFailed),
 			Error:  "failed to get provider ID: " + err.Error(),
 		}, err
 	}
 
-	notification, err := u.createNotification(ctx, req, providerID)
+	notification, err := u.createNotification(ctx, req, providerIDs[0])
 	if err != nil {
 		return SendNotificationResponse{
-			Status: "failed",
+			Status: string(domain.StatusFailed),
 			Error:  "notification creation failed: " + err.Error(),
 		}, err
 	}
-	content, err := u.templateService.GetContent(ctx, req.TenantID, notification.TemplateID, notification.Variables)
+
+	// Future sends wait in the database until the scheduler releases them
+	if notification.Status == domain.StatusScheduled {
+		return SendNotificationResponse{
+			ID:           notification.ID,
+			Status:       string(notification.Status),
+			ScheduledFor: notification.ScheduledFor,
+		}, nil
+	}
+
+	return u.dispatch(ctx, req, notification, providerIDs)
+}
+
+// Release sends a scheduled notification once its send time has arrived. The
+// notification is claimed by moving it from scheduled to pending until the claim
+// expires, so a notification that was cancelled or released elsewhere returns a
+// status conflict without being sent. A pending notification whose claim expired
+// was interrupted mid-release and is claimed again.
+func (u *SendNotificationUseCase) Release(ctx context.Context, notification *domain.Notification) (SendNotificationResponse, error) {
+	if err := u.claim(ctx, notification); err != nil {
+		return SendNotificationResponse{
+			ID:     notification.ID,
+			Status: string(notification.Status),
+			Error:  "failed to release scheduled notification: " + err.Error(),
+		}, err
+	}
+
+	req := scheduledRequest(notification)
+	providerIDs, err := u.getProviderChain(ctx, req, u.preferencesCache)
 	if err != nil {
+		u.markFailed(ctx, notification, "", "failed to get provider ID: "+err.Error())
 		return SendNotificationResponse{
 			ID:     notification.ID,
-			Status: "failed",
-			Error:  "failed to get template content: " + err.Error(),
+			Status: string(notification.Status),
+			Error:  "failed to get provider ID: " + err.Error(),
 		}, err
 	}
 
-	sendReq := dtos.SendNotificationRequest{
-		Sender:     req.TenantID,
-		Receiver:   req.UserID,
-		TenantID:   req.TenantID,
+	return u.dispatch(ctx, req, notification, providerIDs)
+}
+
+// claim moves the notification to pending for releaseClaimTimeout and records the release
+func (u *SendNotificationUseCase) claim(ctx context.Context, notification *domain.Notification) error {
+	reason := ""
+	if notification.Status == domain.StatusPending {
+		reason = "released again after an interrupted release"
+	} else if err := notification.Transition(domain.StatusPending); err != nil {
+		return err
+	}
+	now := time.Now()
+	return u.notificationRepository.ClaimNotification(ctx, notification.NewAttempt(utils.GenerateUUID(), "", reason), now, now.Add(releaseClaimTimeout))
+}
+
+// scheduledRequest rebuilds the send request a scheduled notification was created from.
+// The stored provider is the head of the original chain, so it is tried first again.
+func scheduledRequest(notification *domain.Notification) SendNotificationRequest {
+	variables := make([]TemplateVariable, len(notification.Variables))
+	for i, v := range notification.Variables {
+		variables[i] = TemplateVariable{Key: v.Key, Value: v.Value}
+	}
+
+	return SendNotificationRequest{
+		TenantID:            notification.TenantID,
+		UserID:              notification.UserID,
+		Type:                notification.Type,
+		Channel:             string(tenantDomain.NormalizeChannel(notification.Channel)),
+		TemplateID:          notification.TemplateID,
+		TemplateVersion:     notification.TemplateVersion,
+		Content:             notification.Content,
+		ProviderID:          notification.ProviderID,
+		Variables:           variables,
+		Category:            notification.Category,
+		OverridePreferences: notification.OverridePreferences,
+		Urgent:              notification.Urgent,
+	}
+}
+
+// dispatch renders a pending notification and walks the provider chain until one accepts it
+func (u *SendNotificationUseCase) dispatch(ctx context.Context, req SendNotificationRequest, notification *domain.Notification, providerIDs []string) (SendNotificationResponse, error) {
+	// Preferences are checked at dispatch so scheduled sends see the user's current choices
+	if reason, blocked := u.blockedByPreferences(ctx, notification); blocked {
+		if err := u.transition(ctx, notification, domain.StatusSuppressed, "", reason); err != nil {
+			return SendNotificationResponse{
+				ID:     notification.ID,
+				Status: string(notification.Status),
+				Error:  "failed to suppress notification: " + err.Error(),
+			}, err
+		}
+		return SendNotificationResponse{
+			ID:     notification.ID,
+			Status: string(notification.Status),
+			Reason: reason,
+		}, nil
+	}
+
+	// Non-urgent notifications wait out the user's quiet hours as scheduled
+	if until, deferred := u.quietHoursEnd(ctx, notification); deferred {
+		if err := u.deferUntil(ctx, notification, until, quietHoursReason); err != nil {
+			return SendNotificationResponse{
+				ID:     notification.ID,
+				Status: string(notification.Status),
+				Error:  "failed to defer notification: " + err.Error(),
+			}, err
+		}
+		return SendNotificationResponse{
+			ID:           notification.ID,
+			Status:       string(notification.Status),
+			ScheduledFor: notification.ScheduledFor,
+			Reason:       quietHoursReason,
+		}, nil
+	}
+
+	// A user the channel cannot reach fails before the send is counted against the caps
+	receiver, deviceTokens, err := u.resolveReceiver(ctx, notification)
+	if err != nil {
+		u.markFailed(ctx, notification, "", err.Error())
+		return SendNotificationResponse{
+			ID:     notification.ID,
+			Status: string(notification.Status),
+			Error:  "failed to resolve receiver: " + err.Error(),
+		}, err
+	}
+
+	// Frequency caps are checked last so blocked and deferred notifications are not counted
+	cappedAt := time.Now()
+	if decision, capped := u.frequencyCapped(ctx, notification, cappedAt); capped {
+		if decision.Policy == tenantDomain.CapPolicyDefer {
+			if err := u.deferUntil(ctx, notification, decision.Until, decision.Reason); err != nil {
+				return SendNotificationResponse{
+					ID:     notification.ID,
+					Status: string(notification.Status),
+					Error:  "failed to defer notification: " + err.Error(),
+				}, err
+			}
+			return SendNotificationResponse{
+				ID:           notification.ID,
+				Status:       string(notification.Status),
+				ScheduledFor: notification.ScheduledFor,
+				Reason:       decision.Reason,
+			}, nil
+		}
+		if err := u.transition(ctx, notification, domain.StatusSuppressed, "", decision.Reason); err != nil {
+			return SendNotificationResponse{
+				ID:     notification.ID,
+				Status: string(notification.Status),
+				Error:  "failed to suppress notification: " + err.Error(),
+			}, err
+		}
+		return SendNotificationResponse{
+			ID:     notification.ID,
+			Status: string(notification.Status),
+			Reason: decision.Reason,
+		}, nil
+	}
+
+	rendered, err := u.templateService.GetContent(ctx, req.TenantID, templateServices.ContentRequest{
+		TemplateID: notification.TemplateID,
+		UserID:     req.UserID,
 		Channel:    req.Channel,
-		Content:    content,
-		ProviderID: providerID,
+		Version:    req.TemplateVersion,
+		Variables:  notification.Variables,
+	})
+	if err != nil {
+		u.releaseFrequencyCaps(ctx, notification, cappedAt)
+		u.markFailed(ctx, notification, "", "failed to get template content: "+err.Error())
+		return SendNotificationResponse{
+			ID:     notification.ID,
+			Status: string(notification.Status),
+			Error:  "failed to get template content: " + err.Error(),
+		}, err
 	}
-	sendResp := u.providerService.DispatchNotification(ctx, req.TenantID, providerID, sendReq)
-	if !sendResp.Success {
+	content := rendered.Content
+	notification.Content = content
+	notification.TemplateVersion = rendered.Version
+	notification.Locale = rendered.Locale
+
+	// Save the rendered content, then queue; the provider worker reports the send from there
+	if err := u.notificationRepository.UpdateNotification(ctx, notification); err != nil {
+		u.releaseFrequencyCaps(ctx, notification, cappedAt)
+		return SendNotificationResponse{
+			ID:     notification.ID,
+			Status: string(notification.Status),
+			Error:  "failed to save notification: " + err.Error(),
+		}, err
+	}
+	if err := u.transition(ctx, notification, domain.StatusQueued, "", ""); err != nil {
+		u.releaseFrequencyCaps(ctx, notification, cappedAt)
 		return SendNotificationResponse{
 			ID:     notification.ID,
-			Status: "failed",
-			Error:  "notification sending failed: " + sendResp.Message,
-		}, fmt.Errorf("notification sending failed: %s", sendResp.Message)
+			Status: string(notification.Status),
+			Error:  "failed to queue notification: " + err.Error(),
+		}, err
 	}
-	
+
+	sendReq := dtos.SendNotificationRequest{
+		Sender:         req.TenantID,
+		Receiver:       receiver,
+		DeviceTokens:   deviceTokens,
+		TenantID:       req.TenantID,
+		Channel:        req.Channel,
+		Content:        content,
+		Subject:        rendered.Subject,
+		HTMLContent:    rendered.HTML,
+		Title:          rendered.Title,
+		NotificationID: notification.ID,
+	}
+
+	// Walk the provider chain in priority order until one accepts the notification.
+	// A queued send is accepted once published, so it carries the rest of the
+	// chain for its worker to fail over to.
+	attempts := make([]ProviderAttempt, 0, len(providerIDs))
+	for i, providerID := range providerIDs {
+		sendReq.ProviderID = providerID
+		sendReq.FallbackProviderIDs = providerIDs[i+1:]
+		sendResp := u.providerService.DispatchNotification(ctx, req.TenantID, providerID, sendReq)
+
+		attempts = append(attempts, ProviderAttempt{
+			ProviderID:    providerID,
+			Success:       sendResp.Success,
+			Message:       sendResp.Message,
+			AttemptedAt:   time.Now(),
+			InvalidTokens: sendResp.InvalidTokens,
+		})
+
+		if sendResp.Success {
+			return SendNotificationResponse{
+				ID:              notification.ID,
+				Status:          string(domain.StatusQueued),
+				TemplateVersion: notification.TemplateVersion,
+				Locale:          notification.Locale,
+				Attempts:        attempts,
+			}, nil
+		}
+
+		// Keep the failover in the delivery history; the last failure is recorded as the final status
+		if i < len(providerIDs)-1 {
+			u.recordFailover(ctx, notification, providerID, sendResp.Message)
+		}
+	}
+
+	lastAttempt := attempts[len(attempts)-1]
+	u.releaseFrequencyCaps(ctx, notification, cappedAt)
+	u.markFailed(ctx, notification, lastAttempt.ProviderID, lastAttempt.Message)
+
 	return SendNotificationResponse{
-		ID:     notification.ID,
-		Status: "queued",
-	}, nil
+		ID:              notification.ID,
+		Status:          string(notification.Status),
+		TemplateVersion: notification.TemplateVersion,
+		Locale:          notification.Locale,
+		Error:           fmt.Sprintf("notification sending failed after %d provider attempt(s): %s", len(attempts), lastAttempt.Message),
+		Attempts:        attempts,
+	}, fmt.Errorf("%w: %d provider attempt(s), last error: %s", ErrAllProvidersFailed, len(attempts), lastAttempt.Message)
 }
 
-func (u *SendNotificationUseCase) getProviderID(ctx context.Context, req SendNotificationRequest, preferencesCache *cache.GenericCache) (string, error) {
+// getProviderChain returns the provider IDs to try for the request, in priority order.
+// An explicitly requested provider is tried first, followed by the remaining enabled
+// providers for the channel.
+func (u *SendNotificationUseCase) getProviderChain(ctx context.Context, req SendNotificationRequest, preferencesCache *cache.GenericCache) ([]string, error) {
+	providers, err := u.getChannelProviders(ctx, req, preferencesCache)
+	if err != nil && req.ProviderID == "" {
+		return nil, err
+	}
+
+	chain := make([]string, 0, len(providers)+1)
 	if req.ProviderID != "" {
-		return req.ProviderID, nil
+		chain = append(chain, req.ProviderID)
+	}
+	for _, provider := range providers {
+		if provider.ID != req.ProviderID {
+			chain = append(chain, provider.ID)
+		}
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no provider found for channel %s", req.Channel)
 	}
 
+	return chain, nil
+}
+
+// getChannelProviders returns the enabled providers for the channel ordered by priority
+func (u *SendNotificationUseCase) getChannelProviders(ctx context.Context, req SendNotificationRequest, preferencesCache *cache.GenericCache) ([]*providerDomain.Provider, error) {
 	cacheKey := fmt.Sprintf("preferences:%s:%s", req.TenantID, req.Channel)
 
 	// Try to get from cache first
-	if cachedProvider, found := preferencesCache.Get(cacheKey); found {
-		provider := cachedProvider.(*providerDomain.Provider)
-		return provider.ID, nil
+	if cachedProviders, found := preferencesCache.Get(cacheKey); found {
+		if providers, ok := cachedProviders.([]*providerDomain.Provider); ok {
+			return providers, nil
+		}
 	}
 
 	// If not in cache, fetch from provider Repo
-	provider, err := u.providerRepo.GetProviderByChannel(ctx, req.Channel)
+	providers, err := u.providerRepo.GetProvidersByChannel(ctx, req.Channel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch providers: %w", err)
+	}
+
+	enabled := make([]*providerDomain.Provider, 0, len(providers))
+	for _, provider := range providers {
+		if channel, ok := channelPriority(provider); ok && channel.Enabled {
+			enabled = append(enabled, provider)
+		}
+	}
+
+	if len(enabled) == 0 {
+		return nil, fmt.Errorf("no provider found for channel %s", req.Channel)
+	}
+
+	sort.SliceStable(enabled, func(i, j int) bool {
+		a, _ := channelPriority(enabled[i])
+		b, _ := channelPriority(enabled[j])
+		return a.Priority < b.Priority
+	})
+
+	// Cache the provider chain
+	preferencesCache.Set(cacheKey, enabled, 1)
+
+	return enabled, nil
+}
+
+// channelPriority returns the prioritized channel a provider was loaded with
+func channelPriority(provider *providerDomain.Provider) (providerDomain.PrioritizedChannel, bool) {
+	if provider == nil || len(provider.Channels) == 0 {
+		return providerDomain.PrioritizedChannel{}, false
+	}
+	return provider.Channels[0], true
+}
+
+// resolveReceiver returns the address the notification's channel delivers to:
+// the user's email address for email and phone number for SMS. Push is sent to
+// the user's registered device tokens under the user ID. Other channels find the
+// user's subscriptions themselves and receive the user ID.
+func (u *SendNotificationUseCase) resolveReceiver(ctx context.Context, notification *domain.Notification) (string, []string, error) {
+	channel := tenantDomain.NormalizeChannel(notification.Channel)
+	switch channel {
+	case tenantDomain.ChannelTypeEmail, tenantDomain.ChannelTypeSMS, tenantDomain.ChannelTypePush:
+	default:
+		return notification.UserID, nil, nil
+	}
+
+	user, err := u.userRepository.GetUserByID(ctx, notification.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil, fmt.Errorf("%w: user %s does not exist", ErrNoReceiverAddress, notification.UserID)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch channel {
+	case tenantDomain.ChannelTypeEmail:
+		if user.Email != "" {
+			return user.Email, nil, nil
+		}
+	case tenantDomain.ChannelTypeSMS:
+		if user.PhoneNumber != "" {
+			return user.PhoneNumber, nil, nil
+		}
+	case tenantDomain.ChannelTypePush:
+		if user.DeviceID != "" {
+			return notification.UserID, []string{user.DeviceID}, nil
+		}
+	}
+	return "", nil, fmt.Errorf("%w: user %s has no %s address", ErrNoReceiverAddress, notification.UserID, channel)
+}
+
+// blockedByPreferences reports whether the user's master, channel or category
+// preferences exclude the notification, and why. Notifications that override
+// preferences are never blocked, and a failed preference lookup sends as usual.
+func (u *SendNotificationUseCase) blockedByPreferences(ctx context.Context, notification *domain.Notification) (string, bool) {
+	if notification.OverridePreferences || u.userPreferenceService == nil {
+		return "", false
+	}
+	decision, err := u.userPreferenceService.EvaluateNotification(ctx, notification.UserID, notification.TenantID, notification.Channel, notification.Category)
+	if err != nil || decision.Allowed {
+		return "", false
+	}
+	return decision.Reason, true
+}
+
+// collectIntoDigest adds the notification to the user's digest for its digest key
+// and returns the digest, or nil when the notification should be sent now.
+// Urgent and overriding notifications are never digested, and a failed
+// preference lookup sends as usual.
+func (u *SendNotificationUseCase) collectIntoDigest(ctx context.Context, req SendNotificationRequest) (*domain.Digest, error) {
+	if req.DigestKey == "" || req.TemplateID == "" || req.Urgent || req.OverridePreferences || u.digestService == nil {
+		return nil, nil
+	}
+
+	category := req.Category
+	if category == "" {
+		category = req.Type
+	}
+	settings, enabled, err := u.digestService.UserSettings(ctx, req.TenantID, req.UserID, category)
+	if err != nil || !enabled {
+		return nil, nil
+	}
+
+	variables := make([]domain.TemplateVariable, len(req.Variables))
+	for i, v := range req.Variables {
+		variables[i] = domain.TemplateVariable{Key: v.Key, Value: v.Value}
+	}
+
+	return u.digestService.Add(ctx, notificationServices.DigestItem{
+		TenantID:   req.TenantID,
+		UserID:     req.UserID,
+		Key:        req.DigestKey,
+		Channel:    req.Channel,
+		TemplateID: req.TemplateID,
+		Category:   category,
+		Data:       templateServices.BuildTemplateData(variables),
+	}, settings)
+}
+
+// quietHoursEnd reports whether the notification falls inside the user's quiet
+// hours and when they end. Urgent notifications are never deferred, and a
+// failed lookup sends as usual.
+func (u *SendNotificationUseCase) quietHoursEnd(ctx context.Context, notification *domain.Notification) (time.Time, bool) {
+	if notification.Urgent || u.userPreferenceService == nil {
+		return time.Time{}, false
+	}
+	until, inside, err := u.userPreferenceService.QuietHoursEnd(ctx, notification.UserID, notification.TenantID, time.Now())
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch provider: %w", err)
+		return time.Time{}, false
 	}
+	return until, inside
+}
 
-	if provider == nil {
-		return "", fmt.Errorf("no provider found for channel %s", req.Channel)
+// frequencyCapped counts the notification against the tenant's frequency caps
+// and reports whether a cap was already reached. Urgent notifications are
+// never capped, and a failed lookup sends as usual.
+func (u *SendNotificationUseCase) frequencyCapped(ctx context.Context, notification *domain.Notification, at time.Time) (notificationServices.CapDecision, bool) {
+	if notification.Urgent || u.frequencyCapService == nil {
+		return notificationServices.CapDecision{}, false
+	}
+	decision, err := u.frequencyCapService.Reserve(ctx, notification.TenantID, notification.UserID, notification.Channel, notification.Category, at)
+	if err != nil || decision.Allowed {
+		return notificationServices.CapDecision{}, false
 	}
+	return decision, true
+}
 
-	// Cache the provider
-	preferencesCache.Set(cacheKey, provider, 1)
+// releaseFrequencyCaps gives back the send frequencyCapped counted at the given
+// time, for a notification that will not be delivered
+func (u *SendNotificationUseCase) releaseFrequencyCaps(ctx context.Context, notification *domain.Notification, at time.Time) {
+	if notification.Urgent || u.frequencyCapService == nil {
+		return
+	}
+	_ = u.frequencyCapService.Release(ctx, notification.TenantID, notification.UserID, notification.Channel, notification.Category, at)
+}
+
+// deferUntil moves a pending notification back to scheduled so the scheduler releases it at until
+func (u *SendNotificationUseCase) deferUntil(ctx context.Context, notification *domain.Notification, until time.Time, reason string) error {
+	if err := notification.Transition(domain.StatusScheduled); err != nil {
+		return err
+	}
+	notification.ScheduledFor = &until
+	return u.notificationRepository.DeferNotification(ctx, notification.NewAttempt(utils.GenerateUUID(), "", reason), until)
+}
+
+// transition moves the notification to next and records the change in its delivery history
+func (u *SendNotificationUseCase) transition(ctx context.Context, notification *domain.Notification, next domain.NotificationStatus, providerID, reason string) error {
+	from := notification.Status
+	if err := notification.Transition(next); err != nil {
+		return err
+	}
+	if providerID != "" {
+		notification.ProviderID = providerID
+	}
+	return u.notificationRepository.UpdateNotificationStatus(ctx, from, notification.NewAttempt(utils.GenerateUUID(), "", reason))
+}
+
+// markFailed moves the notification to failed; a failed update does not change the send result
+func (u *SendNotificationUseCase) markFailed(ctx context.Context, notification *domain.Notification, providerID, reason string) {
+	_ = u.transition(ctx, notification, domain.StatusFailed, providerID, reason)
+}
 
-	return provider.ID, nil
+// recordFailover records a provider that rejected the notification before the next one is tried
+func (u *SendNotificationUseCase) recordFailover(ctx context.Context, notification *domain.Notification, providerID, reason string) {
+	attempt := notification.NewAttempt(utils.GenerateUUID(), "", reason)
+	attempt.ProviderID = providerID
+	_ = u.notificationRepository.CreateNotificationAttempt(ctx, attempt)
 }
 
 func (u *SendNotificationUseCase) createNotification(ctx context.Context, req SendNotificationRequest, providerID string) (*domain.Notification, error) {
@@ -128,16 +594,34 @@ func (u *SendNotificationUseCase) createNotification(ctx context.Context, req Se
 
 	ID := utils.GenerateUUID()
 
+	category := req.Category
+	if category == "" {
+		category = req.Type
+	}
+
+	status := domain.StatusPending
+	var scheduledFor *time.Time
+	if req.ScheduledFor != nil && req.ScheduledFor.After(time.Now()) {
+		status = domain.StatusScheduled
+		scheduledFor = req.ScheduledFor
+	}
+
 	notification := &domain.Notification{
-		ID:         ID,
-		TenantID:   req.TenantID,
-		UserID:     req.UserID,
-		Type:       req.Type,
-		Channel:    req.Channel,
-		TemplateID: req.TemplateID,
-		Content:    req.Content,
-		ProviderID: providerID,
-		Variables:  variables,
+		ID:                  ID,
+		TenantID:            req.TenantID,
+		UserID:              req.UserID,
+		Type:                req.Type,
+		Category:            category,
+		OverridePreferences: req.OverridePreferences,
+		Urgent:              req.Urgent,
+		Channel:             req.Channel,
+		TemplateID:          req.TemplateID,
+		TemplateVersion:     req.TemplateVersion,
+		Status:              status,
+		Content:             req.Content,
+		ProviderID:          providerID,
+		Variables:           variables,
+		ScheduledFor:        scheduledFor,
 	}
 
 	err := u.notificationRepository.CreateNotification(ctx, notification)
@@ -147,5 +631,3 @@ func (u *SendNotificationUseCase) createNotification(ctx context.Context, req Se
 
 	return notification, nil
 }
-
-// TODO: Implement fallback mechanism to try the next available provider if the current one fails to send the notification.
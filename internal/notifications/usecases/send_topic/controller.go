@@ -0,0 +1,17 @@
+package sendtopic
+
+import (
+	"context"
+)
+
+type SendTopicController struct {
+	useCase SendTopicUseCase
+}
+
+func NewSendTopicController(useCase SendTopicUseCase) *SendTopicController {
+	return &SendTopicController{useCase: useCase}
+}
+
+func (c *SendTopicController) SendTopic(ctx context.Context, req SendTopicRequest) (SendTopicResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
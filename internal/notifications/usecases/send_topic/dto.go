@@ -0,0 +1,47 @@
+package sendtopic
+
+import "time"
+
+// SendTopicRequest sends a notification to every subscriber of a topic
+type SendTopicRequest struct {
+	ID         string
+	TenantID   string
+	Type       string
+	Channel    string
+	TemplateID string
+	// TemplateVersion pins a template version; zero uses the published version
+	TemplateVersion int
+	Content         string
+	ProviderID      string
+	Variables       []TemplateVariable
+	ScheduledFor    *time.Time `json:",omitempty"`
+	// Category selects subscribers' category preferences; it defaults to Type
+	Category string
+	// OverridePreferences sends even to subscribers who opted out
+	OverridePreferences bool
+	// Urgent delivers during subscribers' quiet hours instead of deferring
+	Urgent bool
+}
+
+func (r *SendTopicRequest) SetID(id string) {
+	r.ID = id
+}
+
+func (r *SendTopicRequest) SetTenantID(tenantID string) {
+	r.TenantID = tenantID
+}
+
+type TemplateVariable struct {
+	Key   string
+	Value string
+}
+
+type SendTopicResponse struct {
+	TopicID string
+	// BatchID reports per-subscriber outcomes through the batch status endpoint
+	BatchID         string
+	Status          string
+	TotalRecipients int
+	Success         bool
+	Message         string
+}
@@ -0,0 +1,9 @@
+package sendtopic
+
+import "errors"
+
+var (
+	ErrChannelRequired = errors.New("channel is required")
+	ErrNoSubscribers   = errors.New("topic has no subscribers")
+	ErrUnexpected      = errors.New("unexpected error occurred")
+)
@@ -0,0 +1,123 @@
+package sendtopic
+
+import (
+	"context"
+
+	"getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/notifications/repos"
+	sendbatch "getnoti.com/internal/notifications/usecases/send_batch"
+)
+
+// subscriberPageSize is the number of subscribers read per query while expanding a topic
+const subscriberPageSize = 1000
+
+type SendTopicUseCase interface {
+	Execute(ctx context.Context, req SendTopicRequest) (SendTopicResponse, error)
+}
+
+type sendTopicUseCase struct {
+	repository       repository.TopicRepository
+	sendBatchUseCase sendbatch.SendBatchUseCase
+}
+
+func NewSendTopicUseCase(
+	repository repository.TopicRepository,
+	sendBatchUseCase sendbatch.SendBatchUseCase,
+) SendTopicUseCase {
+	return &sendTopicUseCase{
+		repository:       repository,
+		sendBatchUseCase: sendBatchUseCase,
+	}
+}
+
+// Execute sends to the topic's subscribers as a batch, reading them a page at a
+// time so large topics are never held in memory. Each subscriber's preferences
+// are applied when their notification is sent, so opted-out members show up in
+// the batch as suppressed.
+func (uc *sendTopicUseCase) Execute(ctx context.Context, req SendTopicRequest) (SendTopicResponse, error) {
+	if req.Channel == "" {
+		return SendTopicResponse{Success: false, Message: ErrChannelRequired.Error()}, ErrChannelRequired
+	}
+
+	topic, err := uc.repository.GetTopicByID(ctx, req.ID)
+	if err != nil {
+		return SendTopicResponse{Success: false, Message: err.Error()}, err
+	}
+
+	total, err := uc.repository.CountSubscribers(ctx, topic.ID)
+	if err != nil {
+		return SendTopicResponse{TopicID: topic.ID, Success: false, Message: ErrUnexpected.Error()}, err
+	}
+	if total == 0 {
+		return SendTopicResponse{TopicID: topic.ID, Success: false, Message: ErrNoSubscribers.Error()}, ErrNoSubscribers
+	}
+
+	batchReq := sendbatch.SendBatchRequest{
+		TenantID:            req.TenantID,
+		Type:                req.Type,
+		Channel:             req.Channel,
+		TemplateID:          req.TemplateID,
+		TemplateVersion:     req.TemplateVersion,
+		Content:             req.Content,
+		ProviderID:          req.ProviderID,
+		Variables:           make([]sendbatch.TemplateVariable, len(req.Variables)),
+		ScheduledFor:        req.ScheduledFor,
+		Category:            req.Category,
+		OverridePreferences: req.OverridePreferences,
+		Urgent:              req.Urgent,
+	}
+	for i, v := range req.Variables {
+		batchReq.Variables[i] = sendbatch.TemplateVariable{Key: v.Key, Value: v.Value}
+	}
+
+	batch := sendbatch.NewBatch(batchReq)
+	batch.TopicID = topic.ID
+
+	res, err := uc.sendBatchUseCase.DispatchPages(ctx, batch, batchReq, total, uc.subscriberPages(topic.ID))
+	if err != nil {
+		return SendTopicResponse{TopicID: topic.ID, BatchID: res.BatchID, Success: false, Message: res.Message}, err
+	}
+
+	return SendTopicResponse{
+		TopicID:         topic.ID,
+		BatchID:         res.BatchID,
+		Status:          res.Status,
+		TotalRecipients: res.TotalRecipients,
+		Success:         true,
+		Message:         "Topic send accepted for processing",
+	}, nil
+}
+
+// subscriberPages reads the topic's subscribers one page per call as batch recipients
+func (uc *sendTopicUseCase) subscriberPages(topicID string) sendbatch.RecipientPager {
+	after := ""
+	position := 0
+	done := false
+	return func(ctx context.Context) ([]*domain.BatchRecipient, error) {
+		if done {
+			return nil, nil
+		}
+
+		subscribers, err := uc.repository.GetSubscribers(ctx, topicID, after, subscriberPageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(subscribers) < subscriberPageSize {
+			done = true
+		}
+		if len(subscribers) > 0 {
+			after = subscribers[len(subscribers)-1].UserID
+		}
+
+		recipients := make([]*domain.BatchRecipient, len(subscribers))
+		for i, subscriber := range subscribers {
+			recipients[i] = &domain.BatchRecipient{
+				Position: position,
+				UserID:   subscriber.UserID,
+				Status:   domain.RecipientStatusPending,
+			}
+			position++
+		}
+		return recipients, nil
+	}
+}
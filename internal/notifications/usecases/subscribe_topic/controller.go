@@ -0,0 +1,17 @@
+package subscribetopic
+
+import (
+	"context"
+)
+
+type SubscribeTopicController struct {
+	useCase SubscribeTopicUseCase
+}
+
+func NewSubscribeTopicController(useCase SubscribeTopicUseCase) *SubscribeTopicController {
+	return &SubscribeTopicController{useCase: useCase}
+}
+
+func (c *SubscribeTopicController) SubscribeTopic(ctx context.Context, req SubscribeTopicRequest) (SubscribeTopicResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,18 @@
+package subscribetopic
+
+type SubscribeTopicRequest struct {
+	ID      string
+	UserIDs []string
+}
+
+func (r *SubscribeTopicRequest) SetID(id string) {
+	r.ID = id
+}
+
+type SubscribeTopicResponse struct {
+	TopicID string
+	// Added is the number of users subscribed; users that already were are not counted
+	Added   int
+	Success bool
+	Message string
+}
@@ -0,0 +1,12 @@
+package subscribetopic
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoUsers       = errors.New("at least one user ID is required")
+	ErrTooManyUsers  = fmt.Errorf("at most %d users can be subscribed per request", MaxUsersPerRequest)
+	ErrInvalidUserID = errors.New("user IDs cannot be empty")
+)
@@ -0,0 +1,73 @@
+package subscribetopic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"getnoti.com/internal/notifications/repos"
+)
+
+// MaxUsersPerRequest is the largest number of users subscribed in one request
+const MaxUsersPerRequest = 1000
+
+type SubscribeTopicUseCase interface {
+	Execute(ctx context.Context, req SubscribeTopicRequest) (SubscribeTopicResponse, error)
+}
+
+type subscribeTopicUseCase struct {
+	repository repository.TopicRepository
+}
+
+func NewSubscribeTopicUseCase(repository repository.TopicRepository) SubscribeTopicUseCase {
+	return &subscribeTopicUseCase{repository: repository}
+}
+
+func (uc *subscribeTopicUseCase) Execute(ctx context.Context, req SubscribeTopicRequest) (SubscribeTopicResponse, error) {
+	userIDs, err := normalizeUserIDs(req.UserIDs)
+	if err != nil {
+		return SubscribeTopicResponse{Success: false, Message: err.Error()}, err
+	}
+
+	// Check the topic exists so an unknown ID is reported rather than ignored
+	topic, err := uc.repository.GetTopicByID(ctx, req.ID)
+	if err != nil {
+		return SubscribeTopicResponse{Success: false, Message: err.Error()}, err
+	}
+
+	added, err := uc.repository.AddSubscribers(ctx, topic.ID, userIDs)
+	if err != nil {
+		return SubscribeTopicResponse{TopicID: topic.ID, Success: false, Message: err.Error()}, err
+	}
+
+	return SubscribeTopicResponse{
+		TopicID: topic.ID,
+		Added:   added,
+		Success: true,
+		Message: fmt.Sprintf("%d user(s) subscribed", added),
+	}, nil
+}
+
+// normalizeUserIDs trims and deduplicates the user IDs, keeping their order
+func normalizeUserIDs(userIDs []string) ([]string, error) {
+	if len(userIDs) == 0 {
+		return nil, ErrNoUsers
+	}
+	if len(userIDs) > MaxUsersPerRequest {
+		return nil, ErrTooManyUsers
+	}
+
+	seen := make(map[string]bool, len(userIDs))
+	result := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		userID = strings.TrimSpace(userID)
+		if userID == "" {
+			return nil, ErrInvalidUserID
+		}
+		if !seen[userID] {
+			seen[userID] = true
+			result = append(result, userID)
+		}
+	}
+	return result, nil
+}
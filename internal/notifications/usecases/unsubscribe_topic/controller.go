@@ -0,0 +1,17 @@
+package unsubscribetopic
+
+import (
+	"context"
+)
+
+type UnsubscribeTopicController struct {
+	useCase UnsubscribeTopicUseCase
+}
+
+func NewUnsubscribeTopicController(useCase UnsubscribeTopicUseCase) *UnsubscribeTopicController {
+	return &UnsubscribeTopicController{useCase: useCase}
+}
+
+func (c *UnsubscribeTopicController) UnsubscribeTopic(ctx context.Context, req UnsubscribeTopicRequest) (UnsubscribeTopicResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,18 @@
+package unsubscribetopic
+
+type UnsubscribeTopicRequest struct {
+	ID      string
+	UserIDs []string
+}
+
+func (r *UnsubscribeTopicRequest) SetID(id string) {
+	r.ID = id
+}
+
+type UnsubscribeTopicResponse struct {
+	TopicID string
+	// Removed is the number of users unsubscribed; users that already were are not counted
+	Removed int
+	Success bool
+	Message string
+}
@@ -0,0 +1,12 @@
+package unsubscribetopic
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNoUsers       = errors.New("at least one user ID is required")
+	ErrTooManyUsers  = fmt.Errorf("at most %d users can be unsubscribed per request", MaxUsersPerRequest)
+	ErrInvalidUserID = errors.New("user IDs cannot be empty")
+)
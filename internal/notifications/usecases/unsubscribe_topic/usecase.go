@@ -0,0 +1,73 @@
+package unsubscribetopic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"getnoti.com/internal/notifications/repos"
+)
+
+// MaxUsersPerRequest is the largest number of users unsubscribed in one request
+const MaxUsersPerRequest = 1000
+
+type UnsubscribeTopicUseCase interface {
+	Execute(ctx context.Context, req UnsubscribeTopicRequest) (UnsubscribeTopicResponse, error)
+}
+
+type unsubscribeTopicUseCase struct {
+	repository repository.TopicRepository
+}
+
+func NewUnsubscribeTopicUseCase(repository repository.TopicRepository) UnsubscribeTopicUseCase {
+	return &unsubscribeTopicUseCase{repository: repository}
+}
+
+func (uc *unsubscribeTopicUseCase) Execute(ctx context.Context, req UnsubscribeTopicRequest) (UnsubscribeTopicResponse, error) {
+	userIDs, err := normalizeUserIDs(req.UserIDs)
+	if err != nil {
+		return UnsubscribeTopicResponse{Success: false, Message: err.Error()}, err
+	}
+
+	// Check the topic exists so an unknown ID is reported rather than ignored
+	topic, err := uc.repository.GetTopicByID(ctx, req.ID)
+	if err != nil {
+		return UnsubscribeTopicResponse{Success: false, Message: err.Error()}, err
+	}
+
+	removed, err := uc.repository.RemoveSubscribers(ctx, topic.ID, userIDs)
+	if err != nil {
+		return UnsubscribeTopicResponse{TopicID: topic.ID, Success: false, Message: err.Error()}, err
+	}
+
+	return UnsubscribeTopicResponse{
+		TopicID: topic.ID,
+		Removed: removed,
+		Success: true,
+		Message: fmt.Sprintf("%d user(s) unsubscribed", removed),
+	}, nil
+}
+
+// normalizeUserIDs trims and deduplicates the user IDs, keeping their order
+func normalizeUserIDs(userIDs []string) ([]string, error) {
+	if len(userIDs) == 0 {
+		return nil, ErrNoUsers
+	}
+	if len(userIDs) > MaxUsersPerRequest {
+		return nil, ErrTooManyUsers
+	}
+
+	seen := make(map[string]bool, len(userIDs))
+	result := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		userID = strings.TrimSpace(userID)
+		if userID == "" {
+			return nil, ErrInvalidUserID
+		}
+		if !seen[userID] {
+			seen[userID] = true
+			result = append(result, userID)
+		}
+	}
+	return result, nil
+}
@@ -1,17 +1,44 @@
 package dtos
 
 type SendNotificationRequest struct {
-	Sender     string
-	Receiver   string
-	Channel    string
-	Content    string
-	ProviderID string
-	TenantID   string
-	UserID     string 
-	Category   string 
+	Sender      string
+	Receiver    string
+	Channel     string
+	Content     string
+	Subject     string
+	HTMLContent string
+	Title       string
+	ProviderID  string
+	TenantID    string
+	UserID      string
+	Category    string
+	// DeviceTokens are the receiver's registered push tokens, which push
+	// providers send to; Receiver stays the user ID
+	DeviceTokens []string
+	// NotificationID links the send to its notification so the outcome can be recorded
+	NotificationID string
+	// FallbackProviderIDs are the providers to try next, in order, when a
+	// queued send is rejected; inline sends fail over in the caller
+	FallbackProviderIDs []string
 }
 
 type SendNotificationResponse struct {
 	Success bool
 	Message string
+	// MessageID is the provider's ID for the accepted message
+	MessageID string
+	// InvalidTokens are device tokens the provider reported as no longer
+	// registered, so they can be pruned
+	InvalidTokens []string
+}
+
+// DeliveryReport is the outcome of a send for a notification
+type DeliveryReport struct {
+	TenantID          string
+	NotificationID    string
+	ProviderID        string
+	Status            string
+	ProviderMessageID string
+	Reason            string
+	InvalidTokens     []string
 }
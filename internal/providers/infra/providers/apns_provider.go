@@ -0,0 +1,437 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"getnoti.com/internal/providers/dtos"
+)
+
+const (
+	apnsProductionEndpoint = "https://api.push.apple.com"
+	apnsSandboxEndpoint    = "https://api.sandbox.push.apple.com"
+	defaultAPNSTimeout     = 30 * time.Second
+	// apnsTokenLifetime renews the provider token well within Apple's one hour
+	// limit without refreshing more often than every 20 minutes
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNs priorities
+const (
+	APNSPriorityImmediate  = 10
+	APNSPriorityConserving = 5
+)
+
+// APNSConfig holds a token-based (.p8) APNs key and the defaults applied to
+// every message that does not set its own
+type APNSConfig struct {
+	KeyID      string
+	TeamID     string
+	PrivateKey string
+	// BundleID is the apns-topic messages are sent to
+	BundleID   string
+	Production bool
+	// Endpoint overrides the production or sandbox host, e.g. for a local stub
+	Endpoint           string
+	InsecureSkipVerify bool
+	Sound              string
+	Priority           int
+	// Expiration is how long APNs keeps trying an offline device; zero tries once
+	Expiration time.Duration
+	Timeout    time.Duration
+}
+
+// APNSProvider sends push notifications to Apple devices over HTTP/2
+type APNSProvider struct {
+	config APNSConfig
+	key    *ecdsa.PrivateKey
+	client *http.Client
+
+	mu          sync.Mutex
+	bearer      string
+	bearerIssue time.Time
+}
+
+// NewAPNSProvider creates a new APNs provider from the given config
+func NewAPNSProvider(config APNSConfig) (*APNSProvider, error) {
+	p := &APNSProvider{}
+	if err := p.setConfig(config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CreateClient configures the provider from a credentials map
+func (p *APNSProvider) CreateClient(ctx context.Context, credentials map[string]interface{}) error {
+	config, err := ParseAPNSConfig(credentials)
+	if err != nil {
+		return err
+	}
+	return p.setConfig(config)
+}
+
+// ParseAPNSConfig builds an APNSConfig from stored credentials
+func ParseAPNSConfig(credentials map[string]interface{}) (APNSConfig, error) {
+	config := APNSConfig{}
+
+	keyID, ok := credentials["key_id"].(string)
+	if !ok || keyID == "" {
+		return config, fmt.Errorf("invalid key_id in credentials")
+	}
+	config.KeyID = keyID
+
+	teamID, ok := credentials["team_id"].(string)
+	if !ok || teamID == "" {
+		return config, fmt.Errorf("invalid team_id in credentials")
+	}
+	config.TeamID = teamID
+
+	privateKey, ok := credentials["private_key"].(string)
+	if !ok || privateKey == "" {
+		return config, fmt.Errorf("invalid private_key in credentials")
+	}
+	config.PrivateKey = privateKey
+
+	bundleID, ok := credentials["bundle_id"].(string)
+	if !ok || bundleID == "" {
+		return config, fmt.Errorf("invalid bundle_id in credentials")
+	}
+	config.BundleID = bundleID
+
+	config.Production, _ = credentials["production"].(bool)
+	config.Endpoint, _ = credentials["endpoint"].(string)
+	config.InsecureSkipVerify, _ = credentials["insecure_skip_verify"].(bool)
+	config.Sound, _ = credentials["sound"].(string)
+
+	if priority, ok := credentials["priority"]; ok {
+		parsed, err := parseAPNSPriority(priority)
+		if err != nil {
+			return config, err
+		}
+		config.Priority = parsed
+	}
+	if expiration, ok := credentials["expiration_seconds"].(float64); ok && expiration > 0 {
+		config.Expiration = time.Duration(expiration) * time.Second
+	}
+	if timeout, ok := credentials["timeout_seconds"].(float64); ok && timeout > 0 {
+		config.Timeout = time.Duration(timeout) * time.Second
+	}
+
+	return config, nil
+}
+
+func (p *APNSProvider) setConfig(config APNSConfig) error {
+	if config.KeyID == "" || config.TeamID == "" {
+		return fmt.Errorf("apns key id and team id are required")
+	}
+	if config.BundleID == "" {
+		return fmt.Errorf("apns bundle id is required")
+	}
+
+	key, err := parseECPrivateKey(config.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("invalid apns private key: %w", err)
+	}
+
+	if config.Endpoint == "" {
+		config.Endpoint = apnsSandboxEndpoint
+		if config.Production {
+			config.Endpoint = apnsProductionEndpoint
+		}
+	}
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	if config.Priority == 0 {
+		config.Priority = APNSPriorityImmediate
+	}
+	if config.Timeout == 0 {
+		config.Timeout = defaultAPNSTimeout
+	}
+
+	// APNs only accepts HTTP/2
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = true
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+	p.key = key
+	p.client = &http.Client{Transport: transport, Timeout: config.Timeout}
+	p.bearer = ""
+	p.bearerIssue = time.Time{}
+	return nil
+}
+
+// SendNotification pushes the message to each of the receiver's device tokens,
+// reporting the tokens APNs no longer accepts
+func (p *APNSProvider) SendNotification(ctx context.Context, req dtos.SendNotificationRequest) dtos.SendNotificationResponse {
+	if p.key == nil {
+		return dtos.SendNotificationResponse{Success: false, Message: "Client not initialized"}
+	}
+
+	if !strings.EqualFold(req.Channel, "push") {
+		return dtos.SendNotificationResponse{Success: false, Message: "Unsupported notification channel"}
+	}
+
+	if req.Receiver == "" {
+		return dtos.SendNotificationResponse{Success: false, Message: "Receiver is required"}
+	}
+
+	message, err := p.buildMessage(req)
+	if err != nil {
+		return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
+	}
+
+	return sendToDevices(req.DeviceTokens, func(token string) (string, error) {
+		return p.send(ctx, token, message)
+	})
+}
+
+// apnsMessage is one push: the JSON payload and the request headers that go with it
+type apnsMessage struct {
+	payload    map[string]interface{}
+	pushType   string
+	priority   int
+	expiration time.Time
+	collapseID string
+}
+
+// apnsContent is rendered content that spells out the push itself. Alert is a
+// string or an object with title, subtitle and body; Data keys are sent next to
+// aps for the app to read.
+type apnsContent struct {
+	Alert            interface{} `json:"alert"`
+	Badge            *int        `json:"badge"`
+	Sound            string      `json:"sound"`
+	ContentAvailable bool        `json:"content_available"`
+	ThreadID         string      `json:"thread_id"`
+	CollapseID       string      `json:"collapse_id"`
+	Priority         interface{} `json:"priority"`
+	// Expiration is a Unix timestamp after which APNs stops trying the device
+	Expiration int64                  `json:"expiration"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// buildMessage sends content that is a JSON push description as given; other
+// content is the alert body under the request's title
+func (p *APNSProvider) buildMessage(req dtos.SendNotificationRequest) (*apnsMessage, error) {
+	var content apnsContent
+	trimmed := strings.TrimSpace(req.Content)
+	if !strings.HasPrefix(trimmed, "{") || json.Unmarshal([]byte(trimmed), &content) != nil ||
+		(content.Alert == nil && content.Badge == nil && !content.ContentAvailable && content.Data == nil) {
+		title := req.Title
+		if title == "" {
+			title = req.Subject
+		}
+		alert := map[string]interface{}{"body": req.Content}
+		if title != "" {
+			alert["title"] = title
+		}
+		content = apnsContent{Alert: alert}
+	}
+
+	aps := map[string]interface{}{}
+	if content.Alert != nil {
+		aps["alert"] = content.Alert
+	}
+	if content.Badge != nil {
+		aps["badge"] = *content.Badge
+	}
+	sound := content.Sound
+	if sound == "" {
+		sound = p.config.Sound
+	}
+	if sound != "" && content.Alert != nil {
+		aps["sound"] = sound
+	}
+	if content.ContentAvailable {
+		aps["content-available"] = 1
+	}
+	if content.ThreadID != "" {
+		aps["thread-id"] = content.ThreadID
+	}
+
+	payload := make(map[string]interface{}, len(content.Data)+1)
+	for key, value := range content.Data {
+		payload[key] = value
+	}
+	payload["aps"] = aps
+
+	message := &apnsMessage{
+		payload:    payload,
+		pushType:   "alert",
+		priority:   p.config.Priority,
+		collapseID: content.CollapseID,
+	}
+
+	// Silent pushes must be sent as background pushes at conserving priority
+	if content.Alert == nil && content.Badge == nil && content.ContentAvailable {
+		message.pushType = "background"
+		message.priority = APNSPriorityConserving
+	}
+	if content.Priority != nil {
+		priority, err := parseAPNSPriority(content.Priority)
+		if err != nil {
+			return nil, err
+		}
+		message.priority = priority
+	}
+
+	if content.Expiration > 0 {
+		message.expiration = time.Unix(content.Expiration, 0)
+	} else if p.config.Expiration > 0 {
+		message.expiration = time.Now().Add(p.config.Expiration)
+	}
+
+	if len(message.collapseID) > 64 {
+		return nil, fmt.Errorf("apns collapse id must be at most 64 bytes")
+	}
+	return message, nil
+}
+
+// parseAPNSPriority accepts 10 or 5, or "high" or "normal"
+func parseAPNSPriority(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case float64:
+		if int(v) == APNSPriorityImmediate || int(v) == APNSPriorityConserving {
+			return int(v), nil
+		}
+	case int:
+		if v == APNSPriorityImmediate || v == APNSPriorityConserving {
+			return v, nil
+		}
+	case string:
+		switch strings.ToLower(v) {
+		case "high", "10":
+			return APNSPriorityImmediate, nil
+		case "normal", "5":
+			return APNSPriorityConserving, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid apns priority: %v", value)
+}
+
+// send posts the push to the device token and returns the apns-id APNs assigned
+func (p *APNSProvider) send(ctx context.Context, deviceToken string, message *apnsMessage) (string, error) {
+	bearer, err := p.token()
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(message.payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal apns payload: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/3/device/%s", p.config.Endpoint, url.PathEscape(deviceToken))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create apns request: %w", err)
+	}
+	httpReq.Header.Set("authorization", "bearer "+bearer)
+	httpReq.Header.Set("apns-topic", p.config.BundleID)
+	httpReq.Header.Set("apns-push-type", message.pushType)
+	httpReq.Header.Set("apns-priority", strconv.Itoa(message.priority))
+	if !message.expiration.IsZero() {
+		httpReq.Header.Set("apns-expiration", strconv.FormatInt(message.expiration.Unix(), 10))
+	}
+	if message.collapseID != "" {
+		httpReq.Header.Set("apns-collapse-id", message.collapseID)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("apns request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return resp.Header.Get("apns-id"), nil
+	}
+
+	var result struct {
+		Reason    string `json:"reason"`
+		Timestamp int64  `json:"timestamp"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&result)
+
+	switch {
+	case resp.StatusCode == http.StatusGone:
+		// The device token is no longer active for the topic
+		return "", fmt.Errorf("%w: %s", ErrUnregisteredToken, result.Reason)
+	case resp.StatusCode == http.StatusForbidden && (result.Reason == "ExpiredProviderToken" || result.Reason == "InvalidProviderToken"):
+		// The next send signs a fresh provider token
+		p.resetToken()
+	}
+
+	if result.Reason == "" {
+		return "", fmt.Errorf("apns returned status %d", resp.StatusCode)
+	}
+	return "", fmt.Errorf("apns returned %d %s", resp.StatusCode, result.Reason)
+}
+
+// token returns the ES256 provider token, signing a new one when the cached
+// token is due for renewal
+func (p *APNSProvider) token() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.bearer != "" && now.Sub(p.bearerIssue) < apnsTokenLifetime {
+		return p.bearer, nil
+	}
+
+	header := map[string]string{"alg": "ES256", "kid": p.config.KeyID}
+	claims := map[string]interface{}{"iss": p.config.TeamID, "iat": now.Unix()}
+	bearer, err := signJWT(header, claims, func(signingInput []byte) ([]byte, error) {
+		digest := sha256.Sum256(signingInput)
+		r, s, err := ecdsa.Sign(rand.Reader, p.key, digest[:])
+		if err != nil {
+			return nil, err
+		}
+		// JWS uses the fixed-size r || s encoding rather than ASN.1
+		return append(padBigInt(r, 32), padBigInt(s, 32)...), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	p.bearer = bearer
+	p.bearerIssue = now
+	return p.bearer, nil
+}
+
+func (p *APNSProvider) resetToken() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.bearer = ""
+}
+
+func padBigInt(n *big.Int, size int) []byte {
+	b := n.Bytes()
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
@@ -0,0 +1,32 @@
+package providers
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const defaultChatTimeout = 30 * time.Second
+
+// decodeChatContent returns content that is a JSON object, such as a Slack
+// message with blocks or an Adaptive Card rendered from a template
+func decodeChatContent(content string) (map[string]interface{}, bool) {
+	trimmed := strings.TrimSpace(content)
+	if !strings.HasPrefix(trimmed, "{") {
+		return nil, false
+	}
+	var message map[string]interface{}
+	if err := json.Unmarshal([]byte(trimmed), &message); err != nil {
+		return nil, false
+	}
+	return message, true
+}
+
+// chatText is the plain text of a chat message: the content under the
+// request's title, with the title wrapped in the chat's bold markup
+func chatText(title, content, bold string) string {
+	if title == "" {
+		return content
+	}
+	return bold + title + bold + "\n" + content
+}
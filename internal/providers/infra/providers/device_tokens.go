@@ -0,0 +1,49 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"getnoti.com/internal/providers/dtos"
+)
+
+// sendToDevices sends a push to each of the receiver's device tokens. Tokens the
+// push service reports as no longer registered are returned as invalid tokens,
+// and the send succeeds when any device accepted it.
+func sendToDevices(tokens []string, send func(token string) (string, error)) dtos.SendNotificationResponse {
+	if len(tokens) == 0 {
+		return dtos.SendNotificationResponse{Success: false, Message: "Receiver has no registered device tokens"}
+	}
+
+	var messageIDs, invalid, failures []string
+	for _, token := range tokens {
+		messageID, err := send(token)
+		if errors.Is(err, ErrUnregisteredToken) {
+			invalid = append(invalid, token)
+			continue
+		}
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		messageIDs = append(messageIDs, messageID)
+	}
+
+	if len(messageIDs) == 0 {
+		message := "no device accepted the message"
+		if len(failures) > 0 {
+			message = strings.Join(failures, "; ")
+		} else if len(invalid) > 0 {
+			message = ErrUnregisteredToken.Error()
+		}
+		return dtos.SendNotificationResponse{Success: false, Message: message, InvalidTokens: invalid}
+	}
+
+	return dtos.SendNotificationResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("delivered to %d of %d devices", len(messageIDs), len(tokens)),
+		MessageID:     messageIDs[0],
+		InvalidTokens: invalid,
+	}
+}
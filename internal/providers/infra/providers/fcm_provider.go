@@ -0,0 +1,415 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"getnoti.com/internal/providers/dtos"
+)
+
+const (
+	defaultFCMEndpoint    = "https://fcm.googleapis.com"
+	defaultGoogleTokenURI = "https://oauth2.googleapis.com/token"
+	fcmScope              = "https://www.googleapis.com/auth/firebase.messaging"
+	defaultFCMTimeout     = 30 * time.Second
+	// fcmTokenRefreshMargin renews the access token shortly before it expires
+	fcmTokenRefreshMargin = time.Minute
+)
+
+// ErrUnregisteredToken means the push service no longer accepts the device
+// token; senders should stop using it
+var ErrUnregisteredToken = errors.New("device token is no longer registered")
+
+// FCMConfig holds a Firebase service account and the platform overrides applied
+// to every message that does not set its own
+type FCMConfig struct {
+	ProjectID    string
+	ClientEmail  string
+	PrivateKey   string
+	PrivateKeyID string
+	// TokenURI and Endpoint default to Google's; they can point at a local stub
+	TokenURI string
+	Endpoint string
+	Android  map[string]interface{}
+	APNS     map[string]interface{}
+	WebPush  map[string]interface{}
+	Timeout  time.Duration
+}
+
+// FCMProvider sends push notifications through the FCM HTTP v1 API
+type FCMProvider struct {
+	config FCMConfig
+	key    *rsa.PrivateKey
+	client *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// NewFCMProvider creates a new FCM provider from the given config
+func NewFCMProvider(config FCMConfig) (*FCMProvider, error) {
+	p := &FCMProvider{}
+	if err := p.setConfig(config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CreateClient configures the provider from a credentials map
+func (p *FCMProvider) CreateClient(ctx context.Context, credentials map[string]interface{}) error {
+	config, err := ParseFCMConfig(credentials)
+	if err != nil {
+		return err
+	}
+	return p.setConfig(config)
+}
+
+// ParseFCMConfig builds an FCMConfig from stored credentials. The service
+// account is read from service_account, either the downloaded JSON key file as
+// a string or object, or from project_id, client_email and private_key.
+func ParseFCMConfig(credentials map[string]interface{}) (FCMConfig, error) {
+	config := FCMConfig{}
+
+	var account map[string]interface{}
+	switch serviceAccount := credentials["service_account"].(type) {
+	case string:
+		if err := json.Unmarshal([]byte(serviceAccount), &account); err != nil {
+			return config, fmt.Errorf("invalid service_account in credentials")
+		}
+	case map[string]interface{}:
+		account = serviceAccount
+	case nil:
+		account = credentials
+	default:
+		return config, fmt.Errorf("invalid service_account in credentials")
+	}
+
+	config.ProjectID, _ = account["project_id"].(string)
+	if config.ProjectID == "" {
+		return config, fmt.Errorf("invalid project_id in credentials")
+	}
+	config.ClientEmail, _ = account["client_email"].(string)
+	if config.ClientEmail == "" {
+		return config, fmt.Errorf("invalid client_email in credentials")
+	}
+	config.PrivateKey, _ = account["private_key"].(string)
+	if config.PrivateKey == "" {
+		return config, fmt.Errorf("invalid private_key in credentials")
+	}
+	config.PrivateKeyID, _ = account["private_key_id"].(string)
+	config.TokenURI, _ = account["token_uri"].(string)
+
+	if tokenURI, ok := credentials["token_uri"].(string); ok && tokenURI != "" {
+		config.TokenURI = tokenURI
+	}
+	config.Endpoint, _ = credentials["endpoint"].(string)
+	config.Android, _ = credentials["android"].(map[string]interface{})
+	config.APNS, _ = credentials["apns"].(map[string]interface{})
+	config.WebPush, _ = credentials["webpush"].(map[string]interface{})
+
+	if timeout, ok := credentials["timeout_seconds"].(float64); ok && timeout > 0 {
+		config.Timeout = time.Duration(timeout) * time.Second
+	}
+
+	return config, nil
+}
+
+func (p *FCMProvider) setConfig(config FCMConfig) error {
+	if config.ProjectID == "" {
+		return fmt.Errorf("fcm project id is required")
+	}
+	if config.ClientEmail == "" {
+		return fmt.Errorf("fcm client email is required")
+	}
+
+	key, err := parseRSAPrivateKey(config.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("invalid fcm private key: %w", err)
+	}
+
+	if config.TokenURI == "" {
+		config.TokenURI = defaultGoogleTokenURI
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = defaultFCMEndpoint
+	}
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	if config.Timeout == 0 {
+		config.Timeout = defaultFCMTimeout
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+	p.key = key
+	p.client = &http.Client{Timeout: config.Timeout}
+	p.accessToken = ""
+	p.tokenExpiry = time.Time{}
+	return nil
+}
+
+// SendNotification sends the message to each of the receiver's device tokens,
+// reporting the tokens FCM no longer accepts
+func (p *FCMProvider) SendNotification(ctx context.Context, req dtos.SendNotificationRequest) dtos.SendNotificationResponse {
+	// CreateClient may reconfigure the provider while a send is in flight
+	config, key, client := p.settings()
+	if key == nil {
+		return dtos.SendNotificationResponse{Success: false, Message: "Client not initialized"}
+	}
+
+	if !strings.EqualFold(req.Channel, "push") {
+		return dtos.SendNotificationResponse{Success: false, Message: "Unsupported notification channel"}
+	}
+
+	if req.Receiver == "" {
+		return dtos.SendNotificationResponse{Success: false, Message: "Receiver is required"}
+	}
+
+	message, err := buildFCMMessage(req, config)
+	if err != nil {
+		return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
+	}
+
+	return sendToDevices(req.DeviceTokens, func(token string) (string, error) {
+		deviceMessage := *message
+		deviceMessage.Token = token
+		return p.send(ctx, config, client, &deviceMessage)
+	})
+}
+
+// settings returns the current config, key and HTTP client together
+func (p *FCMProvider) settings() (FCMConfig, *rsa.PrivateKey, *http.Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.config, p.key, p.client
+}
+
+// fcmMessage is the v1 API message sent to one device token
+type fcmMessage struct {
+	Token        string                 `json:"token"`
+	Notification *fcmNotification       `json:"notification,omitempty"`
+	Data         map[string]string      `json:"data,omitempty"`
+	Android      map[string]interface{} `json:"android,omitempty"`
+	APNS         map[string]interface{} `json:"apns,omitempty"`
+	WebPush      map[string]interface{} `json:"webpush,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+	Image string `json:"image,omitempty"`
+}
+
+// fcmPayload is rendered content that spells out the message itself, so a
+// template can send data and per-platform settings
+type fcmPayload struct {
+	Notification *fcmNotification       `json:"notification"`
+	Data         map[string]interface{} `json:"data"`
+	Android      map[string]interface{} `json:"android"`
+	APNS         map[string]interface{} `json:"apns"`
+	WebPush      map[string]interface{} `json:"webpush"`
+}
+
+// buildFCMMessage sends content that is a JSON payload as given; other content
+// is the notification body under the request's title
+func buildFCMMessage(req dtos.SendNotificationRequest, config FCMConfig) (*fcmMessage, error) {
+	message := &fcmMessage{}
+
+	var payload fcmPayload
+	content := strings.TrimSpace(req.Content)
+	if strings.HasPrefix(content, "{") && json.Unmarshal([]byte(content), &payload) == nil &&
+		(payload.Notification != nil || payload.Data != nil || payload.Android != nil || payload.APNS != nil || payload.WebPush != nil) {
+		message.Notification = payload.Notification
+		message.Android = payload.Android
+		message.APNS = payload.APNS
+		message.WebPush = payload.WebPush
+
+		if len(payload.Data) > 0 {
+			// FCM data values must be strings
+			message.Data = make(map[string]string, len(payload.Data))
+			for key, value := range payload.Data {
+				if s, ok := value.(string); ok {
+					message.Data[key] = s
+					continue
+				}
+				encoded, err := json.Marshal(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid data value for %s: %w", key, err)
+				}
+				message.Data[key] = string(encoded)
+			}
+		}
+	} else {
+		title := req.Title
+		if title == "" {
+			title = req.Subject
+		}
+		message.Notification = &fcmNotification{Title: title, Body: req.Content}
+	}
+
+	if message.Android == nil {
+		message.Android = config.Android
+	}
+	if message.APNS == nil {
+		message.APNS = config.APNS
+	}
+	if message.WebPush == nil {
+		message.WebPush = config.WebPush
+	}
+	return message, nil
+}
+
+// fcmError is the error body returned by the v1 API
+type fcmError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			Type      string `json:"@type"`
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// send posts the message and returns the message name FCM assigned to it
+func (p *FCMProvider) send(ctx context.Context, config FCMConfig, client *http.Client, message *fcmMessage) (string, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"message": message})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal fcm message: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/messages:send", config.Endpoint, url.PathEscape(config.ProjectID))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create fcm request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("fcm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read fcm response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		var result struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			return "", fmt.Errorf("invalid fcm response: %w", err)
+		}
+		return result.Name, nil
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		// The next send fetches a fresh access token
+		p.resetToken()
+	}
+
+	var fcmErr fcmError
+	if json.Unmarshal(respBody, &fcmErr) != nil || fcmErr.Error.Status == "" {
+		return "", fmt.Errorf("fcm returned status %d", resp.StatusCode)
+	}
+	for _, detail := range fcmErr.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return "", fmt.Errorf("%w: %s", ErrUnregisteredToken, fcmErr.Error.Message)
+		}
+	}
+	return "", fmt.Errorf("fcm returned %s: %s", fcmErr.Error.Status, fcmErr.Error.Message)
+}
+
+// token returns an OAuth access token for the service account, exchanging a
+// signed JWT assertion for a new one when the cached token is about to expire
+func (p *FCMProvider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.accessToken != "" && now.Before(p.tokenExpiry) {
+		return p.accessToken, nil
+	}
+
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	if p.config.PrivateKeyID != "" {
+		header["kid"] = p.config.PrivateKeyID
+	}
+	claims := map[string]interface{}{
+		"iss":   p.config.ClientEmail,
+		"scope": fcmScope,
+		"aud":   p.config.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+	assertion, err := signJWT(header, claims, func(signingInput []byte) ([]byte, error) {
+		digest := sha256.Sum256(signingInput)
+		return rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	})
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("fcm token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result); err != nil {
+		return "", fmt.Errorf("invalid fcm token response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+		return "", fmt.Errorf("fcm token request rejected (status %d): %s %s", resp.StatusCode, result.Error, result.ErrorDescription)
+	}
+
+	p.accessToken = result.AccessToken
+	p.tokenExpiry = now.Add(time.Duration(result.ExpiresIn)*time.Second - fcmTokenRefreshMargin)
+	return p.accessToken, nil
+}
+
+func (p *FCMProvider) resetToken() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.accessToken = ""
+}
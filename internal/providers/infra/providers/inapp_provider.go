@@ -0,0 +1,99 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"getnoti.com/internal/notifications/domain"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	"getnoti.com/internal/providers/dtos"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	"getnoti.com/pkg/sse"
+	"github.com/google/uuid"
+)
+
+// InAppProvider delivers notifications to the receiver's in-app inbox and
+// streams them to the receiver's open clients. Clients connected to another
+// API instance see the item the next time they load the inbox.
+type InAppProvider struct {
+	tenantID  string
+	inbox     notificationRepos.InboxRepository
+	publisher sse.Publisher
+}
+
+// NewInAppProvider creates a provider delivering to the tenant's inboxes
+func NewInAppProvider(tenantID string, inbox notificationRepos.InboxRepository, publisher sse.Publisher) *InAppProvider {
+	return &InAppProvider{
+		tenantID:  tenantID,
+		inbox:     inbox,
+		publisher: publisher,
+	}
+}
+
+// CreateClient is a no-op; in-app delivery needs no credentials
+func (p *InAppProvider) CreateClient(ctx context.Context, credentials map[string]interface{}) error {
+	return nil
+}
+
+// inAppContent is rendered content that spells out the inbox item. Data is
+// passed to the client as is, e.g. a link to open when the item is clicked.
+type inAppContent struct {
+	Title string                 `json:"title"`
+	Body  string                 `json:"body"`
+	Data  map[string]interface{} `json:"data"`
+}
+
+func (p *InAppProvider) SendNotification(ctx context.Context, req dtos.SendNotificationRequest) dtos.SendNotificationResponse {
+	if p.inbox == nil {
+		return dtos.SendNotificationResponse{Success: false, Message: "Client not initialized"}
+	}
+
+	if !strings.EqualFold(req.Channel, string(tenantDomain.ChannelTypeInApp)) {
+		return dtos.SendNotificationResponse{Success: false, Message: "Unsupported notification channel"}
+	}
+
+	if req.Receiver == "" {
+		return dtos.SendNotificationResponse{Success: false, Message: "Receiver is required"}
+	}
+
+	item := &domain.InboxItem{
+		ID:             uuid.New().String(),
+		UserID:         req.Receiver,
+		NotificationID: req.NotificationID,
+		Category:       req.Category,
+	}
+
+	// Content that is a JSON item description is used as given; other content
+	// is the body under the request's title
+	var content inAppContent
+	trimmed := strings.TrimSpace(req.Content)
+	if strings.HasPrefix(trimmed, "{") && json.Unmarshal([]byte(trimmed), &content) == nil && (content.Title != "" || content.Body != "") {
+		item.Title = content.Title
+		item.Body = content.Body
+		item.Data = content.Data
+	} else {
+		item.Title = req.Title
+		if item.Title == "" {
+			item.Title = req.Subject
+		}
+		item.Body = req.Content
+	}
+
+	if err := p.inbox.CreateInboxItem(ctx, item); err != nil {
+		return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
+	}
+
+	// The item is stored; a failed stream only delays it until the next inbox load
+	if p.publisher != nil {
+		count, err := p.inbox.CountUnreadInboxItems(ctx, item.UserID)
+		if err == nil {
+			event, err := json.Marshal(domain.InboxEvent{Type: domain.InboxEventItemCreated, Item: item, UnreadCount: count})
+			if err == nil {
+				p.publisher.Publish(domain.InboxStreamChannel(p.tenantID, item.UserID), event)
+			}
+		}
+	}
+
+	return dtos.SendNotificationResponse{Success: true, Message: "Delivered to inbox", MessageID: item.ID}
+}
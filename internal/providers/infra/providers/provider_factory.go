@@ -1,100 +1,225 @@
-
 package providers
 
 import (
-    "context"
-    "fmt"
-    "getnoti.com/internal/providers/repos"
-    "getnoti.com/pkg/cache"
-    "getnoti.com/pkg/credentials"
+	"context"
+	"fmt"
+	notificationRepos "getnoti.com/internal/notifications/repos"
+	"getnoti.com/internal/providers/repos"
+	tenantRepos "getnoti.com/internal/tenants/repos"
+	"getnoti.com/pkg/cache"
+	"getnoti.com/pkg/credentials"
+	"getnoti.com/pkg/sse"
 )
 
 type ProviderFactory struct {
-    providerCache     *cache.GenericCache
-    providerRepo      repos.ProviderRepository
-    credentialManager *credentials.Manager
+	providerCache     *cache.GenericCache
+	providerRepo      repos.ProviderRepository
+	credentialManager *credentials.Manager
+	// tenantRepos gives providers that deliver through the tenant database,
+	// such as web push and in-app, the tenant's repositories
+	tenantRepos TenantRepositoryFactory
+	// publisher streams in-app messages to connected clients
+	publisher sse.Publisher
+}
+
+// TenantRepositoryFactory opens the tenant repositories providers deliver through
+type TenantRepositoryFactory interface {
+	GetWebPushSubscriptionRepositoryForTenant(tenantID string) (tenantRepos.WebPushSubscriptionRepository, error)
+	GetInboxRepositoryForTenant(tenantID string) (notificationRepos.InboxRepository, error)
 }
 
-func NewProviderFactory(providerCache *cache.GenericCache, providerRepo repos.ProviderRepository, credentialManager *credentials.Manager) *ProviderFactory {
-    return &ProviderFactory{
-        providerCache:     providerCache,
-        providerRepo:      providerRepo,
-        credentialManager: credentialManager,
-    }
+func NewProviderFactory(providerCache *cache.GenericCache, providerRepo repos.ProviderRepository, credentialManager *credentials.Manager, tenantRepos TenantRepositoryFactory, publisher sse.Publisher) *ProviderFactory {
+	return &ProviderFactory{
+		providerCache:     providerCache,
+		providerRepo:      providerRepo,
+		credentialManager: credentialManager,
+		tenantRepos:       tenantRepos,
+		publisher:         publisher,
+	}
 }
 
 func (f *ProviderFactory) GetProvider(providerID string, tenantID string, channel string) (Provider, error) {
-    key := providerID + ":" + tenantID + ":" + channel
-
-    if cachedProvider, exists := f.providerCache.Get(key); exists {
-        if provider, ok := cachedProvider.(Provider); ok {
-            return provider, nil
-        }
-    }
-
-    providerDTO, err := f.providerRepo.GetProviderByID(context.Background(), providerID)
-    if err != nil {
-        return nil, fmt.Errorf("failed to get provider: %v", err)
-    }
-
-    var provider Provider
-    switch providerDTO.Name {
-    case "twilio":
-        credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, providerDTO.Name)
-        if err != nil {
-            return nil, fmt.Errorf("failed to get twilio credentials: %v", err)
-        }
-        
-        accountSid, ok := credMap["account_sid"].(string)
-        if !ok {
-            return nil, fmt.Errorf("invalid account_sid in credentials")
-        }
-        authToken, ok := credMap["auth_token"].(string)
-        if !ok {
-            return nil, fmt.Errorf("invalid auth_token in credentials")
-        }
-        
-        provider = NewTwilioProvider(accountSid, authToken)
-        
-    // case "aws_ses":
-    //     credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, "aws")
-    //     if err != nil {
-    //         return nil, fmt.Errorf("failed to get AWS credentials: %v", err)
-    //     }
-        
-    //     accessKey, ok := credMap["access_key"].(string)
-    //     if !ok {
-    //         return nil, fmt.Errorf("invalid AWS access_key in credentials")
-    //     }
-    //     secretKey, ok := credMap["secret_key"].(string)
-    //     if !ok {
-    //         return nil, fmt.Errorf("invalid AWS secret_key in credentials")
-    //     }
-    //     region, ok := credMap["region"].(string)
-    //     if !ok {
-    //         return nil, fmt.Errorf("invalid AWS region in credentials")
-    //     }
-        
-    //     provider = NewAWSSESProvider(accessKey, secretKey, region)
-        
-    // case "sendgrid":
-    //     credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, providerDTO.Name)
-    //     if err != nil {
-    //         return nil, fmt.Errorf("failed to get sendgrid credentials: %v", err)
-    //     }
-        
-    //     apiKey, ok := credMap["api_key"].(string)
-    //     if !ok {
-    //         return nil, fmt.Errorf("invalid api_key in credentials")
-    //     }
-        
-    //     provider = NewSendGridProvider(apiKey)
-        
-    default:
-        return nil, fmt.Errorf("unsupported provider: %s", providerDTO.Name)
-    }
-
-    // Cache the new provider
-    f.providerCache.Set(key, provider, 1)
-    return provider, nil
-}
\ No newline at end of file
+	key := providerID + ":" + tenantID + ":" + channel
+
+	if cachedProvider, exists := f.providerCache.Get(key); exists {
+		if provider, ok := cachedProvider.(Provider); ok {
+			return provider, nil
+		}
+	}
+
+	providerDTO, err := f.providerRepo.GetProviderByID(context.Background(), providerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider: %v", err)
+	}
+
+	var provider Provider
+	switch providerDTO.Name {
+	case "twilio":
+		credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, providerDTO.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get twilio credentials: %v", err)
+		}
+
+		accountSid, ok := credMap["account_sid"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid account_sid in credentials")
+		}
+		authToken, ok := credMap["auth_token"].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid auth_token in credentials")
+		}
+
+		provider = NewTwilioProvider(accountSid, authToken)
+
+	case "smtp":
+		credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, providerDTO.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get smtp credentials: %v", err)
+		}
+
+		config, err := ParseSMTPConfig(credMap)
+		if err != nil {
+			return nil, err
+		}
+
+		provider, err = NewSMTPProvider(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create smtp provider: %v", err)
+		}
+
+	case "fcm":
+		credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, providerDTO.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get fcm credentials: %v", err)
+		}
+
+		config, err := ParseFCMConfig(credMap)
+		if err != nil {
+			return nil, err
+		}
+
+		provider, err = NewFCMProvider(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create fcm provider: %v", err)
+		}
+
+	case "apns":
+		credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, providerDTO.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get apns credentials: %v", err)
+		}
+
+		config, err := ParseAPNSConfig(credMap)
+		if err != nil {
+			return nil, err
+		}
+
+		provider, err = NewAPNSProvider(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create apns provider: %v", err)
+		}
+
+	case "webpush":
+		credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, providerDTO.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get webpush credentials: %v", err)
+		}
+
+		config, err := ParseWebPushConfig(credMap)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions, err := f.tenantRepos.GetWebPushSubscriptionRepositoryForTenant(tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get web push subscriptions: %v", err)
+		}
+
+		provider, err = NewWebPushProvider(config, subscriptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create webpush provider: %v", err)
+		}
+
+	case "inapp":
+		// In-app messages are stored in the tenant database; there are no credentials
+		inbox, err := f.tenantRepos.GetInboxRepositoryForTenant(tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inbox repository: %v", err)
+		}
+
+		provider = NewInAppProvider(tenantID, inbox, f.publisher)
+
+	case "slack":
+		credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, providerDTO.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get slack credentials: %v", err)
+		}
+
+		config, err := ParseSlackConfig(credMap)
+		if err != nil {
+			return nil, err
+		}
+
+		provider, err = NewSlackProvider(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create slack provider: %v", err)
+		}
+
+	case "teams":
+		credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, providerDTO.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get teams credentials: %v", err)
+		}
+
+		config, err := ParseTeamsConfig(credMap)
+		if err != nil {
+			return nil, err
+		}
+
+		provider, err = NewTeamsProvider(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create teams provider: %v", err)
+		}
+
+	// case "aws_ses":
+	//     credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, "aws")
+	//     if err != nil {
+	//         return nil, fmt.Errorf("failed to get AWS credentials: %v", err)
+	//     }
+
+	//     accessKey, ok := credMap["access_key"].(string)
+	//     if !ok {
+	//         return nil, fmt.Errorf("invalid AWS access_key in credentials")
+	//     }
+	//     secretKey, ok := credMap["secret_key"].(string)
+	//     if !ok {
+	//         return nil, fmt.Errorf("invalid AWS secret_key in credentials")
+	//     }
+	//     region, ok := credMap["region"].(string)
+	//     if !ok {
+	//         return nil, fmt.Errorf("invalid AWS region in credentials")
+	//     }
+
+	//     provider = NewAWSSESProvider(accessKey, secretKey, region)
+
+	// case "sendgrid":
+	//     credMap, err := f.credentialManager.GetCredentials(tenantID, credentials.GenericCredential, providerDTO.Name)
+	//     if err != nil {
+	//         return nil, fmt.Errorf("failed to get sendgrid credentials: %v", err)
+	//     }
+
+	//     apiKey, ok := credMap["api_key"].(string)
+	//     if !ok {
+	//         return nil, fmt.Errorf("invalid api_key in credentials")
+	//     }
+
+	//     provider = NewSendGridProvider(apiKey)
+
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", providerDTO.Name)
+	}
+
+	// Cache the new provider
+	f.providerCache.Set(key, provider, 1)
+	return provider, nil
+}
@@ -0,0 +1,227 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"getnoti.com/internal/providers/dtos"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+)
+
+const defaultSlackAPIURL = "https://slack.com/api"
+
+// SlackConfig holds either an incoming webhook URL or a bot token. With a bot
+// token messages are posted with chat.postMessage to Channel, unless the
+// content names its own channel; a webhook always posts to the channel it was
+// created for.
+type SlackConfig struct {
+	WebhookURL string
+	BotToken   string
+	Channel    string
+	Username   string
+	IconEmoji  string
+	IconURL    string
+	// APIURL overrides the Slack Web API base URL, e.g. for a local stub
+	APIURL  string
+	Timeout time.Duration
+}
+
+// SlackProvider sends chat messages to Slack
+type SlackProvider struct {
+	config SlackConfig
+	client *http.Client
+}
+
+// NewSlackProvider creates a new Slack provider from the given config
+func NewSlackProvider(config SlackConfig) (*SlackProvider, error) {
+	p := &SlackProvider{}
+	if err := p.setConfig(config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CreateClient configures the provider from a credentials map
+func (p *SlackProvider) CreateClient(ctx context.Context, credentials map[string]interface{}) error {
+	config, err := ParseSlackConfig(credentials)
+	if err != nil {
+		return err
+	}
+	return p.setConfig(config)
+}
+
+// ParseSlackConfig builds a SlackConfig from stored credentials
+func ParseSlackConfig(credentials map[string]interface{}) (SlackConfig, error) {
+	config := SlackConfig{}
+
+	config.WebhookURL, _ = credentials["webhook_url"].(string)
+	config.BotToken, _ = credentials["bot_token"].(string)
+	if config.WebhookURL == "" && config.BotToken == "" {
+		return config, fmt.Errorf("invalid webhook_url or bot_token in credentials")
+	}
+
+	config.Channel, _ = credentials["channel"].(string)
+	config.Username, _ = credentials["username"].(string)
+	config.IconEmoji, _ = credentials["icon_emoji"].(string)
+	config.IconURL, _ = credentials["icon_url"].(string)
+	config.APIURL, _ = credentials["api_url"].(string)
+
+	if timeout, ok := credentials["timeout_seconds"].(float64); ok && timeout > 0 {
+		config.Timeout = time.Duration(timeout) * time.Second
+	}
+
+	return config, nil
+}
+
+func (p *SlackProvider) setConfig(config SlackConfig) error {
+	if config.WebhookURL == "" && config.BotToken == "" {
+		return fmt.Errorf("slack webhook url or bot token is required")
+	}
+	if config.APIURL == "" {
+		config.APIURL = defaultSlackAPIURL
+	}
+	config.APIURL = strings.TrimRight(config.APIURL, "/")
+	if config.Timeout == 0 {
+		config.Timeout = defaultChatTimeout
+	}
+
+	p.config = config
+	p.client = &http.Client{Timeout: config.Timeout}
+	return nil
+}
+
+// SendNotification posts the message to Slack. Content that is a JSON Slack
+// message, e.g. with blocks or attachments, is posted as given; other content
+// is sent as text under the request's title.
+func (p *SlackProvider) SendNotification(ctx context.Context, req dtos.SendNotificationRequest) dtos.SendNotificationResponse {
+	if p.client == nil {
+		return dtos.SendNotificationResponse{Success: false, Message: "Client not initialized"}
+	}
+
+	if !strings.EqualFold(req.Channel, string(tenantDomain.ChannelTypeChat)) {
+		return dtos.SendNotificationResponse{Success: false, Message: "Unsupported notification channel"}
+	}
+
+	message := p.buildMessage(req)
+
+	var messageID string
+	var err error
+	if p.config.BotToken != "" {
+		messageID, err = p.postMessage(ctx, message)
+	} else {
+		err = p.postWebhook(ctx, message)
+	}
+	if err != nil {
+		return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
+	}
+	return dtos.SendNotificationResponse{Success: true, Message: "Message posted to Slack", MessageID: messageID}
+}
+
+func (p *SlackProvider) buildMessage(req dtos.SendNotificationRequest) map[string]interface{} {
+	title := req.Title
+	if title == "" {
+		title = req.Subject
+	}
+
+	message, ok := decodeChatContent(req.Content)
+	if !ok {
+		message = map[string]interface{}{"text": chatText(title, req.Content, "*")}
+	}
+	// Slack shows the text in notifications when the message is made of blocks
+	if _, ok := message["text"]; !ok && title != "" {
+		message["text"] = title
+	}
+
+	for key, value := range map[string]string{
+		"channel":    p.config.Channel,
+		"username":   p.config.Username,
+		"icon_emoji": p.config.IconEmoji,
+		"icon_url":   p.config.IconURL,
+	} {
+		if _, ok := message[key]; !ok && value != "" {
+			message[key] = value
+		}
+	}
+	return message
+}
+
+// postWebhook posts to the incoming webhook, which answers "ok" or a plain-text error
+func (p *SlackProvider) postWebhook(ctx context.Context, message map[string]interface{}) error {
+	resp, err := p.post(ctx, p.config.WebhookURL, "", message)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	return slackStatusError(resp, strings.TrimSpace(string(body)))
+}
+
+// postMessage calls chat.postMessage and returns the channel and timestamp
+// Slack identifies the message by
+func (p *SlackProvider) postMessage(ctx context.Context, message map[string]interface{}) (string, error) {
+	if _, ok := message["channel"]; !ok {
+		return "", fmt.Errorf("slack channel is required to post with a bot token")
+	}
+
+	resp, err := p.post(ctx, p.config.APIURL+"/chat.postMessage", p.config.BotToken, message)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		OK      bool   `json:"ok"`
+		Error   string `json:"error"`
+		Channel string `json:"channel"`
+		TS      string `json:"ts"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&result); err != nil || resp.StatusCode != http.StatusOK {
+		return "", slackStatusError(resp, result.Error)
+	}
+	if !result.OK {
+		return "", fmt.Errorf("slack returned %s", result.Error)
+	}
+	return result.Channel + ":" + result.TS, nil
+}
+
+func (p *SlackProvider) post(ctx context.Context, url, token string, message map[string]interface{}) (*http.Response, error) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal slack message: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create slack request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("slack request failed: %w", err)
+	}
+	return resp, nil
+}
+
+func slackStatusError(resp *http.Response, detail string) error {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("slack rate limited the request; retry after %s seconds", resp.Header.Get("Retry-After"))
+	}
+	if detail == "" {
+		return fmt.Errorf("slack returned status %d", resp.StatusCode)
+	}
+	return fmt.Errorf("slack returned %d %s", resp.StatusCode, detail)
+}
@@ -0,0 +1,413 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"getnoti.com/internal/providers/dtos"
+)
+
+// SMTP encryption modes
+const (
+	SMTPEncryptionNone     = "none"
+	SMTPEncryptionSTARTTLS = "starttls"
+	SMTPEncryptionTLS      = "tls"
+)
+
+// SMTP authentication mechanisms
+const (
+	SMTPAuthPlain = "plain"
+	SMTPAuthLogin = "login"
+)
+
+const defaultSMTPTimeout = 30 * time.Second
+
+// SMTPConfig holds the connection settings for an SMTP server
+type SMTPConfig struct {
+	Host               string
+	Port               int
+	Username           string
+	Password           string
+	From               string
+	FromName           string
+	Encryption         string
+	AuthMethod         string
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+}
+
+type SMTPProvider struct {
+	config SMTPConfig
+}
+
+// NewSMTPProvider creates a new SMTP provider from the given config
+func NewSMTPProvider(config SMTPConfig) (*SMTPProvider, error) {
+	p := &SMTPProvider{}
+	if err := p.setConfig(config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CreateClient configures the provider from a credentials map
+func (p *SMTPProvider) CreateClient(ctx context.Context, credentials map[string]interface{}) error {
+	config, err := ParseSMTPConfig(credentials)
+	if err != nil {
+		return err
+	}
+	return p.setConfig(config)
+}
+
+// ParseSMTPConfig builds an SMTPConfig from stored credentials
+func ParseSMTPConfig(credentials map[string]interface{}) (SMTPConfig, error) {
+	config := SMTPConfig{}
+
+	host, ok := credentials["host"].(string)
+	if !ok || host == "" {
+		return config, fmt.Errorf("invalid host in credentials")
+	}
+	config.Host = host
+
+	switch port := credentials["port"].(type) {
+	case float64:
+		config.Port = int(port)
+	case int:
+		config.Port = port
+	case string:
+		parsed, err := strconv.Atoi(port)
+		if err != nil {
+			return config, fmt.Errorf("invalid port in credentials")
+		}
+		config.Port = parsed
+	case nil:
+	default:
+		return config, fmt.Errorf("invalid port in credentials")
+	}
+
+	from, ok := credentials["from"].(string)
+	if !ok || from == "" {
+		return config, fmt.Errorf("invalid from in credentials")
+	}
+	config.From = from
+
+	config.FromName, _ = credentials["from_name"].(string)
+	config.Username, _ = credentials["username"].(string)
+	config.Password, _ = credentials["password"].(string)
+	config.Encryption, _ = credentials["encryption"].(string)
+	config.AuthMethod, _ = credentials["auth_method"].(string)
+	config.InsecureSkipVerify, _ = credentials["insecure_skip_verify"].(bool)
+
+	if timeout, ok := credentials["timeout_seconds"].(float64); ok && timeout > 0 {
+		config.Timeout = time.Duration(timeout) * time.Second
+	}
+
+	return config, nil
+}
+
+func (p *SMTPProvider) setConfig(config SMTPConfig) error {
+	if config.Host == "" {
+		return fmt.Errorf("smtp host is required")
+	}
+	if config.From == "" {
+		return fmt.Errorf("smtp from address is required")
+	}
+
+	config.Encryption = strings.ToLower(config.Encryption)
+	switch config.Encryption {
+	case "":
+		config.Encryption = SMTPEncryptionSTARTTLS
+	case SMTPEncryptionNone, SMTPEncryptionSTARTTLS, SMTPEncryptionTLS:
+	default:
+		return fmt.Errorf("unsupported smtp encryption: %s", config.Encryption)
+	}
+
+	config.AuthMethod = strings.ToLower(config.AuthMethod)
+	switch config.AuthMethod {
+	case "":
+		config.AuthMethod = SMTPAuthPlain
+	case SMTPAuthPlain, SMTPAuthLogin:
+	default:
+		return fmt.Errorf("unsupported smtp auth method: %s", config.AuthMethod)
+	}
+
+	if config.Port == 0 {
+		switch config.Encryption {
+		case SMTPEncryptionTLS:
+			config.Port = 465
+		case SMTPEncryptionSTARTTLS:
+			config.Port = 587
+		default:
+			config.Port = 25
+		}
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = defaultSMTPTimeout
+	}
+
+	p.config = config
+	return nil
+}
+
+func (p *SMTPProvider) SendNotification(ctx context.Context, req dtos.SendNotificationRequest) dtos.SendNotificationResponse {
+	if p.config.Host == "" {
+		return dtos.SendNotificationResponse{Success: false, Message: "Client not initialized"}
+	}
+
+	if !strings.EqualFold(req.Channel, "email") {
+		return dtos.SendNotificationResponse{Success: false, Message: "Unsupported notification channel"}
+	}
+
+	if req.Receiver == "" {
+		return dtos.SendNotificationResponse{Success: false, Message: "Receiver is required"}
+	}
+
+	messageID, err := p.send(ctx, req)
+	if err != nil {
+		return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
+	}
+	return dtos.SendNotificationResponse{Success: true, Message: messageID, MessageID: messageID}
+}
+
+// send delivers a single message and returns its Message-ID
+func (p *SMTPProvider) send(ctx context.Context, req dtos.SendNotificationRequest) (string, error) {
+	messageID, err := p.newMessageID()
+	if err != nil {
+		return "", err
+	}
+
+	msg, err := p.buildMessage(req, messageID)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := p.dial(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	if p.config.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return "", fmt.Errorf("smtp server does not support authentication")
+		}
+		if err := client.Auth(p.auth()); err != nil {
+			return "", fmt.Errorf("smtp authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(p.config.From); err != nil {
+		return "", fmt.Errorf("smtp MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(req.Receiver); err != nil {
+		return "", fmt.Errorf("smtp RCPT TO failed: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return "", fmt.Errorf("smtp DATA failed: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		w.Close()
+		return "", fmt.Errorf("failed to write message body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("smtp server rejected message: %w", err)
+	}
+
+	if err := client.Quit(); err != nil {
+		return "", fmt.Errorf("smtp QUIT failed: %w", err)
+	}
+
+	return messageID, nil
+}
+
+// dial opens a connection to the SMTP server and negotiates TLS as configured
+func (p *SMTPProvider) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(p.config.Host, strconv.Itoa(p.config.Port))
+	dialer := &net.Dialer{Timeout: p.config.Timeout}
+	tlsConfig := &tls.Config{
+		ServerName:         p.config.Host,
+		InsecureSkipVerify: p.config.InsecureSkipVerify,
+	}
+
+	var conn net.Conn
+	var err error
+	if p.config.Encryption == SMTPEncryptionTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+
+	deadline := time.Now().Add(p.config.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, p.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create smtp client: %w", err)
+	}
+
+	if p.config.Encryption == SMTPEncryptionSTARTTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("smtp server does not support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("smtp STARTTLS failed: %w", err)
+		}
+	}
+
+	return client, nil
+}
+
+func (p *SMTPProvider) auth() smtp.Auth {
+	if p.config.AuthMethod == SMTPAuthLogin {
+		return &loginAuth{username: p.config.Username, password: p.config.Password}
+	}
+	return smtp.PlainAuth("", p.config.Username, p.config.Password, p.config.Host)
+}
+
+// buildMessage renders the RFC 5322 message, using multipart/alternative when an HTML body is present
+func (p *SMTPProvider) buildMessage(req dtos.SendNotificationRequest, messageID string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	from := p.config.From
+	if p.config.FromName != "" {
+		from = fmt.Sprintf("%s <%s>", mime.QEncoding.Encode("utf-8", p.config.FromName), p.config.From)
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", from)
+	header.Set("To", req.Receiver)
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", req.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("Message-ID", messageID)
+	header.Set("MIME-Version", "1.0")
+
+	if req.HTMLContent == "" {
+		header.Set("Content-Type", "text/plain; charset=UTF-8")
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		writeHeader(&buf, header)
+		if err := writeQuotedPrintable(&buf, req.Content); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", req.Content},
+		{"text/html; charset=UTF-8", req.HTMLContent},
+	}
+	for _, part := range parts {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create mime part: %w", err)
+		}
+		if err := writeQuotedPrintable(pw, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	header.Set("Content-Type", "multipart/alternative; boundary="+mw.Boundary())
+	writeHeader(&buf, header)
+	buf.Write(body.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+func (p *SMTPProvider) newMessageID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate message id: %w", err)
+	}
+
+	domain := p.config.Host
+	if at := strings.LastIndex(p.config.From, "@"); at != -1 {
+		domain = p.config.From[at+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
+
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	keys := []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"}
+	for _, key := range keys {
+		if value := header.Get(key); value != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, value)
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qw := quotedprintable.NewWriter(w)
+	if _, err := qw.Write([]byte(content)); err != nil {
+		return fmt.Errorf("failed to encode message body: %w", err)
+	}
+	return qw.Close()
+}
+
+// loginAuth implements the LOGIN authentication mechanism, which net/smtp does not provide
+type loginAuth struct {
+	username string
+	password string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(string(fromServer))) {
+	case "username:":
+		return []byte(a.username), nil
+	case "password:":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected server challenge: %s", fromServer)
+	}
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
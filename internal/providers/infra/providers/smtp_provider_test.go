@@ -0,0 +1,340 @@
+package providers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"getnoti.com/internal/providers/dtos"
+)
+
+// smtpSink is an in-process SMTP server that records the messages it accepts
+type smtpSink struct {
+	t         *testing.T
+	ln        net.Listener
+	tlsConfig *tls.Config
+	// implicitTLS wraps every connection in TLS, as on port 465
+	implicitTLS bool
+	// rejectRcpt makes RCPT TO fail for this address
+	rejectRcpt string
+
+	mu       sync.Mutex
+	messages []sinkMessage
+}
+
+type sinkMessage struct {
+	from     string
+	to       []string
+	username string
+	password string
+	tls      bool
+	data     string
+}
+
+func newSMTPSink(t *testing.T, configure func(*smtpSink)) *smtpSink {
+	t.Helper()
+
+	s := &smtpSink{t: t, tlsConfig: selfSignedTLSConfig(t)}
+	if configure != nil {
+		configure(s)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	if s.implicitTLS {
+		ln = tls.NewListener(ln, s.tlsConfig)
+	}
+	s.ln = ln
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpSink) config() SMTPConfig {
+	host, port, _ := net.SplitHostPort(s.ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return SMTPConfig{
+		Host:               host,
+		Port:               p,
+		From:               "alerts@example.com",
+		Encryption:         SMTPEncryptionNone,
+		InsecureSkipVerify: true,
+		Timeout:            5 * time.Second,
+	}
+}
+
+func (s *smtpSink) received() []sinkMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]sinkMessage(nil), s.messages...)
+}
+
+func (s *smtpSink) serve(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	_, isTLS := conn.(*tls.Conn)
+	tp := textproto.NewConn(conn)
+	msg := sinkMessage{tls: isTLS}
+
+	tp.PrintfLine("220 sink ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250-sink")
+			if !msg.tls {
+				tp.PrintfLine("250-STARTTLS")
+			}
+			tp.PrintfLine("250 AUTH PLAIN LOGIN")
+		case "STARTTLS":
+			tp.PrintfLine("220 ready")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			tp = textproto.NewConn(conn)
+			msg.tls = true
+		case "AUTH":
+			mechanism, initial, _ := strings.Cut(arg, " ")
+			switch strings.ToUpper(mechanism) {
+			case "PLAIN":
+				decoded, _ := base64.StdEncoding.DecodeString(initial)
+				parts := strings.Split(string(decoded), "\x00")
+				if len(parts) == 3 {
+					msg.username, msg.password = parts[1], parts[2]
+				}
+			case "LOGIN":
+				tp.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte("Username:")))
+				username, _ := tp.ReadLine()
+				tp.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte("Password:")))
+				password, _ := tp.ReadLine()
+				u, _ := base64.StdEncoding.DecodeString(username)
+				p, _ := base64.StdEncoding.DecodeString(password)
+				msg.username, msg.password = string(u), string(p)
+			}
+			tp.PrintfLine("235 authenticated")
+		case "MAIL":
+			msg.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			tp.PrintfLine("250 ok")
+		case "RCPT":
+			rcpt := strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			if rcpt == s.rejectRcpt {
+				tp.PrintfLine("550 no such user")
+				continue
+			}
+			msg.to = append(msg.to, rcpt)
+			tp.PrintfLine("250 ok")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			tp.PrintfLine("250 queued")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func selfSignedTLSConfig(t *testing.T) *tls.Config {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+}
+
+func sendTestEmail(t *testing.T, config SMTPConfig, req dtos.SendNotificationRequest) dtos.SendNotificationResponse {
+	t.Helper()
+
+	provider, err := NewSMTPProvider(config)
+	if err != nil {
+		t.Fatalf("NewSMTPProvider: %v", err)
+	}
+	return provider.SendNotification(context.Background(), req)
+}
+
+func TestSMTPProviderSendsPlainText(t *testing.T) {
+	sink := newSMTPSink(t, nil)
+
+	res := sendTestEmail(t, sink.config(), dtos.SendNotificationRequest{
+		Channel:  "email",
+		Receiver: "user@example.com",
+		Subject:  "Héllo",
+		Content:  "Your code is 1234",
+	})
+	if !res.Success {
+		t.Fatalf("send failed: %s", res.Message)
+	}
+
+	messages := sink.received()
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+	msg := messages[0]
+	if msg.from != "alerts@example.com" || len(msg.to) != 1 || msg.to[0] != "user@example.com" {
+		t.Errorf("envelope = %q -> %v", msg.from, msg.to)
+	}
+	if !strings.Contains(msg.data, "Message-ID: "+res.MessageID) {
+		t.Errorf("message id %q not in headers:\n%s", res.MessageID, msg.data)
+	}
+	if !strings.Contains(msg.data, "Subject: =?utf-8?q?H=C3=A9llo?=") {
+		t.Errorf("subject not encoded:\n%s", msg.data)
+	}
+	if !strings.Contains(msg.data, "Content-Type: text/plain; charset=UTF-8") || !strings.Contains(msg.data, "Your code is 1234") {
+		t.Errorf("unexpected body:\n%s", msg.data)
+	}
+}
+
+func TestSMTPProviderSendsMultipartWithHTML(t *testing.T) {
+	sink := newSMTPSink(t, nil)
+
+	res := sendTestEmail(t, sink.config(), dtos.SendNotificationRequest{
+		Channel:     "email",
+		Receiver:    "user@example.com",
+		Subject:     "Welcome",
+		Content:     "Welcome aboard",
+		HTMLContent: "<p>Welcome aboard</p>",
+	})
+	if !res.Success {
+		t.Fatalf("send failed: %s", res.Message)
+	}
+
+	data := sink.received()[0].data
+	for _, want := range []string{"multipart/alternative; boundary=", "text/plain; charset=UTF-8", "text/html; charset=UTF-8", "<p>Welcome aboard</p>"} {
+		if !strings.Contains(data, want) {
+			t.Errorf("message missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestSMTPProviderEncryptionAndAuth(t *testing.T) {
+	tests := []struct {
+		name        string
+		implicitTLS bool
+		encryption  string
+		authMethod  string
+	}{
+		{name: "starttls plain", encryption: SMTPEncryptionSTARTTLS, authMethod: SMTPAuthPlain},
+		{name: "starttls login", encryption: SMTPEncryptionSTARTTLS, authMethod: SMTPAuthLogin},
+		{name: "implicit tls", implicitTLS: true, encryption: SMTPEncryptionTLS, authMethod: SMTPAuthPlain},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := newSMTPSink(t, func(s *smtpSink) { s.implicitTLS = tt.implicitTLS })
+
+			config := sink.config()
+			config.Encryption = tt.encryption
+			config.AuthMethod = tt.authMethod
+			config.Username = "mailer"
+			config.Password = "s3cret"
+
+			res := sendTestEmail(t, config, dtos.SendNotificationRequest{Channel: "email", Receiver: "user@example.com", Content: "hi"})
+			if !res.Success {
+				t.Fatalf("send failed: %s", res.Message)
+			}
+
+			msg := sink.received()[0]
+			if !msg.tls {
+				t.Error("message was sent without TLS")
+			}
+			if msg.username != "mailer" || msg.password != "s3cret" {
+				t.Errorf("auth = %q/%q, want mailer/s3cret", msg.username, msg.password)
+			}
+		})
+	}
+}
+
+func TestSMTPProviderReportsRejectedRecipient(t *testing.T) {
+	sink := newSMTPSink(t, func(s *smtpSink) { s.rejectRcpt = "gone@example.com" })
+
+	res := sendTestEmail(t, sink.config(), dtos.SendNotificationRequest{Channel: "email", Receiver: "gone@example.com", Content: "hi"})
+	if res.Success {
+		t.Fatal("send succeeded for a rejected recipient")
+	}
+	if !strings.Contains(res.Message, "RCPT TO") {
+		t.Errorf("message = %q, want RCPT TO failure", res.Message)
+	}
+	if n := len(sink.received()); n != 0 {
+		t.Errorf("sink accepted %d messages, want 0", n)
+	}
+}
+
+func TestSMTPProviderRejectsOtherChannels(t *testing.T) {
+	sink := newSMTPSink(t, nil)
+
+	res := sendTestEmail(t, sink.config(), dtos.SendNotificationRequest{Channel: "sms", Receiver: "user@example.com", Content: "hi"})
+	if res.Success || res.Message != "Unsupported notification channel" {
+		t.Errorf("got %+v, want unsupported channel", res)
+	}
+}
+
+func TestParseSMTPConfig(t *testing.T) {
+	config, err := ParseSMTPConfig(map[string]interface{}{
+		"host":            "smtp.example.com",
+		"port":            "2525",
+		"from":            "alerts@example.com",
+		"timeout_seconds": float64(10),
+	})
+	if err != nil {
+		t.Fatalf("ParseSMTPConfig: %v", err)
+	}
+	if config.Port != 2525 || config.Timeout != 10*time.Second {
+		t.Errorf("config = %+v", config)
+	}
+
+	if _, err := ParseSMTPConfig(map[string]interface{}{"from": "alerts@example.com"}); err == nil {
+		t.Error("expected an error for a missing host")
+	}
+}
@@ -0,0 +1,174 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"getnoti.com/internal/providers/dtos"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+)
+
+const adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+
+// TeamsConfig holds a Microsoft Teams incoming webhook, either a connector
+// webhook or a Workflows webhook
+type TeamsConfig struct {
+	WebhookURL string
+	Timeout    time.Duration
+}
+
+// TeamsProvider sends Adaptive Card messages to a Microsoft Teams channel
+type TeamsProvider struct {
+	config TeamsConfig
+	client *http.Client
+}
+
+// NewTeamsProvider creates a new Teams provider from the given config
+func NewTeamsProvider(config TeamsConfig) (*TeamsProvider, error) {
+	p := &TeamsProvider{}
+	if err := p.setConfig(config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CreateClient configures the provider from a credentials map
+func (p *TeamsProvider) CreateClient(ctx context.Context, credentials map[string]interface{}) error {
+	config, err := ParseTeamsConfig(credentials)
+	if err != nil {
+		return err
+	}
+	return p.setConfig(config)
+}
+
+// ParseTeamsConfig builds a TeamsConfig from stored credentials
+func ParseTeamsConfig(credentials map[string]interface{}) (TeamsConfig, error) {
+	config := TeamsConfig{}
+
+	webhookURL, ok := credentials["webhook_url"].(string)
+	if !ok || webhookURL == "" {
+		return config, fmt.Errorf("invalid webhook_url in credentials")
+	}
+	config.WebhookURL = webhookURL
+
+	if timeout, ok := credentials["timeout_seconds"].(float64); ok && timeout > 0 {
+		config.Timeout = time.Duration(timeout) * time.Second
+	}
+
+	return config, nil
+}
+
+func (p *TeamsProvider) setConfig(config TeamsConfig) error {
+	if config.WebhookURL == "" {
+		return fmt.Errorf("teams webhook url is required")
+	}
+	if config.Timeout == 0 {
+		config.Timeout = defaultChatTimeout
+	}
+
+	p.config = config
+	p.client = &http.Client{Timeout: config.Timeout}
+	return nil
+}
+
+// SendNotification posts the message to the Teams webhook. Content that is a
+// JSON Adaptive Card is sent as the card, and content that is already a
+// message with attachments is sent as given; other content is put in a card
+// under the request's title.
+func (p *TeamsProvider) SendNotification(ctx context.Context, req dtos.SendNotificationRequest) dtos.SendNotificationResponse {
+	if p.client == nil {
+		return dtos.SendNotificationResponse{Success: false, Message: "Client not initialized"}
+	}
+
+	if !strings.EqualFold(req.Channel, string(tenantDomain.ChannelTypeChat)) {
+		return dtos.SendNotificationResponse{Success: false, Message: "Unsupported notification channel"}
+	}
+
+	body, err := json.Marshal(p.buildMessage(req))
+	if err != nil {
+		return dtos.SendNotificationResponse{Success: false, Message: fmt.Sprintf("failed to marshal teams message: %v", err)}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return dtos.SendNotificationResponse{Success: false, Message: fmt.Sprintf("failed to create teams request: %v", err)}
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return dtos.SendNotificationResponse{Success: false, Message: fmt.Sprintf("teams request failed: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	// Connector webhooks answer 200 and Workflows webhooks 202
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return dtos.SendNotificationResponse{Success: true, Message: "Message posted to Teams"}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return dtos.SendNotificationResponse{Success: false, Message: fmt.Sprintf("teams rate limited the request; retry after %s seconds", resp.Header.Get("Retry-After"))}
+	}
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	return dtos.SendNotificationResponse{Success: false, Message: fmt.Sprintf("teams returned %d %s", resp.StatusCode, strings.TrimSpace(string(respBody)))}
+}
+
+func (p *TeamsProvider) buildMessage(req dtos.SendNotificationRequest) map[string]interface{} {
+	content, ok := decodeChatContent(req.Content)
+	if ok {
+		if _, ok := content["attachments"]; ok {
+			return content
+		}
+		if cardType, _ := content["type"].(string); cardType == "AdaptiveCard" {
+			return adaptiveCardMessage(content)
+		}
+	}
+
+	title := req.Title
+	if title == "" {
+		title = req.Subject
+	}
+
+	var body []interface{}
+	if title != "" {
+		body = append(body, map[string]interface{}{
+			"type":   "TextBlock",
+			"text":   title,
+			"weight": "Bolder",
+			"size":   "Medium",
+			"wrap":   true,
+		})
+	}
+	body = append(body, map[string]interface{}{
+		"type": "TextBlock",
+		"text": req.Content,
+		"wrap": true,
+	})
+
+	return adaptiveCardMessage(map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	})
+}
+
+// adaptiveCardMessage wraps a card in the message envelope Teams webhooks accept
+func adaptiveCardMessage(card map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": adaptiveCardContentType,
+				"contentUrl":  nil,
+				"content":     card,
+			},
+		},
+	}
+}
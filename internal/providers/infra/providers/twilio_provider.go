@@ -1,75 +1,92 @@
 package providers
 
 import (
-    "context"
-    "fmt"
-    "getnoti.com/internal/providers/dtos"
-    "github.com/twilio/twilio-go"
-    twilioApi "github.com/twilio/twilio-go/rest/api/v2010"
+	"context"
+	"fmt"
+	"strings"
+
+	"getnoti.com/internal/providers/dtos"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	"github.com/twilio/twilio-go"
+	twilioApi "github.com/twilio/twilio-go/rest/api/v2010"
 )
 
 type TwilioProvider struct {
-    client *twilio.RestClient
+	client *twilio.RestClient
 }
 
 func NewTwilioProvider(accountSid, authToken string) *TwilioProvider {
-    client := twilio.NewRestClientWithParams(twilio.ClientParams{
-        Username: accountSid,
-        Password: authToken,
-    })
-    return &TwilioProvider{
-        client: client,
-    }
+	client := twilio.NewRestClientWithParams(twilio.ClientParams{
+		Username: accountSid,
+		Password: authToken,
+	})
+	return &TwilioProvider{
+		client: client,
+	}
 }
 
 func (p *TwilioProvider) CreateClient(ctx context.Context, credentials map[string]interface{}) error {
-    accountSid, ok := credentials["account_sid"].(string)
-    if !ok {
-        return fmt.Errorf("invalid account_sid in credentials")
-    }
-    authToken, ok := credentials["auth_token"].(string)
-    if !ok {
-        return fmt.Errorf("invalid auth_token in credentials")
-    }
+	accountSid, ok := credentials["account_sid"].(string)
+	if !ok {
+		return fmt.Errorf("invalid account_sid in credentials")
+	}
+	authToken, ok := credentials["auth_token"].(string)
+	if !ok {
+		return fmt.Errorf("invalid auth_token in credentials")
+	}
 
-    p.client = twilio.NewRestClientWithParams(twilio.ClientParams{
-        Username: accountSid,
-        Password: authToken,
-    })
-    return nil
+	p.client = twilio.NewRestClientWithParams(twilio.ClientParams{
+		Username: accountSid,
+		Password: authToken,
+	})
+	return nil
 }
 
 func (p *TwilioProvider) SendNotification(ctx context.Context, req dtos.SendNotificationRequest) dtos.SendNotificationResponse {
-    if p.client == nil {
-        return dtos.SendNotificationResponse{Success: false, Message: "Client not initialized"}
-    }
+	if p.client == nil {
+		return dtos.SendNotificationResponse{Success: false, Message: "Client not initialized"}
+	}
+
+	switch twilioChannel(req.Channel) {
+	case "SMS":
+		params := &twilioApi.CreateMessageParams{}
+		params.SetTo(req.Receiver)
+		params.SetFrom(req.Sender)
+		params.SetBody(req.Content)
+
+		resp, err := p.client.Api.CreateMessage(params)
+		if err != nil {
+			return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
+		}
+		return dtos.SendNotificationResponse{Success: true, Message: *resp.Sid, MessageID: *resp.Sid}
 
-    switch req.Channel {
-    case "SMS":
-        params := &twilioApi.CreateMessageParams{}
-        params.SetTo(req.Receiver)
-        params.SetFrom(req.Sender)
-        params.SetBody(req.Content)
-        
-        resp, err := p.client.Api.CreateMessage(params)
-        if err != nil {
-            return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
-        }
-        return dtos.SendNotificationResponse{Success: true, Message: *resp.Sid}
+	case "Call":
+		params := &twilioApi.CreateCallParams{}
+		params.SetTo(req.Receiver)
+		params.SetFrom(req.Sender)
+		params.SetUrl("http://demo.twilio.com/docs/voice.xml")
 
-    case "Call":
-        params := &twilioApi.CreateCallParams{}
-        params.SetTo(req.Receiver)
-        params.SetFrom(req.Sender)
-        params.SetUrl("http://demo.twilio.com/docs/voice.xml")
-        
-        resp, err := p.client.Api.CreateCall(params)
-        if err != nil {
-            return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
-        }
-        return dtos.SendNotificationResponse{Success: true, Message: *resp.Sid}
+		resp, err := p.client.Api.CreateCall(params)
+		if err != nil {
+			return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
+		}
+		return dtos.SendNotificationResponse{Success: true, Message: *resp.Sid, MessageID: *resp.Sid}
+
+	default:
+		return dtos.SendNotificationResponse{Success: false, Message: "Unsupported notification channel"}
+	}
+}
 
-    default:
-        return dtos.SendNotificationResponse{Success: false, Message: "Unsupported notification channel"}
-    }
+// twilioChannel maps a notification channel to the Twilio API it is sent with.
+// Sends arrive with lowercase channel names, e.g. "sms"; "SMS" and "Call" are
+// still accepted as given.
+func twilioChannel(channel string) string {
+	switch strings.ToLower(channel) {
+	case string(tenantDomain.ChannelTypeSMS):
+		return "SMS"
+	case "call", "voice":
+		return "Call"
+	default:
+		return channel
+	}
 }
@@ -0,0 +1,508 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"getnoti.com/internal/providers/dtos"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	tenantRepos "getnoti.com/internal/tenants/repos"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	defaultWebPushTTL     = 24 * time.Hour
+	defaultWebPushTimeout = 30 * time.Second
+	// webPushRecordSize is the single aes128gcm record every payload is sent in
+	webPushRecordSize = 4096
+	// webPushMaxPayload leaves room in the record for the 86 byte header, the
+	// padding delimiter and the 16 byte tag
+	webPushMaxPayload = webPushRecordSize - 86 - 1 - 16
+	// webPushVAPIDLifetime keeps VAPID tokens within the 24 hour limit push services allow
+	webPushVAPIDLifetime = 12 * time.Hour
+)
+
+// Web Push urgencies, from RFC 8030
+const (
+	WebPushUrgencyVeryLow = "very-low"
+	WebPushUrgencyLow     = "low"
+	WebPushUrgencyNormal  = "normal"
+	WebPushUrgencyHigh    = "high"
+)
+
+// WebPushConfig holds a tenant's VAPID key pair and the defaults applied to
+// every message that does not set its own
+type WebPushConfig struct {
+	// PublicKey and PrivateKey are the base64url encoded uncompressed P-256
+	// public key and 32 byte private scalar; the public key is what browsers
+	// pass to PushManager.subscribe() as applicationServerKey
+	PublicKey  string
+	PrivateKey string
+	// Subject is the mailto: or https: contact push services can reach the sender at
+	Subject string
+	TTL     time.Duration
+	Urgency string
+	Timeout time.Duration
+}
+
+// WebPushProvider sends encrypted browser push messages (RFC 8291) signed
+// with the tenant's VAPID key (RFC 8292)
+type WebPushProvider struct {
+	config    WebPushConfig
+	key       *ecdsa.PrivateKey
+	publicKey []byte
+	client    *http.Client
+	// subscriptions holds the tenant's browser subscriptions; a nil repository
+	// limits the provider to receivers given as subscription JSON
+	subscriptions tenantRepos.WebPushSubscriptionRepository
+}
+
+// NewWebPushProvider creates a new Web Push provider from the given config
+func NewWebPushProvider(config WebPushConfig, subscriptions tenantRepos.WebPushSubscriptionRepository) (*WebPushProvider, error) {
+	p := &WebPushProvider{subscriptions: subscriptions}
+	if err := p.setConfig(config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// CreateClient configures the provider from a credentials map
+func (p *WebPushProvider) CreateClient(ctx context.Context, credentials map[string]interface{}) error {
+	config, err := ParseWebPushConfig(credentials)
+	if err != nil {
+		return err
+	}
+	return p.setConfig(config)
+}
+
+// ParseWebPushConfig builds a WebPushConfig from stored credentials
+func ParseWebPushConfig(credentials map[string]interface{}) (WebPushConfig, error) {
+	config := WebPushConfig{}
+
+	privateKey, ok := credentials["vapid_private_key"].(string)
+	if !ok || privateKey == "" {
+		return config, fmt.Errorf("invalid vapid_private_key in credentials")
+	}
+	config.PrivateKey = privateKey
+
+	subject, ok := credentials["subject"].(string)
+	if !ok || subject == "" {
+		return config, fmt.Errorf("invalid subject in credentials")
+	}
+	config.Subject = subject
+
+	config.PublicKey, _ = credentials["vapid_public_key"].(string)
+	config.Urgency, _ = credentials["urgency"].(string)
+
+	if ttl, ok := credentials["ttl_seconds"].(float64); ok && ttl >= 0 {
+		config.TTL = time.Duration(ttl) * time.Second
+	}
+	if timeout, ok := credentials["timeout_seconds"].(float64); ok && timeout > 0 {
+		config.Timeout = time.Duration(timeout) * time.Second
+	}
+
+	return config, nil
+}
+
+func (p *WebPushProvider) setConfig(config WebPushConfig) error {
+	if !strings.HasPrefix(config.Subject, "mailto:") && !strings.HasPrefix(config.Subject, "https:") {
+		return fmt.Errorf("web push subject must be a mailto: or https: URL")
+	}
+
+	key, publicKey, err := parseVAPIDPrivateKey(config.PrivateKey)
+	if err != nil {
+		return err
+	}
+	if config.PublicKey != "" {
+		configured, err := tenantDomain.DecodeWebPushKey(config.PublicKey)
+		if err != nil || !bytes.Equal(configured, publicKey) {
+			return fmt.Errorf("vapid public key does not match the private key")
+		}
+	}
+	config.PublicKey = base64.RawURLEncoding.EncodeToString(publicKey)
+
+	if config.TTL == 0 {
+		config.TTL = defaultWebPushTTL
+	}
+	if config.Urgency == "" {
+		config.Urgency = WebPushUrgencyNormal
+	}
+	if !validWebPushUrgency(config.Urgency) {
+		return fmt.Errorf("invalid web push urgency: %s", config.Urgency)
+	}
+	if config.Timeout == 0 {
+		config.Timeout = defaultWebPushTimeout
+	}
+
+	p.config = config
+	p.key = key
+	p.publicKey = publicKey
+	p.client = &http.Client{Timeout: config.Timeout}
+	return nil
+}
+
+// parseVAPIDPrivateKey reads a base64url encoded P-256 private scalar and
+// returns the signing key with its uncompressed public key
+func parseVAPIDPrivateKey(encoded string) (*ecdsa.PrivateKey, []byte, error) {
+	raw, err := tenantDomain.DecodeWebPushKey(encoded)
+	if err != nil || len(raw) != 32 {
+		return nil, nil, fmt.Errorf("vapid private key must be a base64url encoded 32 byte P-256 key")
+	}
+	ecdhKey, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+
+	publicKey := ecdhKey.PublicKey().Bytes()
+	key := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(publicKey[1:33]),
+			Y:     new(big.Int).SetBytes(publicKey[33:]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}
+	return key, publicKey, nil
+}
+
+func validWebPushUrgency(urgency string) bool {
+	switch urgency {
+	case WebPushUrgencyVeryLow, WebPushUrgencyLow, WebPushUrgencyNormal, WebPushUrgencyHigh:
+		return true
+	}
+	return false
+}
+
+// SendNotification pushes the message to every browser the receiver subscribed
+// from. The receiver is a user ID, or a single subscription as JSON. Endpoints
+// the push service reports as gone are removed and returned as invalid tokens.
+func (p *WebPushProvider) SendNotification(ctx context.Context, req dtos.SendNotificationRequest) dtos.SendNotificationResponse {
+	if p.key == nil {
+		return dtos.SendNotificationResponse{Success: false, Message: "Client not initialized"}
+	}
+
+	if !strings.EqualFold(req.Channel, string(tenantDomain.ChannelTypeWebPush)) {
+		return dtos.SendNotificationResponse{Success: false, Message: "Unsupported notification channel"}
+	}
+
+	if req.Receiver == "" {
+		return dtos.SendNotificationResponse{Success: false, Message: "Receiver is required"}
+	}
+
+	subscriptions, err := p.resolveSubscriptions(ctx, req.Receiver)
+	if err != nil {
+		return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
+	}
+	if len(subscriptions) == 0 {
+		return dtos.SendNotificationResponse{Success: false, Message: "Receiver has no web push subscriptions"}
+	}
+
+	message, err := p.buildMessage(req)
+	if err != nil {
+		return dtos.SendNotificationResponse{Success: false, Message: err.Error()}
+	}
+
+	var messageIDs, invalid, failures []string
+	for _, subscription := range subscriptions {
+		messageID, err := p.send(ctx, subscription, message)
+		if errors.Is(err, ErrUnregisteredToken) {
+			invalid = append(invalid, subscription.Endpoint)
+			if p.subscriptions != nil {
+				if err := p.subscriptions.DeleteWebPushSubscriptionByEndpoint(ctx, subscription.Endpoint); err != nil {
+					failures = append(failures, err.Error())
+				}
+			}
+			continue
+		}
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		messageIDs = append(messageIDs, messageID)
+	}
+
+	if len(messageIDs) == 0 {
+		message := "no web push subscription accepted the message"
+		if len(failures) > 0 {
+			message = strings.Join(failures, "; ")
+		} else if len(invalid) > 0 {
+			message = ErrUnregisteredToken.Error()
+		}
+		return dtos.SendNotificationResponse{Success: false, Message: message, InvalidTokens: invalid}
+	}
+
+	return dtos.SendNotificationResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("delivered to %d of %d web push subscriptions", len(messageIDs), len(subscriptions)),
+		MessageID:     messageIDs[0],
+		InvalidTokens: invalid,
+	}
+}
+
+// resolveSubscriptions looks up the user's subscriptions, unless the receiver
+// is a subscription itself
+func (p *WebPushProvider) resolveSubscriptions(ctx context.Context, receiver string) ([]tenantDomain.WebPushSubscription, error) {
+	if strings.HasPrefix(strings.TrimSpace(receiver), "{") {
+		var subscription struct {
+			Endpoint string `json:"endpoint"`
+			Keys     struct {
+				P256dh string `json:"p256dh"`
+				Auth   string `json:"auth"`
+			} `json:"keys"`
+		}
+		if err := json.Unmarshal([]byte(receiver), &subscription); err != nil {
+			return nil, fmt.Errorf("invalid web push subscription: %w", err)
+		}
+		return []tenantDomain.WebPushSubscription{{
+			Endpoint: subscription.Endpoint,
+			P256dh:   subscription.Keys.P256dh,
+			Auth:     subscription.Keys.Auth,
+		}}, nil
+	}
+
+	if p.subscriptions == nil {
+		return nil, fmt.Errorf("web push subscriptions are not available")
+	}
+	subscriptions, err := p.subscriptions.GetWebPushSubscriptionsByUserID(ctx, receiver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get web push subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// webPushMessage is one push: the plaintext payload and the headers that go with it
+type webPushMessage struct {
+	payload []byte
+	ttl     time.Duration
+	urgency string
+	topic   string
+}
+
+// webPushContent is rendered content that spells out the push itself. Payload
+// is delivered to the service worker as is; when it is absent the whole
+// content is the payload.
+type webPushContent struct {
+	Payload    json.RawMessage `json:"payload"`
+	TTLSeconds *int64          `json:"ttl_seconds"`
+	Urgency    string          `json:"urgency"`
+	Topic      string          `json:"topic"`
+}
+
+// buildMessage sends content that is JSON to the service worker as given;
+// other content is sent as a title and body
+func (p *WebPushProvider) buildMessage(req dtos.SendNotificationRequest) (*webPushMessage, error) {
+	message := &webPushMessage{ttl: p.config.TTL, urgency: p.config.Urgency}
+
+	trimmed := strings.TrimSpace(req.Content)
+	if strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed)) {
+		var content webPushContent
+		_ = json.Unmarshal([]byte(trimmed), &content)
+		message.payload = []byte(trimmed)
+		if len(content.Payload) > 0 {
+			message.payload = content.Payload
+		}
+		if content.TTLSeconds != nil && *content.TTLSeconds >= 0 {
+			message.ttl = time.Duration(*content.TTLSeconds) * time.Second
+		}
+		if content.Urgency != "" {
+			message.urgency = content.Urgency
+		}
+		message.topic = content.Topic
+	} else {
+		title := req.Title
+		if title == "" {
+			title = req.Subject
+		}
+		payload, err := json.Marshal(map[string]string{"title": title, "body": req.Content})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal web push payload: %w", err)
+		}
+		message.payload = payload
+	}
+
+	if len(message.payload) > webPushMaxPayload {
+		return nil, fmt.Errorf("web push payload exceeds %d bytes", webPushMaxPayload)
+	}
+	if !validWebPushUrgency(message.urgency) {
+		return nil, fmt.Errorf("invalid web push urgency: %s", message.urgency)
+	}
+	// Topics are limited to 32 characters of the URL-safe base64 alphabet
+	if len(message.topic) > 32 || strings.Trim(message.topic, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_") != "" {
+		return nil, fmt.Errorf("web push topic must be at most 32 base64url characters")
+	}
+	return message, nil
+}
+
+// send encrypts the message for the subscription and posts it to the push
+// service, returning the message location the service assigned
+func (p *WebPushProvider) send(ctx context.Context, subscription tenantDomain.WebPushSubscription, message *webPushMessage) (string, error) {
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return "", fmt.Errorf("invalid web push endpoint: %s", subscription.Endpoint)
+	}
+
+	body, err := encryptWebPushPayload(message.payload, subscription)
+	if err != nil {
+		return "", err
+	}
+
+	authorization, err := p.vapidAuthorization(endpoint.Scheme + "://" + endpoint.Host)
+	if err != nil {
+		return "", err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create web push request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", authorization)
+	httpReq.Header.Set("Content-Encoding", "aes128gcm")
+	httpReq.Header.Set("Content-Type", "application/octet-stream")
+	httpReq.Header.Set("TTL", strconv.FormatInt(int64(message.ttl/time.Second), 10))
+	httpReq.Header.Set("Urgency", message.urgency)
+	if message.topic != "" {
+		httpReq.Header.Set("Topic", message.topic)
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("web push request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.Header.Get("Location"), nil
+	}
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		// The subscription expired or the user unsubscribed
+		return "", fmt.Errorf("%w: %s", ErrUnregisteredToken, subscription.Endpoint)
+	}
+
+	detail := strings.TrimSpace(string(respBody))
+	if detail == "" {
+		return "", fmt.Errorf("web push service returned status %d", resp.StatusCode)
+	}
+	return "", fmt.Errorf("web push service returned %d %s", resp.StatusCode, detail)
+}
+
+// vapidAuthorization signs a VAPID token for the push service's origin
+func (p *WebPushProvider) vapidAuthorization(audience string) (string, error) {
+	header := map[string]string{"typ": "JWT", "alg": "ES256"}
+	claims := map[string]interface{}{
+		"aud": audience,
+		"exp": time.Now().Add(webPushVAPIDLifetime).Unix(),
+		"sub": p.config.Subject,
+	}
+	token, err := signJWT(header, claims, func(signingInput []byte) ([]byte, error) {
+		digest := sha256.Sum256(signingInput)
+		r, s, err := ecdsa.Sign(rand.Reader, p.key, digest[:])
+		if err != nil {
+			return nil, err
+		}
+		return append(padBigInt(r, 32), padBigInt(s, 32)...), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", token, p.config.PublicKey), nil
+}
+
+// encryptWebPushPayload encrypts the payload for the subscription as a single
+// aes128gcm record, as described in RFC 8291
+func encryptWebPushPayload(payload []byte, subscription tenantDomain.WebPushSubscription) ([]byte, error) {
+	userAgentPublic, err := tenantDomain.DecodeWebPushKey(subscription.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription p256dh key: %w", err)
+	}
+	authSecret, err := tenantDomain.DecodeWebPushKey(subscription.Auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, fmt.Errorf("invalid subscription auth secret")
+	}
+
+	curve := ecdh.P256()
+	userAgentKey, err := curve.NewPublicKey(userAgentPublic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription p256dh key: %w", err)
+	}
+	// Each message uses a fresh application server key pair and salt
+	serverKey, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate web push key: %w", err)
+	}
+	sharedSecret, err := serverKey.ECDH(userAgentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive web push secret: %w", err)
+	}
+	serverPublic := serverKey.PublicKey().Bytes()
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate web push salt: %w", err)
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), userAgentPublic...)
+	keyInfo = append(keyInfo, serverPublic...)
+	ikm, err := hkdfExpand(sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create web push cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create web push cipher: %w", err)
+	}
+
+	// The 0x02 delimiter marks the last (and only) record
+	plaintext := make([]byte, 0, len(payload)+1)
+	plaintext = append(plaintext, payload...)
+	plaintext = append(plaintext, 0x02)
+
+	header := make([]byte, 0, 21+len(serverPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(serverPublic)))
+	header = append(header, serverPublic...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+func hkdfExpand(secret, salt, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), out); err != nil {
+		return nil, fmt.Errorf("failed to derive web push key: %w", err)
+	}
+	return out, nil
+}
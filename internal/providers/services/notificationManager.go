@@ -3,35 +3,66 @@ package providers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
-	tenantServices "getnoti.com/internal/tenants/services"
 	"getnoti.com/internal/providers/dtos"
 	"getnoti.com/internal/providers/infra/providers"
 	"getnoti.com/pkg/queue"
 	"getnoti.com/pkg/workerpool"
 )
 
+// DeliveryRecorder records the outcome of a send against its notification
+type DeliveryRecorder interface {
+	RecordDelivery(ctx context.Context, report dtos.DeliveryReport) error
+	// RecordFailover records a provider that rejected the send before the
+	// next provider in the chain is tried
+	RecordFailover(ctx context.Context, report dtos.DeliveryReport) error
+}
+
+// InvalidTokensError is returned when a provider rejected a send because the
+// receiver's device tokens are no longer registered
+type InvalidTokensError struct {
+	ProviderID string
+	Tokens     []string
+	Message    string
+}
+
+func (e *InvalidTokensError) Error() string {
+	return fmt.Sprintf("provider %s rejected notification: %s", e.ProviderID, e.Message)
+}
+
 type NotificationManager struct {
 	notificationQueue queue.Queue
 	providerFactory   *providers.ProviderFactory
 	workerPoolManager *workerpool.WorkerPoolManager
-	userPrefService *tenantServices.UserPreferenceService
+	deliveryRecorder  DeliveryRecorder
 	mu                sync.RWMutex
 }
 
-func NewNotificationManager(nq queue.Queue, pf *providers.ProviderFactory, wpm *workerpool.WorkerPoolManager, 	userPrefService *tenantServices.UserPreferenceService) *NotificationManager {
+func NewNotificationManager(nq queue.Queue, pf *providers.ProviderFactory, wpm *workerpool.WorkerPoolManager, deliveryRecorder DeliveryRecorder) *NotificationManager {
 	return &NotificationManager{
 		notificationQueue: nq,
 		providerFactory:   pf,
 		workerPoolManager: wpm,
-		userPrefService: userPrefService,
+		deliveryRecorder:  deliveryRecorder,
 	}
 }
 
 func (nm *NotificationManager) DispatchNotification(ctx context.Context, req dtos.SendNotificationRequest) error {
+	// Without a queue the notification is sent inline, so a failure can still
+	// fall through to the next provider
+	if nm.notificationQueue == nil {
+		return nm.deliver(ctx, req)
+	}
+	return nm.enqueue(ctx, req)
+}
+
+// enqueue publishes the notification to its provider's queue and makes sure
+// the provider has workers consuming it
+func (nm *NotificationManager) enqueue(ctx context.Context, req dtos.SendNotificationRequest) error {
 	messageBytes, err := json.Marshal(req)
 	if err != nil {
 		return fmt.Errorf("failed to marshal notification request: %w", err)
@@ -89,28 +120,84 @@ func (nm *NotificationManager) handleMessage(msg queue.Message) {
 		return
 	}
 
-	// Check user preferences (if this is a user-targeted notification)
-	if req.UserID != "" && nm.userPrefService != nil {
-		ctx := context.Background()
-		shouldSend, err := nm.userPrefService.ShouldSendNotification(ctx, req.UserID, req.TenantID, req.Channel, req.Category)
-		if err != nil {
-			fmt.Printf("Error checking user preferences: %v\n", err)
-			// Continue with sending as default behavior if preferences check fails
-		} else if !shouldSend {
-			fmt.Printf("Notification skipped based on user preferences for user %s\n", req.UserID)
-			return
-		}
+	// A queued send has no caller left to fail over, so a rejected message
+	// moves on to the next provider's queue until the chain is exhausted
+	ctx := context.Background()
+	err = nm.deliver(ctx, req)
+	for err != nil && len(req.FallbackProviderIDs) > 0 {
+		fmt.Printf("Provider %s failed, failing over: %v\n", req.ProviderID, err)
+		nm.recordFailover(ctx, req, err)
+		req.ProviderID, req.FallbackProviderIDs = req.FallbackProviderIDs[0], req.FallbackProviderIDs[1:]
+		err = nm.enqueue(ctx, req)
+	}
+	if err != nil {
+		fmt.Printf("Failed to send notification: %v\n", err)
+		nm.recordDelivery(ctx, req, "failed", "", err.Error(), invalidTokensOf(err))
 	}
+}
+
+// invalidTokensOf returns the unregistered device tokens a provider reported, if any
+func invalidTokensOf(err error) []string {
+	var tokensErr *InvalidTokensError
+	if errors.As(err, &tokensErr) {
+		return tokensErr.Tokens
+	}
+	return nil
+}
 
+// deliver sends the notification through its provider and records a sent
+// outcome; failures are returned to the caller. Preferences were already
+// applied by the send use case before the notification was dispatched.
+func (nm *NotificationManager) deliver(ctx context.Context, req dtos.SendNotificationRequest) error {
 	provider, err := nm.providerFactory.GetProvider(req.ProviderID, req.Sender, req.Channel)
 	if provider == nil || err != nil {
-		fmt.Printf("Failed to get provider instance for provider %s\n", req.ProviderID)
-		return
+		return fmt.Errorf("failed to get provider instance for provider %s", req.ProviderID)
 	}
 
-	resp := provider.SendNotification(context.Background(), req)
+	resp := provider.SendNotification(ctx, req)
+	if !resp.Success && len(resp.InvalidTokens) > 0 {
+		return &InvalidTokensError{ProviderID: req.ProviderID, Tokens: resp.InvalidTokens, Message: resp.Message}
+	}
 	if !resp.Success {
-		fmt.Printf("Failed to send notification: %s\n", resp.Message)
+		return fmt.Errorf("provider %s rejected notification: %s", req.ProviderID, resp.Message)
+	}
+	nm.recordDelivery(ctx, req, "sent", resp.MessageID, "", nil)
+	return nil
+}
+
+// recordDelivery reports the outcome of a send linked to a notification
+func (nm *NotificationManager) recordDelivery(ctx context.Context, req dtos.SendNotificationRequest, status, messageID, reason string, invalidTokens []string) {
+	if nm.deliveryRecorder == nil || req.NotificationID == "" {
+		return
+	}
+	err := nm.deliveryRecorder.RecordDelivery(ctx, dtos.DeliveryReport{
+		TenantID:          req.TenantID,
+		NotificationID:    req.NotificationID,
+		ProviderID:        req.ProviderID,
+		Status:            status,
+		ProviderMessageID: messageID,
+		Reason:            reason,
+		InvalidTokens:     invalidTokens,
+	})
+	if err != nil {
+		fmt.Printf("Failed to record %s status for notification %s: %v\n", status, req.NotificationID, err)
+	}
+}
+
+// recordFailover reports a provider that rejected a queued send before the next one is tried
+func (nm *NotificationManager) recordFailover(ctx context.Context, req dtos.SendNotificationRequest, cause error) {
+	if nm.deliveryRecorder == nil || req.NotificationID == "" {
+		return
+	}
+	err := nm.deliveryRecorder.RecordFailover(ctx, dtos.DeliveryReport{
+		TenantID:       req.TenantID,
+		NotificationID: req.NotificationID,
+		ProviderID:     req.ProviderID,
+		Reason:         cause.Error(),
+		InvalidTokens:  invalidTokensOf(cause),
+	})
+	if err != nil {
+		fmt.Printf("Failed to record failover for notification %s: %v\n", req.NotificationID, err)
 	}
 }
 
@@ -2,6 +2,8 @@ package createprovider
 
 import (
 	"context"
+	"strconv"
+
 	"getnoti.com/internal/providers/domain"
 	"getnoti.com/internal/providers/repos"
 	"getnoti.com/internal/shared/utils"
@@ -33,7 +35,7 @@ func (uc *createProviderUseCase) Execute(ctx context.Context, req CreateProvider
 	// Set up channels with priorities
 	for _, channelType := range req.Channels {
 		// Get the next available priority for this channel
-		priority, err := uc.repo.GetNextAvailablePriority(ctx, string(channelType))
+		priority, err := uc.repo.GetNextAvailablePriority(ctx, strconv.Itoa(int(channelType)))
 		if err != nil {
 			return CreateProviderResponse{}, err
 		}
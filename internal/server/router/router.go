@@ -10,10 +10,12 @@ import (
 	"getnoti.com/internal/shared/handler"
 	tenantMiddleware "getnoti.com/internal/shared/middleware"
 	templateroutes "getnoti.com/internal/templates/infra/http"
+	apikeyroutes "getnoti.com/internal/tenants/infra/http/apikeys"
 	preferencesroutes "getnoti.com/internal/tenants/infra/http/preferences"
 	tenantroutes "getnoti.com/internal/tenants/infra/http/tenants"
 	userroutes "getnoti.com/internal/tenants/infra/http/users"
 	webhookroutes "getnoti.com/internal/webhooks/infra/http"
+	workflowroutes "getnoti.com/internal/workflows/infra/http"
 	"getnoti.com/pkg/cache"
 	"getnoti.com/pkg/credentials"
 	"getnoti.com/pkg/db"
@@ -24,68 +26,83 @@ import (
 )
 
 type Router struct {
-    serviceContainer   *container.ServiceContainer
-    dbManager          *db.Manager
-    mainDB             db.Database
-    genericCache       *cache.GenericCache
-    queueManager       *queue.QueueManager
-    workerPoolManager  *workerpool.WorkerPoolManager
-    credentialManager  *credentials.Manager
-    sseServer sse.Server
+	serviceContainer  *container.ServiceContainer
+	dbManager         *db.Manager
+	mainDB            db.Database
+	genericCache      *cache.GenericCache
+	queueManager      *queue.QueueManager
+	workerPoolManager *workerpool.WorkerPoolManager
+	credentialManager *credentials.Manager
+	sseServer         sse.Server
 }
 
 func New(serviceContainer *container.ServiceContainer, mainDB db.Database, dbManager *db.Manager, genericCache *cache.GenericCache, queueManager *queue.QueueManager, workerPoolManager *workerpool.WorkerPoolManager, credentialManager *credentials.Manager) *Router {
-    return &Router{
-        serviceContainer:  serviceContainer,
-        dbManager:         dbManager,
-        mainDB:            mainDB,
-        genericCache:      genericCache,
-        queueManager:      queueManager,
-        workerPoolManager: workerPoolManager,
-        credentialManager: credentialManager,
-        sseServer:         sse.New(),
-    }
+	return &Router{
+		serviceContainer:  serviceContainer,
+		dbManager:         dbManager,
+		mainDB:            mainDB,
+		genericCache:      genericCache,
+		queueManager:      queueManager,
+		workerPoolManager: workerPoolManager,
+		credentialManager: credentialManager,
+		sseServer:         serviceContainer.GetSSEServer(),
+	}
 }
 
 func (r *Router) Handler() *chi.Mux {
-    router := chi.NewRouter()
+	router := chi.NewRouter()
 
-    // Apply middleware
-    middleware.Apply(router)
+	// Apply middleware
+	middleware.Apply(router)
 
-    // Mount routes
-    r.mountV1Routes(router)
+	// Mount routes
+	r.mountV1Routes(router)
 
-    return router
+	return router
 }
 
 func (r *Router) mountV1Routes(router chi.Router) {
-    v1Router := chi.NewRouter()   
-    v1Router.With(tenantMiddleware.WithTenantID).Mount("/notifications", 
-        notificationroutes.NewRouter(r.serviceContainer, r.dbManager, r.genericCache, r.queueManager, r.credentialManager, r.workerPoolManager))
-    v1Router.Mount("/tenants", 
-        tenantroutes.NewRouter(r.mainDB, r.dbManager, r.credentialManager))
-    v1Router.With(tenantMiddleware.WithTenantID).Mount("/users", 
-        userroutes.NewRouter(r.dbManager))
-    v1Router.With(tenantMiddleware.WithTenantID).Mount("/templates", 
-        templateroutes.NewRouter(r.dbManager))    
-    v1Router.With(tenantMiddleware.WithTenantID).Mount("/providers", 
-        providerroutes.NewRouter(r.dbManager))
-    v1Router.With(tenantMiddleware.WithTenantID).Mount("/webhooks", 
-        webhookroutes.NewRouter(r.serviceContainer, r.dbManager))    
-    v1Router.With(tenantMiddleware.WithTenantID).Mount("/preferences", 
-        preferencesroutes.NewRouter(handler.NewBaseHandler(r.dbManager)))
+	v1Router := chi.NewRouter()
+	tenantAuth := tenantMiddleware.WithAPIKey(r.serviceContainer.GetAPIKeyService())
+	v1Router.With(tenantAuth).Mount("/notifications",
+		notificationroutes.NewRouter(r.serviceContainer, r.dbManager, r.genericCache, r.queueManager, r.credentialManager, r.workerPoolManager))
+	v1Router.With(tenantAuth).Mount("/topics",
+		notificationroutes.NewTopicRouter(r.serviceContainer, r.dbManager, r.genericCache, r.queueManager, r.credentialManager, r.workerPoolManager))
+	v1Router.Mount("/tenants",
+		tenantroutes.NewRouter(r.mainDB, r.dbManager, r.credentialManager, r.serviceContainer.GetAPIKeyService(), r.serviceContainer.GetConfig().GetAdminAPIKey()))
+	v1Router.With(tenantAuth).Mount("/users",
+		userroutes.NewRouter(r.dbManager))
+	// Inbox routes also accept a per-user inbox token, so browsers and apps can
+	// read a user's inbox without holding the tenant API key
+	inboxConfig := r.serviceContainer.GetConfig()
+	inboxAuth := tenantMiddleware.WithInboxAuth(r.serviceContainer.GetAPIKeyService(), inboxConfig.GetInboxTokenSecret())
+	v1Router.With(inboxAuth).Mount("/users/{id}/inbox",
+		notificationroutes.NewInboxRouter(r.serviceContainer, r.dbManager, r.genericCache, r.queueManager, r.credentialManager, r.workerPoolManager, inboxConfig.GetInboxTokenSecret(), inboxConfig.Inbox.TokenTTL))
+	v1Router.With(tenantAuth).Mount("/templates",
+		templateroutes.NewRouter(r.dbManager))
+	v1Router.With(tenantAuth).Mount("/providers",
+		providerroutes.NewRouter(r.dbManager))
+	v1Router.With(tenantAuth).Mount("/webhooks",
+		webhookroutes.NewRouter(r.serviceContainer, r.dbManager))
+	v1Router.With(tenantAuth).Mount("/preferences",
+		preferencesroutes.NewRouter(handler.NewBaseHandler(r.dbManager)))
+	v1Router.With(tenantAuth).Mount("/api-keys",
+		apikeyroutes.NewRouter(r.serviceContainer, r.dbManager))
+	v1Router.With(tenantAuth).Mount("/workflows",
+		workflowroutes.NewRouter(r.serviceContainer, r.dbManager))
+	v1Router.With(tenantAuth).Mount("/executions",
+		workflowroutes.NewExecutionRouter(r.serviceContainer, r.dbManager))
 
-    // Add SSE endpoint for tenant (tenantMiddleware must be applied to extract tenantID)
-    v1Router.With(tenantMiddleware.WithTenantID).Get("/events/stream", func(w http.ResponseWriter, req *http.Request) {
-        tenantID, ok := req.Context().Value(tenantMiddleware.TenantIDKey).(string)
-        if !ok || tenantID == "" {
-            http.Error(w, "tenant ID required", http.StatusBadRequest)
-            return
-        }
-        channel := "tenant_" + tenantID
-        r.sseServer.ServeHTTP(w, req, channel)
-    })
+	// Add SSE endpoint for tenant (tenantAuth must be applied to extract tenantID)
+	v1Router.With(tenantAuth).Get("/events/stream", func(w http.ResponseWriter, req *http.Request) {
+		tenantID, ok := req.Context().Value(tenantMiddleware.TenantIDKey).(string)
+		if !ok || tenantID == "" {
+			http.Error(w, "tenant ID required", http.StatusBadRequest)
+			return
+		}
+		channel := "tenant_" + tenantID
+		r.sseServer.ServeHTTP(w, req, channel)
+	})
 
-    router.Mount("/v1", v1Router)
-}
\ No newline at end of file
+	router.Mount("/v1", v1Router)
+}
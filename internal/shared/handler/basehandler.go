@@ -31,10 +31,20 @@ func (h *BaseHandler) DecodeJSONBody(w http.ResponseWriter, r *http.Request, dst
 }
 
 func (h *BaseHandler) RespondWithJSON(w http.ResponseWriter, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(data); err != nil {
+	h.RespondWithJSONStatus(w, http.StatusOK, data)
+}
+
+// RespondWithJSONStatus writes data as JSON with the given status code
+func (h *BaseHandler) RespondWithJSONStatus(w http.ResponseWriter, statusCode int, data interface{}) {
+	// Encode before writing the header so a failure can still be reported
+	body, err := json.Marshal(data)
+	if err != nil {
 		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(append(body, '\n'))
 }
 
 func (h *BaseHandler) HandleError(w http.ResponseWriter, message string, err error, statusCode int) {
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// WithAdminKey restricts routes to operators holding the admin API key, sent
+// the same way as a tenant key. Without a configured key the routes are not found.
+func WithAdminKey(adminKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if adminKey == "" {
+				http.NotFound(w, r)
+				return
+			}
+
+			rawKey := apiKeyFromRequest(r)
+			if rawKey == "" || subtle.ConstantTimeCompare([]byte(rawKey), []byte(adminKey)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Invalid admin API key", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
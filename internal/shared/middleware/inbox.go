@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// InboxTokenPrefix marks a per-user inbox token, so it is never mistaken for an API key
+const InboxTokenPrefix = "inbox_"
+
+// InboxUserIDKey holds the user an inbox token was issued to
+const InboxUserIDKey contextKey = "inboxUserID"
+
+var (
+	ErrInvalidInboxToken = errors.New("invalid inbox token")
+	ErrInboxTokenExpired = errors.New("inbox token has expired")
+)
+
+type inboxTokenClaims struct {
+	TenantID  string `json:"tid"`
+	UserID    string `json:"uid"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// SignInboxToken issues a token that lets one user of a tenant read their own inbox
+// until expiresAt, so browsers and apps never hold the tenant API key
+func SignInboxToken(secret, tenantID, userID string, expiresAt time.Time) (string, error) {
+	payload, err := json.Marshal(inboxTokenClaims{TenantID: tenantID, UserID: userID, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return InboxTokenPrefix + encoded + "." + inboxTokenSignature(secret, encoded), nil
+}
+
+// VerifyInboxToken checks the token's signature and expiry and returns the tenant and user it was issued to
+func VerifyInboxToken(secret, token string, now time.Time) (tenantID, userID string, err error) {
+	encoded, signature, ok := strings.Cut(strings.TrimPrefix(token, InboxTokenPrefix), ".")
+	if secret == "" || !strings.HasPrefix(token, InboxTokenPrefix) || !ok {
+		return "", "", ErrInvalidInboxToken
+	}
+	if !hmac.Equal([]byte(signature), []byte(inboxTokenSignature(secret, encoded))) {
+		return "", "", ErrInvalidInboxToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", ErrInvalidInboxToken
+	}
+	var claims inboxTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.TenantID == "" || claims.UserID == "" {
+		return "", "", ErrInvalidInboxToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return "", "", ErrInboxTokenExpired
+	}
+	return claims.TenantID, claims.UserID, nil
+}
+
+func inboxTokenSignature(secret, encoded string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(InboxTokenPrefix + encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// WithInboxAuth authenticates inbox routes either by the tenant API key, for
+// calls from the tenant's backend, or by an inbox token issued to the user in
+// the {id} URL parameter. Because EventSource cannot set headers, an inbox
+// token may also be sent as the "token" query parameter.
+func WithInboxAuth(authenticator APIKeyAuthenticator, secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		apiKeyAuth := WithAPIKey(authenticator)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := apiKeyFromRequest(r)
+			if token == "" {
+				token = strings.TrimSpace(r.URL.Query().Get("token"))
+				if !strings.HasPrefix(token, InboxTokenPrefix) {
+					token = ""
+				}
+			}
+			if !strings.HasPrefix(token, InboxTokenPrefix) {
+				apiKeyAuth.ServeHTTP(w, r)
+				return
+			}
+
+			tenantID, userID, err := VerifyInboxToken(secret, token, time.Now())
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Invalid inbox token", http.StatusUnauthorized)
+				return
+			}
+			if userID != chi.URLParam(r, "id") {
+				http.Error(w, "Inbox token was issued to another user", http.StatusForbidden)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), TenantIDKey, tenantID)
+			ctx = context.WithValue(ctx, InboxUserIDKey, userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type stubAuthenticator map[string]string
+
+func (s stubAuthenticator) AuthenticateAPIKey(ctx context.Context, rawKey string) (string, error) {
+	if tenantID, ok := s[rawKey]; ok {
+		return tenantID, nil
+	}
+	return "", errors.New("unknown key")
+}
+
+func TestVerifyInboxToken(t *testing.T) {
+	now := time.Now()
+	token, err := SignInboxToken("secret", "tenant-1", "user-1", now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SignInboxToken: %v", err)
+	}
+
+	tenantID, userID, err := VerifyInboxToken("secret", token, now)
+	if err != nil || tenantID != "tenant-1" || userID != "user-1" {
+		t.Fatalf("VerifyInboxToken = %q, %q, %v", tenantID, userID, err)
+	}
+
+	if _, _, err := VerifyInboxToken("other-secret", token, now); !errors.Is(err, ErrInvalidInboxToken) {
+		t.Errorf("wrong secret: got %v, want %v", err, ErrInvalidInboxToken)
+	}
+	if _, _, err := VerifyInboxToken("", token, now); !errors.Is(err, ErrInvalidInboxToken) {
+		t.Errorf("no secret: got %v, want %v", err, ErrInvalidInboxToken)
+	}
+	if _, _, err := VerifyInboxToken("secret", token, now.Add(2*time.Hour)); !errors.Is(err, ErrInboxTokenExpired) {
+		t.Errorf("expired: got %v, want %v", err, ErrInboxTokenExpired)
+	}
+
+	// Swapping in another user's claims must break the signature
+	forged, _ := SignInboxToken("secret", "tenant-1", "user-2", now.Add(time.Hour))
+	_, forgedSignature, _ := strings.Cut(forged, ".")
+	payload, _, _ := strings.Cut(token, ".")
+	if _, _, err := VerifyInboxToken("secret", payload+"."+forgedSignature, now); !errors.Is(err, ErrInvalidInboxToken) {
+		t.Errorf("forged: got %v, want %v", err, ErrInvalidInboxToken)
+	}
+}
+
+func TestWithInboxAuth(t *testing.T) {
+	token, _ := SignInboxToken("secret", "tenant-1", "user-1", time.Now().Add(time.Hour))
+
+	router := chi.NewRouter()
+	router.With(WithInboxAuth(stubAuthenticator{"api-key": "tenant-1"}, "secret")).
+		Get("/users/{id}/inbox", func(w http.ResponseWriter, r *http.Request) {
+			tenantID, _ := r.Context().Value(TenantIDKey).(string)
+			w.Write([]byte(tenantID))
+		})
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		want   int
+	}{
+		{name: "api key", path: "/users/user-2/inbox", header: "Bearer api-key", want: http.StatusOK},
+		{name: "inbox token", path: "/users/user-1/inbox", header: "Bearer " + token, want: http.StatusOK},
+		{name: "inbox token in query", path: "/users/user-1/inbox?token=" + token, want: http.StatusOK},
+		{name: "inbox token for another user", path: "/users/user-2/inbox", header: "Bearer " + token, want: http.StatusForbidden},
+		{name: "tampered inbox token", path: "/users/user-1/inbox", header: "Bearer " + token + "x", want: http.StatusUnauthorized},
+		{name: "api key in query", path: "/users/user-1/inbox?token=api-key", want: http.StatusUnauthorized},
+		{name: "no credentials", path: "/users/user-1/inbox", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.want, rec.Body.String())
+			}
+			if tt.want == http.StatusOK && rec.Body.String() != "tenant-1" {
+				t.Errorf("tenant = %q, want tenant-1", rec.Body.String())
+			}
+		})
+	}
+}
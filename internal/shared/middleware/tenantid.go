@@ -3,26 +3,51 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"strings"
 )
 
 type contextKey string
 
 const TenantIDKey contextKey = "tenantID"
 
+// APIKeyHeader is the alternative to a bearer Authorization header
+const APIKeyHeader = "X-API-Key"
 
-func WithTenantID(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		tenantID := r.Header.Get("X-Tenant-ID")
-		if tenantID == "" {
-			tenantID = r.URL.Query().Get("tenant_id")
-		}
+// APIKeyAuthenticator resolves the tenant that owns a raw API key
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, rawKey string) (string, error)
+}
 
-		if tenantID == "" {
-			http.Error(w, "Tenant ID is required", http.StatusBadRequest)
-			return
-		}
+// WithAPIKey authenticates requests by API key and stores the owning tenant in the context.
+// The key is read from "Authorization: Bearer <key>" or the X-API-Key header.
+func WithAPIKey(authenticator APIKeyAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rawKey := apiKeyFromRequest(r)
+			if rawKey == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "API key is required", http.StatusUnauthorized)
+				return
+			}
+
+			tenantID, err := authenticator.AuthenticateAPIKey(r.Context(), rawKey)
+			if err != nil || tenantID == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, "Invalid API key", http.StatusUnauthorized)
+				return
+			}
 
-		ctx := context.WithValue(r.Context(), TenantIDKey, tenantID)
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
+			ctx := context.WithValue(r.Context(), TenantIDKey, tenantID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func apiKeyFromRequest(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if scheme, token, ok := strings.Cut(auth, " "); ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return strings.TrimSpace(r.Header.Get(APIKeyHeader))
 }
@@ -26,10 +26,19 @@ type TenantIDSetter interface {
 	SetTenantID(string)
 }
 
+// GetTenantIDFromReq returns the authenticated tenant ID from the request context
+func GetTenantIDFromReq(r *http.Request) (string, error) {
+	tenantID, ok := r.Context().Value(tenantMiddleware.TenantIDKey).(string)
+	if !ok || tenantID == "" {
+		return "", fmt.Errorf("tenant ID not found in context")
+	}
+	return tenantID, nil
+}
+
 // AddTenantIDToRequest adds the tenant ID to the request if it implements TenantIDSetter
 func AddTenantIDToRequest(r *http.Request, req interface{}) error {
 	if setter, ok := req.(TenantIDSetter); ok {
-		tenantID, err := GetIDFromReq(r)
+		tenantID, err := GetTenantIDFromReq(r)
 		if err != nil {
 			return fmt.Errorf("failed to get tenant ID: %w", err)
 		}
@@ -1,14 +1,149 @@
 package domain
 
+import (
+	"fmt"
+	"time"
+
+	tenantDomain "getnoti.com/internal/tenants/domain"
+)
+
 type Template struct {
-    ID        string
-    Name      string
-    Content   string
-    IsPublic  bool
-    Variables  []string
+	ID        string
+	Name      string
+	Content   string
+	IsPublic  bool
+	Variables []string
+	// Variants holds channel-specific content; Content is used for channels without one
+	Variants map[tenantDomain.ChannelType]ChannelContent
+	// Translations holds localized content keyed by locale, e.g. "fr" or "pt-BR"
+	Translations map[string]TemplateTranslation
+	// DraftVersion is the latest saved version; content fields mirror it
+	DraftVersion int
+	// PublishedVersion is the version used for sends that do not pin one
+	PublishedVersion int
+}
+
+// ChannelContent is the content of a template for one channel. Each field is
+// itself a template. Channels use the fields that apply to them:
+// email uses Subject, HTML and Text; SMS uses Body; push, web push, in-app and chat use Title and Body.
+// A chat Body may be a JSON Slack message or Adaptive Card, with values inserted through the json filter.
+type ChannelContent struct {
+	Subject string `json:"subject,omitempty"`
+	HTML    string `json:"html,omitempty"`
+	Text    string `json:"text,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Body    string `json:"body,omitempty"`
+}
+
+// Parts returns the non-empty fields keyed by name
+func (c ChannelContent) Parts() map[string]string {
+	parts := map[string]string{}
+	for name, value := range map[string]string{
+		"subject": c.Subject,
+		"html":    c.HTML,
+		"text":    c.Text,
+		"title":   c.Title,
+		"body":    c.Body,
+	} {
+		if value != "" {
+			parts[name] = value
+		}
+	}
+	return parts
+}
+
+// TemplateTranslation is a template's content in one locale. It follows the same
+// rules as the base template: a channel variant if present, otherwise Content.
+type TemplateTranslation struct {
+	Content  string                                      `json:"content,omitempty"`
+	Variants map[tenantDomain.ChannelType]ChannelContent `json:"variants,omitempty"`
+}
+
+// Variant returns the content to render for a channel, falling back to the
+// template's base Content when the channel has no variant
+func (t *Template) Variant(channel tenantDomain.ChannelType) ChannelContent {
+	if variant, ok := t.Variants[channel]; ok {
+		return variant
+	}
+	return contentVariant(channel, t.Content)
+}
+
+// LocalizedVariant returns the content for a channel in the first of the locales
+// that has a translation for it, along with that locale. Each locale is tried
+// from most to least specific. When no translation applies the base content is
+// returned with an empty locale.
+func (t *Template) LocalizedVariant(channel tenantDomain.ChannelType, locales ...string) (ChannelContent, string) {
+	for _, locale := range locales {
+		for _, candidate := range tenantDomain.LocaleFallbacks(locale) {
+			translation, ok := t.Translations[candidate]
+			if !ok {
+				continue
+			}
+			if variant, ok := translation.Variants[channel]; ok {
+				return variant, candidate
+			}
+			if translation.Content != "" {
+				return contentVariant(channel, translation.Content), candidate
+			}
+		}
+	}
+	return t.Variant(channel), ""
+}
+
+// NormalizeTranslations rewrites translation keys to canonical locale tags
+func (t *Template) NormalizeTranslations() error {
+	if len(t.Translations) == 0 {
+		return nil
+	}
+	normalized := make(map[string]TemplateTranslation, len(t.Translations))
+	for locale, translation := range t.Translations {
+		key, err := tenantDomain.NormalizeLocale(locale)
+		if err != nil || key == "" {
+			return fmt.Errorf("%w: %q", tenantDomain.ErrInvalidLocale, locale)
+		}
+		if _, exists := normalized[key]; exists {
+			return fmt.Errorf("%w: duplicate translation for %q", tenantDomain.ErrInvalidLocale, key)
+		}
+		normalized[key] = translation
+	}
+	t.Translations = normalized
+	return nil
+}
+
+func contentVariant(channel tenantDomain.ChannelType, content string) ChannelContent {
+	if channel == tenantDomain.ChannelTypeEmail {
+		return ChannelContent{Text: content}
+	}
+	return ChannelContent{Body: content}
+}
+
+// TemplateVersion is an immutable snapshot of a template's content
+type TemplateVersion struct {
+	ID           string
+	TemplateID   string
+	Version      int
+	Content      string
+	Variables    []string
+	Variants     map[tenantDomain.ChannelType]ChannelContent
+	Translations map[string]TemplateTranslation
+	CreatedAt    time.Time
+}
+
+// NewVersion snapshots the template's current content as the given version
+func (t *Template) NewVersion(id string, version int) *TemplateVersion {
+	return &TemplateVersion{
+		ID:           id,
+		TemplateID:   t.ID,
+		Version:      version,
+		Content:      t.Content,
+		Variables:    t.Variables,
+		Variants:     t.Variants,
+		Translations: t.Translations,
+		CreatedAt:    time.Now(),
+	}
 }
 
 type TemplateVariable struct {
 	Key   string
 	Value string
-}
\ No newline at end of file
+}
@@ -0,0 +1,204 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Engine limits. Templates are authored by tenants, so rendering is bounded
+// in size and work regardless of the data passed in.
+const (
+	MaxTemplateSize   = 256 * 1024
+	MaxOutputSize     = 1024 * 1024
+	MaxLoopIterations = 10000
+	MaxNestingDepth   = 32
+)
+
+var (
+	ErrCompile            = errors.New("template compile failed")
+	ErrRender             = errors.New("template render failed")
+	ErrTemplateTooLarge   = errors.New("template exceeds maximum size")
+	ErrOutputLimit        = errors.New("rendered output exceeds maximum size")
+	ErrIterationLimit     = errors.New("template exceeds maximum loop iterations")
+	ErrUnknownFilter      = errors.New("unknown filter")
+	ErrInvalidFilterUsage = errors.New("invalid filter usage")
+)
+
+// SyntaxError describes a compile error and where it occurred in the source
+type SyntaxError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return ErrCompile
+}
+
+// Position is a location in the template source
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Reference is a variable path used by a template
+type Reference struct {
+	Path string
+	// HasDefault is true when every use of the path supplies a default value
+	HasDefault bool
+}
+
+// Template is a compiled template that can be rendered any number of times.
+//
+// Syntax:
+//
+//	{{ user.name }}                       output a value, nested paths and list indexes allowed
+//	{{ name | upper }}                    apply filters, left to right
+//	{{ name | default: "there" }}         filters may take arguments
+//	"text": {{ message | json }}          output a JSON value, for JSON content such as chat blocks
+//	{{ banner | raw }}                    output without HTML escaping when rendered with RenderHTML
+//	{{#if order.total > 100}}..{{else if vip}}..{{else}}..{{/if}}
+//	{{#each items as item}}..{{else}}..{{/each}}   this, @index, @first and @last are set in loops
+//	{{! comment }}
+type Template struct {
+	source string
+	nodes  []node
+	refs   []Reference
+}
+
+// Compile parses a template source and reports syntax errors, unknown filters
+// and invalid filter arguments.
+func Compile(source string) (*Template, error) {
+	if len(source) > MaxTemplateSize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTemplateTooLarge, len(source))
+	}
+
+	items, err := scan(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{items: items}
+	nodes, err := p.parseTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Template{source: source, nodes: nodes}
+	t.refs = collectReferences(nodes)
+	return t, nil
+}
+
+// Source returns the original template text
+func (t *Template) Source() string {
+	return t.source
+}
+
+// References returns the data paths the template reads, sorted by path.
+// Loop aliases and loop-local names are not included.
+func (t *Template) References() []Reference {
+	refs := make([]Reference, len(t.refs))
+	copy(refs, t.refs)
+	return refs
+}
+
+// Render executes the template against data. Missing values render as empty strings.
+func (t *Template) Render(data map[string]interface{}) (string, error) {
+	return t.render(newRenderer(data))
+}
+
+// RenderHTML executes the template like Render, HTML-escaping every output
+// value. Values whose last filter is raw are output as is, and values whose
+// last filter is escape are not escaped twice.
+func (t *Template) RenderHTML(data map[string]interface{}) (string, error) {
+	r := newRenderer(data)
+	r.escapeHTML = true
+	return t.render(r)
+}
+
+func (t *Template) render(r *renderer) (string, error) {
+	if err := r.renderNodes(t.nodes); err != nil {
+		return "", err
+	}
+	return r.out.String(), nil
+}
+
+// collectReferences walks the parsed template and records every root data path
+func collectReferences(nodes []node) []Reference {
+	seen := map[string]*Reference{}
+	var order []string
+
+	add := func(path string, hasDefault bool) {
+		if ref, ok := seen[path]; ok {
+			ref.HasDefault = ref.HasDefault && hasDefault
+			return
+		}
+		seen[path] = &Reference{Path: path, HasDefault: hasDefault}
+		order = append(order, path)
+	}
+
+	var walk func(nodes []node, locals map[string]bool)
+	walk = func(nodes []node, locals map[string]bool) {
+		for _, n := range nodes {
+			switch n := n.(type) {
+			case *outputNode:
+				visitPaths(n.expr, false, locals, add)
+			case *ifNode:
+				for _, branch := range n.branches {
+					visitPaths(branch.cond, false, locals, add)
+					walk(branch.body, locals)
+				}
+				walk(n.elseBody, locals)
+			case *eachNode:
+				visitPaths(n.source, false, locals, add)
+				inner := map[string]bool{"this": true, "@index": true, "@first": true, "@last": true}
+				for k := range locals {
+					inner[k] = true
+				}
+				if n.alias != "" {
+					inner[n.alias] = true
+				}
+				walk(n.body, inner)
+				walk(n.elseBody, locals)
+			}
+		}
+	}
+	walk(nodes, map[string]bool{})
+
+	sort.Strings(order)
+	refs := make([]Reference, 0, len(order))
+	for _, path := range order {
+		refs = append(refs, *seen[path])
+	}
+	return refs
+}
+
+// Lookup resolves a dotted path in template data the same way templates do
+func Lookup(data map[string]interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return nil, false
+	}
+	return newRenderer(data).lookup(strings.Split(path, "."))
+}
+
+// SetPath stores a value at a dotted path, creating nested objects as needed.
+// This lets flat key/value variables such as "user.name" populate nested data.
+func SetPath(data map[string]interface{}, path string, value interface{}) {
+	segments := strings.Split(path, ".")
+	current := data
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := current[segment].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[segment] = next
+		}
+		current = next
+	}
+	current[segments[len(segments)-1]] = value
+}
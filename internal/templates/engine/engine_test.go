@@ -0,0 +1,309 @@
+package engine
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	data := map[string]interface{}{
+		"name":    "Ada",
+		"user":    map[string]interface{}{"first": "Ada", "plan": map[string]string{"tier": "pro"}},
+		"items":   []interface{}{"a", "b", "c"},
+		"total":   120.5,
+		"count":   3,
+		"active":  true,
+		"empty":   "",
+		"zero":    0,
+		"sent_at": time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+		"tags":    []string{"x", "y"},
+		"order":   map[string]interface{}{"id": 42},
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{name: "plain text", source: "Hello", want: "Hello"},
+		{name: "variable", source: "Hi {{ name }}!", want: "Hi Ada!"},
+		{name: "no spaces in tag", source: "{{name}}", want: "Ada"},
+		{name: "nested path", source: "{{ user.first }}", want: "Ada"},
+		{name: "typed map", source: "{{ user.plan.tier }}", want: "pro"},
+		{name: "list index", source: "{{ items.1 }}", want: "b"},
+		{name: "typed list index", source: "{{ tags.0 }}", want: "x"},
+		{name: "index out of range", source: "[{{ items.9 }}]", want: "[]"},
+		{name: "missing value", source: "[{{ missing.path }}]", want: "[]"},
+		{name: "path through a string", source: "[{{ name.first }}]", want: "[]"},
+		{name: "float", source: "{{ total }}", want: "120.5"},
+		{name: "int", source: "{{ count }}", want: "3"},
+		{name: "bool", source: "{{ active }}", want: "true"},
+		{name: "time", source: "{{ sent_at }}", want: "2026-03-01T10:00:00Z"},
+		{name: "object", source: "{{ order }}", want: `{"id":42}`},
+		{name: "list", source: "{{ items }}", want: `["a","b","c"]`},
+		{name: "string literal", source: `{{ "a \"quoted\" word" }}`, want: `a "quoted" word`},
+		{name: "single-quoted literal", source: `{{ 'single' }}`, want: "single"},
+		{name: "closing delimiter in a string", source: `{{ "}}" }}`, want: "}}"},
+		{name: "number literal", source: "{{ 1.5 }}", want: "1.5"},
+		{name: "comment", source: "a{{! ignored }}b", want: "ab"},
+		{name: "this at the root", source: "{{ this.name }}", want: "Ada"},
+		{name: "multiline", source: "line 1\n{{ name }}\nline 3", want: "line 1\nAda\nline 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := render(t, tt.source, data)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderIf(t *testing.T) {
+	data := map[string]interface{}{
+		"total":  150,
+		"name":   "Ada",
+		"vip":    true,
+		"empty":  "",
+		"zero":   0,
+		"none":   []interface{}{},
+		"items":  []interface{}{1},
+		"status": "paid",
+		"price":  "9.5",
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{name: "true", source: "{{#if vip}}yes{{/if}}", want: "yes"},
+		{name: "false", source: "{{#if missing}}yes{{/if}}", want: ""},
+		{name: "else", source: "{{#if missing}}yes{{else}}no{{/if}}", want: "no"},
+		{name: "else if", source: "{{#if total > 200}}a{{else if total > 100}}b{{else}}c{{/if}}", want: "b"},
+		{name: "falls through to else", source: "{{#if total > 200}}a{{else if total > 180}}b{{else}}c{{/if}}", want: "c"},
+		{name: "empty string is false", source: "{{#if empty}}y{{else}}n{{/if}}", want: "n"},
+		{name: "zero is false", source: "{{#if zero}}y{{else}}n{{/if}}", want: "n"},
+		{name: "empty list is false", source: "{{#if none}}y{{else}}n{{/if}}", want: "n"},
+		{name: "list is true", source: "{{#if items}}y{{else}}n{{/if}}", want: "y"},
+		{name: "equals string", source: `{{#if status == "paid"}}y{{/if}}`, want: "y"},
+		{name: "not equals", source: `{{#if status != "paid"}}y{{else}}n{{/if}}`, want: "n"},
+		{name: "numeric string compares as number", source: "{{#if price < 10}}y{{/if}}", want: "y"},
+		{name: ">=", source: "{{#if total >= 150}}y{{/if}}", want: "y"},
+		{name: "<=", source: "{{#if total <= 149}}y{{else}}n{{/if}}", want: "n"},
+		{name: "and", source: "{{#if vip and total > 100}}y{{/if}}", want: "y"},
+		{name: "or", source: "{{#if missing or vip}}y{{/if}}", want: "y"},
+		{name: "not", source: "{{#if not missing}}y{{/if}}", want: "y"},
+		{name: "parentheses", source: "{{#if not (vip and zero)}}y{{/if}}", want: "y"},
+		{name: "filter in condition", source: `{{#if name | lower == "ada"}}y{{/if}}`, want: "y"},
+		{name: "nested", source: "{{#if vip}}{{#if total > 100}}both{{/if}}{{/if}}", want: "both"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := render(t, tt.source, data)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderEach(t *testing.T) {
+	data := map[string]interface{}{
+		"items":  []interface{}{"a", "b", "c"},
+		"typed":  []string{"x", "y"},
+		"orders": []interface{}{map[string]interface{}{"id": 1, "lines": []interface{}{"l1", "l2"}}, map[string]interface{}{"id": 2, "lines": []interface{}{}}},
+		"prices": map[string]interface{}{"b": 2, "a": 1},
+		"none":   []interface{}{},
+		"name":   "Ada",
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{name: "this", source: "{{#each items}}{{ this }}{{/each}}", want: "abc"},
+		{name: "alias", source: "{{#each items as item}}{{ item }};{{/each}}", want: "a;b;c;"},
+		{name: "index", source: "{{#each items}}{{ @index }}{{/each}}", want: "012"},
+		{name: "first and last", source: "{{#each items}}{{#if @first}}[{{/if}}{{ this }}{{#if @last}}]{{else}},{{/if}}{{/each}}", want: "[a,b,c]"},
+		{name: "typed slice", source: "{{#each typed}}{{ this }}{{/each}}", want: "xy"},
+		{name: "else on empty list", source: "{{#each none}}x{{else}}empty{{/each}}", want: "empty"},
+		{name: "else on missing list", source: "{{#each missing}}x{{else}}empty{{/each}}", want: "empty"},
+		{name: "map in key order", source: "{{#each prices}}{{ this.key }}={{ this.value }};{{/each}}", want: "a=1;b=2;"},
+		{name: "root data inside loop", source: "{{#each typed}}{{ name }}{{/each}}", want: "AdaAda"},
+		{name: "nested loops", source: "{{#each orders as order}}{{ order.id }}:{{#each order.lines as line}}{{ line }}{{else}}-{{/each}};{{/each}}", want: "1:l1l2;2:-;"},
+		{name: "inner index shadows outer", source: "{{#each orders as order}}{{#each order.lines}}{{ @index }}{{/each}}{{ @index }}|{{/each}}", want: "010|1|"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := render(t, tt.source, data)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		line    int
+		column  int
+		message string
+	}{
+		{name: "unclosed tag", source: "Hi {{ name", line: 1, column: 4, message: "unclosed tag"},
+		{name: "empty tag", source: "{{ }}", line: 1, column: 1, message: "empty tag"},
+		{name: "unknown block", source: "{{#with user}}{{/with}}", line: 1, column: 1, message: "unknown block tag"},
+		{name: "unclosed if", source: "{{#if a}}yes", line: 1, column: 1, message: "unclosed {{#if}}"},
+		{name: "unclosed each", source: "{{#each a}}x", line: 1, column: 1, message: "unclosed {{#each}}"},
+		{name: "stray end", source: "text\n  {{/if}}", line: 2, column: 3, message: "unexpected {{/if}}"},
+		{name: "stray else", source: "{{else}}", line: 1, column: 1, message: "unexpected {{else}}"},
+		{name: "mismatched end", source: "{{#if a}}x{{/each}}", line: 1, column: 11, message: "unexpected {{/each}}"},
+		{name: "missing condition", source: "{{#if}}x{{/if}}", line: 1, column: 1, message: "missing condition"},
+		{name: "missing list", source: "{{#each}}x{{/each}}", line: 1, column: 1, message: "missing list"},
+		{name: "invalid loop variable", source: "{{#each items as this}}x{{/each}}", line: 1, column: 1, message: "invalid loop variable"},
+		{name: "unknown filter", source: "{{ name | shout }}", line: 1, column: 1, message: `unknown filter "shout"`},
+		{name: "missing filter name", source: "{{ name | }}", line: 1, column: 1, message: "expected filter name"},
+		{name: "too few filter arguments", source: "{{ name | default }}", line: 1, column: 1, message: `filter "default" takes 1 argument(s)`},
+		{name: "too many filter arguments", source: `{{ name | upper: "x" }}`, line: 1, column: 1, message: `filter "upper" takes no arguments`},
+		{name: "unterminated string", source: `{{ "abc }}`, line: 1, column: 1, message: "unclosed tag"},
+		{name: "unexpected character", source: "{{ name ; }}", line: 1, column: 1, message: "unexpected character"},
+		{name: "dangling operator", source: "{{#if a ==}}x{{/if}}", line: 1, column: 1, message: "unexpected end of expression"},
+		{name: "missing parenthesis", source: "{{#if (a}}x{{/if}}", line: 1, column: 1, message: `missing ")"`},
+		{name: "error in a later tag", source: "ok\n{{ a }} {{ b | nope }}", line: 2, column: 9, message: "unknown filter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.source)
+			if !errors.Is(err, ErrCompile) {
+				t.Fatalf("Compile error = %v, want %v", err, ErrCompile)
+			}
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Compile error = %T, want *SyntaxError", err)
+			}
+			if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column || !strings.Contains(syntaxErr.Message, tt.message) {
+				t.Errorf("got %d:%d %q, want %d:%d containing %q", syntaxErr.Line, syntaxErr.Column, syntaxErr.Message, tt.line, tt.column, tt.message)
+			}
+		})
+	}
+}
+
+func TestLimits(t *testing.T) {
+	t.Run("template size", func(t *testing.T) {
+		if _, err := Compile(strings.Repeat("a", MaxTemplateSize)); err != nil {
+			t.Fatalf("Compile at the limit: %v", err)
+		}
+		if _, err := Compile(strings.Repeat("a", MaxTemplateSize+1)); !errors.Is(err, ErrTemplateTooLarge) {
+			t.Errorf("got %v, want %v", err, ErrTemplateTooLarge)
+		}
+	})
+
+	t.Run("nesting depth", func(t *testing.T) {
+		nested := func(depth int) string {
+			return strings.Repeat("{{#if a}}", depth) + strings.Repeat("{{/if}}", depth)
+		}
+		if _, err := Compile(nested(MaxNestingDepth)); err != nil {
+			t.Fatalf("Compile at the limit: %v", err)
+		}
+		if _, err := Compile(nested(MaxNestingDepth + 1)); !errors.Is(err, ErrCompile) {
+			t.Errorf("got %v, want %v", err, ErrCompile)
+		}
+	})
+
+	t.Run("output size", func(t *testing.T) {
+		data := map[string]interface{}{"chunk": strings.Repeat("x", MaxOutputSize/2)}
+		if _, err := render(t, "{{ chunk }}{{ chunk }}", data); err != nil {
+			t.Fatalf("Render at the limit: %v", err)
+		}
+		if _, err := render(t, "{{ chunk }}{{ chunk }}!", data); !errors.Is(err, ErrOutputLimit) {
+			t.Errorf("got %v, want %v", err, ErrOutputLimit)
+		}
+	})
+
+	t.Run("loop iterations", func(t *testing.T) {
+		items := make([]interface{}, MaxLoopIterations/2)
+		data := map[string]interface{}{"items": items}
+		if _, err := render(t, "{{#each items}}{{/each}}{{#each items}}{{/each}}", data); err != nil {
+			t.Fatalf("Render at the limit: %v", err)
+		}
+		// Iterations are counted across every loop in the render, nested or not
+		if _, err := render(t, "{{#each items}}{{/each}}{{#each items}}{{/each}}{{#each items}}{{/each}}", data); !errors.Is(err, ErrIterationLimit) {
+			t.Errorf("got %v, want %v", err, ErrIterationLimit)
+		}
+		nested := map[string]interface{}{"items": make([]interface{}, 101)}
+		if _, err := render(t, "{{#each items}}{{#each items}}{{/each}}{{/each}}", nested); !errors.Is(err, ErrIterationLimit) {
+			t.Errorf("nested: got %v, want %v", err, ErrIterationLimit)
+		}
+	})
+}
+
+func TestRenderErrorsReportPosition(t *testing.T) {
+	_, err := render(t, "line 1\n  {{ amount | currency }}", map[string]interface{}{"amount": "lots"})
+	if !errors.Is(err, ErrRender) || !errors.Is(err, ErrInvalidFilterUsage) {
+		t.Fatalf("got %v, want %v wrapping %v", err, ErrRender, ErrInvalidFilterUsage)
+	}
+	if !strings.Contains(err.Error(), "line 2, column 3") {
+		t.Errorf("error %q does not report the tag position", err)
+	}
+}
+
+func TestReferences(t *testing.T) {
+	tmpl, err := Compile(`{{ user.name | default: "there" }} {{ total }}
+{{#if vip and user.name}}{{ greeting }}{{/if}}
+{{#each orders as order}}{{ order.id }}{{ this }}{{ @index }}{{ currency }}{{/each}}`)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	want := []Reference{
+		{Path: "currency"},
+		{Path: "greeting"},
+		{Path: "orders"},
+		{Path: "total"},
+		{Path: "user.name"},
+		{Path: "vip"},
+	}
+	if got := tmpl.References(); !reflect.DeepEqual(got, want) {
+		t.Errorf("References = %+v, want %+v", got, want)
+	}
+}
+
+func TestLookupAndSetPath(t *testing.T) {
+	data := map[string]interface{}{}
+	SetPath(data, "user.name", "Ada")
+	SetPath(data, "user.plan", "pro")
+	SetPath(data, "total", 3)
+
+	tests := []struct {
+		path  string
+		want  interface{}
+		found bool
+	}{
+		{path: "user.name", want: "Ada", found: true},
+		{path: "user.plan", want: "pro", found: true},
+		{path: "total", want: 3, found: true},
+		{path: "user.missing", found: false},
+		{path: "", found: false},
+	}
+	for _, tt := range tests {
+		got, found := Lookup(data, tt.path)
+		if found != tt.found || got != tt.want {
+			t.Errorf("Lookup(%q) = %v, %v; want %v, %v", tt.path, got, found, tt.want, tt.found)
+		}
+	}
+}
@@ -0,0 +1,425 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type expr interface{}
+
+// pathExpr reads a dotted path such as user.address.city or items.0.name
+type pathExpr struct {
+	raw      string
+	segments []string
+}
+
+type literalExpr struct {
+	value interface{}
+}
+
+type filterCall struct {
+	name   string
+	filter *filter
+	args   []expr
+}
+
+// pipeExpr applies filters to an operand, left to right
+type pipeExpr struct {
+	operand expr
+	filters []filterCall
+}
+
+type notExpr struct {
+	operand expr
+}
+
+// binaryExpr is a logical (and/or) or comparison operation
+type binaryExpr struct {
+	op          string
+	left, right expr
+}
+
+type tokenKind int
+
+const (
+	tokIdent tokenKind = iota
+	tokString
+	tokNumber
+	tokOperator
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value interface{}
+}
+
+// Names that are always bound inside loops and never read from template data
+var loopLocals = map[string]bool{
+	"this":   true,
+	"@index": true,
+	"@first": true,
+	"@last":  true,
+}
+
+var keywords = map[string]bool{
+	"and":   true,
+	"or":    true,
+	"not":   true,
+	"true":  true,
+	"false": true,
+	"null":  true,
+}
+
+var comparisonOperators = map[string]bool{
+	"==": true,
+	"!=": true,
+	">":  true,
+	">=": true,
+	"<":  true,
+	"<=": true,
+}
+
+type exprParser struct {
+	tokens []token
+	pos    int
+	at     Position
+}
+
+// parseExpression parses the contents of an output tag or block condition
+func parseExpression(text string, at Position) (expr, error) {
+	tokens, err := tokenize(text, at)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, &SyntaxError{Line: at.Line, Column: at.Column, Message: "empty expression"}
+	}
+
+	p := &exprParser{tokens: tokens, at: at}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, p.errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return e, nil
+}
+
+func tokenize(text string, at Position) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '"' || c == '\'':
+			s, n, err := readString(text[i:])
+			if err != nil {
+				return nil, &SyntaxError{Line: at.Line, Column: at.Column, Message: err.Error()}
+			}
+			tokens = append(tokens, token{kind: tokString, text: text[i : i+n], value: s})
+			i += n
+		case isDigit(c) || (c == '-' && i+1 < len(text) && isDigit(text[i+1]) && expectsOperand(tokens)):
+			j := i + 1
+			for j < len(text) && (isDigit(text[j]) || text[j] == '.') {
+				j++
+			}
+			f, err := strconv.ParseFloat(text[i:j], 64)
+			if err != nil {
+				return nil, &SyntaxError{Line: at.Line, Column: at.Column, Message: fmt.Sprintf("invalid number %q", text[i:j])}
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: text[i:j], value: f})
+			i = j
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(text) && isIdentPart(text[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: text[i:j]})
+			i = j
+		default:
+			op := ""
+			for _, candidate := range []string{"||", "&&", "==", "!=", ">=", "<=", "|", "!", ">", "<", "(", ")", ":", ","} {
+				if strings.HasPrefix(text[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, &SyntaxError{Line: at.Line, Column: at.Column, Message: fmt.Sprintf("unexpected character %q", c)}
+			}
+			tokens = append(tokens, token{kind: tokOperator, text: op})
+			i += len(op)
+		}
+	}
+	return tokens, nil
+}
+
+// expectsOperand reports whether a '-' at this point starts a negative number
+func expectsOperand(tokens []token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	last := tokens[len(tokens)-1]
+	return last.kind == tokOperator && last.text != ")"
+}
+
+func readString(s string) (string, int, error) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(s[i])
+			}
+		case c == quote:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '@' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '.' || c == '-'
+}
+
+func isIdentifier(s string) bool {
+	if s == "" || !isIdentStart(s[0]) || s[0] == '@' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isIdentPart(s[i]) || s[i] == '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isReserved(name string) bool {
+	return keywords[name] || loopLocals[name]
+}
+
+func (p *exprParser) peek() *token {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *exprParser) accept(texts ...string) (string, bool) {
+	tok := p.peek()
+	if tok == nil || tok.kind == tokString || tok.kind == tokNumber {
+		return "", false
+	}
+	for _, text := range texts {
+		if tok.text == text {
+			p.pos++
+			return text, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||", "or"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: "or", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseAnd() (expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&", "and"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: "and", left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseNot() (expr, error) {
+	if _, ok := p.accept("!", "not"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (expr, error) {
+	left, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok != nil && tok.kind == tokOperator && comparisonOperators[tok.text] {
+		p.pos++
+		right, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryExpr{op: tok.text, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *exprParser) parsePipe() (expr, error) {
+	operand, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	var filters []filterCall
+	for {
+		if _, ok := p.accept("|"); !ok {
+			break
+		}
+		tok := p.peek()
+		if tok == nil || tok.kind != tokIdent {
+			return nil, p.errorf("expected filter name after \"|\"")
+		}
+		p.pos++
+
+		f, ok := builtinFilters[tok.text]
+		if !ok {
+			return nil, p.errorf("unknown filter %q", tok.text)
+		}
+
+		call := filterCall{name: tok.text, filter: f}
+		if _, ok := p.accept(":"); ok {
+			for {
+				arg, err := p.parsePrimary()
+				if err != nil {
+					return nil, err
+				}
+				call.args = append(call.args, arg)
+				if _, ok := p.accept(","); !ok {
+					break
+				}
+			}
+		}
+		if len(call.args) < f.minArgs || len(call.args) > f.maxArgs {
+			return nil, p.errorf("filter %q takes %s", tok.text, f.describeArgs())
+		}
+		filters = append(filters, call)
+	}
+
+	if len(filters) == 0 {
+		return operand, nil
+	}
+	return &pipeExpr{operand: operand, filters: filters}, nil
+}
+
+func (p *exprParser) parsePrimary() (expr, error) {
+	tok := p.peek()
+	if tok == nil {
+		return nil, p.errorf("unexpected end of expression")
+	}
+	p.pos++
+
+	switch tok.kind {
+	case tokString, tokNumber:
+		return &literalExpr{value: tok.value}, nil
+	case tokOperator:
+		if tok.text == "(" {
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.accept(")"); !ok {
+				return nil, p.errorf("missing \")\"")
+			}
+			return e, nil
+		}
+		return nil, p.errorf("unexpected %q", tok.text)
+	}
+
+	switch tok.text {
+	case "true":
+		return &literalExpr{value: true}, nil
+	case "false":
+		return &literalExpr{value: false}, nil
+	case "null":
+		return &literalExpr{value: nil}, nil
+	case "and", "or", "not":
+		return nil, p.errorf("unexpected %q", tok.text)
+	}
+
+	segments := strings.Split(tok.text, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, p.errorf("invalid path %q", tok.text)
+		}
+	}
+	return &pathExpr{raw: tok.text, segments: segments}, nil
+}
+
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Line: p.at.Line, Column: p.at.Column, Message: fmt.Sprintf(format, args...)}
+}
+
+// visitPaths reports the data paths read by an expression
+func visitPaths(e expr, hasDefault bool, locals map[string]bool, add func(path string, hasDefault bool)) {
+	switch e := e.(type) {
+	case *pathExpr:
+		if locals[e.segments[0]] || loopLocals[e.segments[0]] {
+			return
+		}
+		add(e.raw, hasDefault)
+	case *pipeExpr:
+		defaulted := false
+		for _, call := range e.filters {
+			if call.name == "default" {
+				defaulted = true
+			}
+			for _, arg := range call.args {
+				visitPaths(arg, false, locals, add)
+			}
+		}
+		visitPaths(e.operand, hasDefault || defaulted, locals, add)
+	case *notExpr:
+		visitPaths(e.operand, hasDefault, locals, add)
+	case *binaryExpr:
+		visitPaths(e.left, hasDefault, locals, add)
+		visitPaths(e.right, hasDefault, locals, add)
+	}
+}
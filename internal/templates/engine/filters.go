@@ -0,0 +1,295 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+type filterFunc func(value interface{}, args []interface{}) (interface{}, error)
+
+type filter struct {
+	minArgs int
+	maxArgs int
+	apply   filterFunc
+}
+
+func (f *filter) describeArgs() string {
+	switch {
+	case f.minArgs == f.maxArgs && f.maxArgs == 0:
+		return "no arguments"
+	case f.minArgs == f.maxArgs:
+		return fmt.Sprintf("%d argument(s)", f.minArgs)
+	default:
+		return fmt.Sprintf("%d to %d arguments", f.minArgs, f.maxArgs)
+	}
+}
+
+// builtinFilters is the complete set of functions templates may call
+var builtinFilters = map[string]*filter{
+	"upper":      {0, 0, stringFilter(strings.ToUpper)},
+	"lower":      {0, 0, stringFilter(strings.ToLower)},
+	"title":      {0, 0, stringFilter(titleCase)},
+	"capitalize": {0, 0, stringFilter(capitalize)},
+	"trim":       {0, 0, stringFilter(strings.TrimSpace)},
+	"escape":     {0, 0, stringFilter(html.EscapeString)},
+	"raw":        {0, 0, rawFilter},
+	"url_encode": {0, 0, stringFilter(url.QueryEscape)},
+	"default":    {1, 1, defaultFilter},
+	"date":       {0, 2, dateFilter},
+	"currency":   {0, 1, currencyFilter},
+	"number":     {0, 1, numberFilter},
+	"truncate":   {1, 2, truncateFilter},
+	"join":       {0, 1, joinFilter},
+	"length":     {0, 0, lengthFilter},
+	"json":       {0, 0, jsonFilter},
+}
+
+// Named layouts accepted by the date filter in addition to Go layouts
+var dateLayouts = map[string]string{
+	"date":     "2006-01-02",
+	"datetime": "2006-01-02 15:04",
+	"time":     "15:04",
+	"short":    "Jan 2, 2006",
+	"long":     "January 2, 2006",
+	"rfc3339":  time.RFC3339,
+}
+
+// Layouts tried when parsing string input to the date filter
+var dateInputLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+type currencyFormat struct {
+	symbol   string
+	decimals int
+}
+
+var currencyFormats = map[string]currencyFormat{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"INR": {"₹", 2},
+	"JPY": {"¥", 0},
+	"CNY": {"¥", 2},
+	"AUD": {"A$", 2},
+	"CAD": {"CA$", 2},
+}
+
+func stringFilter(fn func(string) string) filterFunc {
+	return func(value interface{}, args []interface{}) (interface{}, error) {
+		return fn(toString(value)), nil
+	}
+}
+
+func titleCase(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		words[i] = capitalize(strings.ToLower(w))
+	}
+	return strings.Join(words, " ")
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// rawFilter leaves the value unchanged; as the last filter it opts the value
+// out of HTML escaping
+func rawFilter(value interface{}, args []interface{}) (interface{}, error) {
+	return value, nil
+}
+
+func defaultFilter(value interface{}, args []interface{}) (interface{}, error) {
+	if !truthy(value) {
+		return args[0], nil
+	}
+	return value, nil
+}
+
+// dateFilter formats a time, RFC 3339 string or Unix timestamp.
+// Arguments are an optional layout (a Go layout or a named layout) and an optional IANA time zone.
+func dateFilter(value interface{}, args []interface{}) (interface{}, error) {
+	if value == nil || value == "" {
+		return "", nil
+	}
+
+	t, err := toTime(value)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := dateLayouts["date"]
+	if len(args) > 0 {
+		layout = toString(args[0])
+		if named, ok := dateLayouts[layout]; ok {
+			layout = named
+		}
+	}
+
+	if len(args) > 1 {
+		loc, err := time.LoadLocation(toString(args[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown time zone %q", ErrInvalidFilterUsage, toString(args[1]))
+		}
+		t = t.In(loc)
+	}
+
+	return t.Format(layout), nil
+}
+
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		for _, layout := range dateInputLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+	}
+	if f, ok := toNumber(value); ok {
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: cannot parse %q as a date", ErrInvalidFilterUsage, toString(value))
+}
+
+// currencyFilter formats a number as money, e.g. 1234.5 | currency: "EUR" gives €1,234.50
+func currencyFilter(value interface{}, args []interface{}) (interface{}, error) {
+	amount, ok := toNumber(value)
+	if !ok {
+		if value == nil || value == "" {
+			return "", nil
+		}
+		return nil, fmt.Errorf("%w: %q is not a number", ErrInvalidFilterUsage, toString(value))
+	}
+
+	code := "USD"
+	if len(args) > 0 {
+		code = strings.ToUpper(toString(args[0]))
+	}
+
+	format, known := currencyFormats[code]
+	if !known {
+		format = currencyFormat{symbol: code + " ", decimals: 2}
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return sign + format.symbol + formatNumber(amount, format.decimals), nil
+}
+
+func numberFilter(value interface{}, args []interface{}) (interface{}, error) {
+	n, ok := toNumber(value)
+	if !ok {
+		if value == nil || value == "" {
+			return "", nil
+		}
+		return nil, fmt.Errorf("%w: %q is not a number", ErrInvalidFilterUsage, toString(value))
+	}
+
+	decimals := 0
+	if len(args) > 0 {
+		d, ok := toNumber(args[0])
+		if !ok || d < 0 || d > 10 {
+			return nil, fmt.Errorf("%w: decimals must be between 0 and 10", ErrInvalidFilterUsage)
+		}
+		decimals = int(d)
+	}
+
+	if n < 0 {
+		return "-" + formatNumber(-n, decimals), nil
+	}
+	return formatNumber(n, decimals), nil
+}
+
+// formatNumber formats a non-negative number with thousands separators
+func formatNumber(n float64, decimals int) string {
+	s := strconv.FormatFloat(n, 'f', decimals, 64)
+	whole, frac, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	if frac != "" {
+		b.WriteByte('.')
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+func truncateFilter(value interface{}, args []interface{}) (interface{}, error) {
+	limit, ok := toNumber(args[0])
+	if !ok || math.IsNaN(limit) || limit < 0 {
+		return nil, fmt.Errorf("%w: length must be a non-negative number", ErrInvalidFilterUsage)
+	}
+	suffix := "..."
+	if len(args) > 1 {
+		suffix = toString(args[1])
+	}
+
+	// Compare as floats so a limit too large for an int cannot overflow the slice bound
+	runes := []rune(toString(value))
+	if limit >= float64(len(runes)) {
+		return string(runes), nil
+	}
+	return string(runes[:int(limit)]) + suffix, nil
+}
+
+func joinFilter(value interface{}, args []interface{}) (interface{}, error) {
+	separator := ", "
+	if len(args) > 0 {
+		separator = toString(args[0])
+	}
+
+	items := iterable(value)
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = toString(item)
+	}
+	return strings.Join(parts, separator), nil
+}
+
+func lengthFilter(value interface{}, args []interface{}) (interface{}, error) {
+	if s, ok := value.(string); ok {
+		return utf8.RuneCountInString(s), nil
+	}
+	return len(iterable(value)), nil
+}
+
+// jsonFilter encodes the value as JSON, so strings are quoted and escaped and
+// lists and objects keep their structure inside JSON templates
+func jsonFilter(value interface{}, args []interface{}) (interface{}, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilterUsage, err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func render(t *testing.T, source string, data map[string]interface{}) (string, error) {
+	t.Helper()
+
+	tmpl, err := Compile(source)
+	if err != nil {
+		t.Fatalf("Compile(%q): %v", source, err)
+	}
+	return tmpl.Render(data)
+}
+
+func TestFilters(t *testing.T) {
+	data := map[string]interface{}{
+		"name":    "  ada lovelace  ",
+		"word":    "hELLO wORLD",
+		"html":    `<a href="x">&</a>`,
+		"query":   "a b&c=d",
+		"empty":   "",
+		"amount":  1234567.891,
+		"loss":    -42.5,
+		"tags":    []interface{}{"vip", "beta", 3},
+		"object":  map[string]interface{}{"note": "<b>"},
+		"sent_at": time.Date(2026, 3, 1, 22, 30, 0, 0, time.UTC),
+		"unix":    1772404200,
+		"rfc":     "2026-03-01T22:30:00Z",
+		"count":   "7",
+		"accent":  "héllo",
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{name: "upper", source: `{{ word | upper }}`, want: "HELLO WORLD"},
+		{name: "lower", source: `{{ word | lower }}`, want: "hello world"},
+		{name: "title", source: `{{ word | title }}`, want: "Hello World"},
+		{name: "capitalize", source: `{{ "ada lovelace" | capitalize }}`, want: "Ada lovelace"},
+		{name: "capitalize empty", source: `{{ empty | capitalize }}`, want: ""},
+		{name: "trim", source: `[{{ name | trim }}]`, want: "[ada lovelace]"},
+		{name: "chained", source: `{{ name | trim | title }}`, want: "Ada Lovelace"},
+		{name: "escape", source: `{{ html | escape }}`, want: "&lt;a href=&#34;x&#34;&gt;&amp;&lt;/a&gt;"},
+		{name: "raw", source: `{{ html | raw }}`, want: `<a href="x">&</a>`},
+		{name: "url_encode", source: `{{ query | url_encode }}`, want: "a+b%26c%3Dd"},
+		{name: "default on missing", source: `{{ missing | default: "there" }}`, want: "there"},
+		{name: "default on empty", source: `{{ empty | default: "there" }}`, want: "there"},
+		{name: "default keeps value", source: `{{ count | default: "none" }}`, want: "7"},
+		{name: "default to variable", source: `{{ missing | default: count }}`, want: "7"},
+		{name: "date", source: `{{ sent_at | date }}`, want: "2026-03-01"},
+		{name: "date named layout", source: `{{ sent_at | date: "datetime" }}`, want: "2026-03-01 22:30"},
+		{name: "date time layout", source: `{{ sent_at | date: "time" }}`, want: "22:30"},
+		{name: "date short layout", source: `{{ sent_at | date: "short" }}`, want: "Mar 1, 2026"},
+		{name: "date long layout", source: `{{ sent_at | date: "long" }}`, want: "March 1, 2026"},
+		{name: "date rfc3339 layout", source: `{{ sent_at | date: "rfc3339" }}`, want: "2026-03-01T22:30:00Z"},
+		{name: "date go layout", source: `{{ sent_at | date: "02/01/2006" }}`, want: "01/03/2026"},
+		{name: "date time zone", source: `{{ sent_at | date: "datetime", "Asia/Tokyo" }}`, want: "2026-03-02 07:30"},
+		{name: "date from string", source: `{{ rfc | date: "long" }}`, want: "March 1, 2026"},
+		{name: "date from unix", source: `{{ unix | date: "rfc3339" }}`, want: "2026-03-01T22:30:00Z"},
+		{name: "date of missing", source: `[{{ missing | date }}]`, want: "[]"},
+		{name: "currency", source: `{{ amount | currency }}`, want: "$1,234,567.89"},
+		{name: "currency code", source: `{{ amount | currency: "eur" }}`, want: "€1,234,567.89"},
+		{name: "currency GBP", source: `{{ 5 | currency: "GBP" }}`, want: "£5.00"},
+		{name: "currency without decimals", source: `{{ amount | currency: "JPY" }}`, want: "¥1,234,568"},
+		{name: "currency unknown code", source: `{{ 5 | currency: "CHF" }}`, want: "CHF 5.00"},
+		{name: "currency negative", source: `{{ loss | currency }}`, want: "-$42.50"},
+		{name: "currency numeric string", source: `{{ count | currency }}`, want: "$7.00"},
+		{name: "currency of missing", source: `[{{ missing | currency }}]`, want: "[]"},
+		{name: "number", source: `{{ amount | number }}`, want: "1,234,568"},
+		{name: "number decimals", source: `{{ amount | number: 2 }}`, want: "1,234,567.89"},
+		{name: "number negative", source: `{{ loss | number: 1 }}`, want: "-42.5"},
+		{name: "number small", source: `{{ 999 | number }}`, want: "999"},
+		{name: "join", source: `{{ tags | join }}`, want: "vip, beta, 3"},
+		{name: "join separator", source: `{{ tags | join: " / " }}`, want: "vip / beta / 3"},
+		{name: "join missing", source: `[{{ missing | join }}]`, want: "[]"},
+		{name: "length of list", source: `{{ tags | length }}`, want: "3"},
+		{name: "length of string counts runes", source: `{{ accent | length }}`, want: "5"},
+		{name: "length of missing", source: `{{ missing | length }}`, want: "0"},
+		{name: "json string", source: `{{ html | json }}`, want: `"<a href=\"x\">&</a>"`},
+		{name: "json list", source: `{{ tags | json }}`, want: `["vip","beta",3]`},
+		{name: "json object", source: `{{ object | json }}`, want: `{"note":"<b>"}`},
+		{name: "json missing", source: `{{ missing | json }}`, want: "null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := render(t, tt.source, data)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFiltersRejectInvalidUsage(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{name: "date of non-date", source: `{{ "soon" | date }}`},
+		{name: "date unknown time zone", source: `{{ 0 | date: "date", "Mars/Olympus" }}`},
+		{name: "currency of non-number", source: `{{ "lots" | currency }}`},
+		{name: "number of non-number", source: `{{ "lots" | number }}`},
+		{name: "number negative decimals", source: `{{ 1 | number: -1 }}`},
+		{name: "number too many decimals", source: `{{ 1 | number: 11 }}`},
+		{name: "number non-numeric decimals", source: `{{ 1 | number: "two" }}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := render(t, tt.source, nil)
+			if !errors.Is(err, ErrInvalidFilterUsage) {
+				t.Errorf("got error %v, want %v", err, ErrInvalidFilterUsage)
+			}
+		})
+	}
+}
+
+func TestTruncateFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		data   map[string]interface{}
+		want   string
+	}{
+		{name: "shorter than limit", source: `{{ name | truncate: 10 }}`, want: "Ada"},
+		{name: "cut with default suffix", source: `{{ name | truncate: 2 }}`, want: "Ad..."},
+		{name: "custom suffix", source: `{{ name | truncate: 1, "~" }}`, want: "A~"},
+		{name: "zero limit", source: `{{ name | truncate: 0 }}`, want: "..."},
+		{name: "multibyte runes", source: `{{ "héllo" | truncate: 2 }}`, want: "hé..."},
+		{name: "huge literal limit", source: `{{ name | truncate: 99999999999999999999999 }}`, want: "Ada"},
+		{name: "huge variable limit", source: `{{ name | truncate: limit }}`, data: map[string]interface{}{"limit": 1e19}, want: "Ada"},
+		{name: "infinite limit", source: `{{ name | truncate: limit }}`, data: map[string]interface{}{"limit": math.Inf(1)}, want: "Ada"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := map[string]interface{}{"name": "Ada"}
+			for k, v := range tt.data {
+				data[k] = v
+			}
+
+			got, err := render(t, tt.source, data)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateFilterRejectsInvalidLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		limit  interface{}
+	}{
+		{name: "negative literal", source: `{{ name | truncate: -1 }}`},
+		{name: "negative variable", source: `{{ name | truncate: limit }}`, limit: -1e19},
+		{name: "negative infinity", source: `{{ name | truncate: limit }}`, limit: math.Inf(-1)},
+		{name: "NaN", source: `{{ name | truncate: limit }}`, limit: math.NaN()},
+		{name: "not a number", source: `{{ name | truncate: "ten" }}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := render(t, tt.source, map[string]interface{}{"name": "Ada", "limit": tt.limit})
+			if !errors.Is(err, ErrInvalidFilterUsage) {
+				t.Errorf("got error %v, want %v", err, ErrInvalidFilterUsage)
+			}
+		})
+	}
+}
@@ -0,0 +1,351 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	openDelim  = "{{"
+	closeDelim = "}}"
+)
+
+type node interface{}
+
+type textNode struct {
+	text string
+}
+
+type outputNode struct {
+	expr expr
+	pos  Position
+}
+
+type ifBranch struct {
+	cond expr
+	body []node
+}
+
+type ifNode struct {
+	branches []ifBranch
+	elseBody []node
+	pos      Position
+}
+
+type eachNode struct {
+	source   expr
+	alias    string
+	body     []node
+	elseBody []node
+	pos      Position
+}
+
+type tagKind int
+
+const (
+	tagOutput tagKind = iota
+	tagComment
+	tagIf
+	tagElseIf
+	tagElse
+	tagEndIf
+	tagEach
+	tagEndEach
+)
+
+// item is a span of literal text or a single {{ }} tag
+type item struct {
+	isTag bool
+	text  string
+	kind  tagKind
+	pos   Position
+}
+
+// scan splits the source into text and tags, tracking positions for errors
+func scan(source string) ([]item, error) {
+	var items []item
+	line, col := 1, 1
+
+	advance := func(s string) {
+		for _, r := range s {
+			if r == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+		}
+	}
+
+	rest := source
+	for len(rest) > 0 {
+		start := strings.Index(rest, openDelim)
+		if start < 0 {
+			items = append(items, item{text: rest, pos: Position{line, col}})
+			break
+		}
+		if start > 0 {
+			items = append(items, item{text: rest[:start], pos: Position{line, col}})
+			advance(rest[:start])
+			rest = rest[start:]
+		}
+
+		tagPos := Position{line, col}
+		end := findClose(rest[len(openDelim):])
+		if end < 0 {
+			return nil, &SyntaxError{Line: tagPos.Line, Column: tagPos.Column, Message: "unclosed tag, missing \"}}\""}
+		}
+
+		raw := rest[:len(openDelim)+end+len(closeDelim)]
+		content := strings.TrimSpace(rest[len(openDelim) : len(openDelim)+end])
+		kind, body, err := classifyTag(content, tagPos)
+		if err != nil {
+			return nil, err
+		}
+		if kind != tagComment {
+			items = append(items, item{isTag: true, text: body, kind: kind, pos: tagPos})
+		}
+
+		advance(raw)
+		rest = rest[len(raw):]
+	}
+
+	return items, nil
+}
+
+// findClose returns the index of the closing delimiter, skipping quoted strings
+func findClose(s string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(s[i:], closeDelim):
+			return i
+		}
+	}
+	return -1
+}
+
+func classifyTag(content string, pos Position) (tagKind, string, error) {
+	switch {
+	case strings.HasPrefix(content, "!"):
+		return tagComment, "", nil
+	case content == "":
+		return 0, "", &SyntaxError{Line: pos.Line, Column: pos.Column, Message: "empty tag"}
+	case content == "else":
+		return tagElse, "", nil
+	case content == "/if":
+		return tagEndIf, "", nil
+	case content == "/each":
+		return tagEndEach, "", nil
+	}
+
+	keyword, rest := splitKeyword(content)
+	switch keyword {
+	case "#if":
+		return tagIf, rest, nil
+	case "#each":
+		return tagEach, rest, nil
+	case "else":
+		if k, r := splitKeyword(rest); k == "if" {
+			return tagElseIf, r, nil
+		}
+	}
+
+	if strings.HasPrefix(content, "#") || strings.HasPrefix(content, "/") {
+		return 0, "", &SyntaxError{Line: pos.Line, Column: pos.Column, Message: fmt.Sprintf("unknown block tag %q", content)}
+	}
+	return tagOutput, content, nil
+}
+
+func splitKeyword(s string) (string, string) {
+	if i := strings.IndexAny(s, " \t\r\n"); i >= 0 {
+		return s[:i], strings.TrimSpace(s[i:])
+	}
+	return s, ""
+}
+
+type parser struct {
+	items []item
+	pos   int
+	depth int
+}
+
+func (p *parser) parseTemplate() ([]node, error) {
+	nodes, term, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	if term != nil {
+		return nil, p.errorf(term.pos, "unexpected %s", describeTag(term.kind))
+	}
+	return nodes, nil
+}
+
+// parseList parses nodes until one of the terminator tags or the end of input.
+// The terminator, if any, is returned and consumed.
+func (p *parser) parseList(terminators ...tagKind) ([]node, *item, error) {
+	var nodes []node
+	for p.pos < len(p.items) {
+		it := p.items[p.pos]
+		p.pos++
+
+		if !it.isTag {
+			nodes = append(nodes, &textNode{text: it.text})
+			continue
+		}
+
+		switch it.kind {
+		case tagOutput:
+			e, err := parseExpression(it.text, it.pos)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, &outputNode{expr: e, pos: it.pos})
+		case tagIf:
+			n, err := p.parseIf(it)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, n)
+		case tagEach:
+			n, err := p.parseEach(it)
+			if err != nil {
+				return nil, nil, err
+			}
+			nodes = append(nodes, n)
+		default:
+			for _, t := range terminators {
+				if it.kind == t {
+					return nodes, &it, nil
+				}
+			}
+			return nil, nil, p.errorf(it.pos, "unexpected %s", describeTag(it.kind))
+		}
+	}
+	return nodes, nil, nil
+}
+
+func (p *parser) enter(pos Position) error {
+	p.depth++
+	if p.depth > MaxNestingDepth {
+		return p.errorf(pos, "blocks nested deeper than %d levels", MaxNestingDepth)
+	}
+	return nil
+}
+
+func (p *parser) parseIf(open item) (*ifNode, error) {
+	if err := p.enter(open.pos); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	n := &ifNode{pos: open.pos}
+	condText, condPos := open.text, open.pos
+	for {
+		if condText == "" {
+			return nil, p.errorf(condPos, "missing condition")
+		}
+		cond, err := parseExpression(condText, condPos)
+		if err != nil {
+			return nil, err
+		}
+
+		body, term, err := p.parseList(tagElseIf, tagElse, tagEndIf)
+		if err != nil {
+			return nil, err
+		}
+		if term == nil {
+			return nil, p.errorf(open.pos, "unclosed {{#if}}")
+		}
+		n.branches = append(n.branches, ifBranch{cond: cond, body: body})
+
+		switch term.kind {
+		case tagElseIf:
+			condText, condPos = term.text, term.pos
+			continue
+		case tagElse:
+			elseBody, end, err := p.parseList(tagEndIf)
+			if err != nil {
+				return nil, err
+			}
+			if end == nil {
+				return nil, p.errorf(open.pos, "unclosed {{#if}}")
+			}
+			n.elseBody = elseBody
+		}
+		return n, nil
+	}
+}
+
+func (p *parser) parseEach(open item) (*eachNode, error) {
+	if err := p.enter(open.pos); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	sourceText, alias := open.text, ""
+	if i := strings.LastIndex(sourceText, " as "); i >= 0 {
+		alias = strings.TrimSpace(sourceText[i+len(" as "):])
+		sourceText = strings.TrimSpace(sourceText[:i])
+		if !isIdentifier(alias) || strings.Contains(alias, ".") || isReserved(alias) {
+			return nil, p.errorf(open.pos, "invalid loop variable %q", alias)
+		}
+	}
+	if sourceText == "" {
+		return nil, p.errorf(open.pos, "missing list to iterate")
+	}
+
+	source, err := parseExpression(sourceText, open.pos)
+	if err != nil {
+		return nil, err
+	}
+
+	body, term, err := p.parseList(tagElse, tagEndEach)
+	if err != nil {
+		return nil, err
+	}
+	if term == nil {
+		return nil, p.errorf(open.pos, "unclosed {{#each}}")
+	}
+
+	n := &eachNode{source: source, alias: alias, body: body, pos: open.pos}
+	if term.kind == tagElse {
+		elseBody, end, err := p.parseList(tagEndEach)
+		if err != nil {
+			return nil, err
+		}
+		if end == nil {
+			return nil, p.errorf(open.pos, "unclosed {{#each}}")
+		}
+		n.elseBody = elseBody
+	}
+	return n, nil
+}
+
+func (p *parser) errorf(pos Position, format string, args ...interface{}) error {
+	return &SyntaxError{Line: pos.Line, Column: pos.Column, Message: fmt.Sprintf(format, args...)}
+}
+
+func describeTag(kind tagKind) string {
+	switch kind {
+	case tagElse:
+		return "{{else}}"
+	case tagElseIf:
+		return "{{else if}}"
+	case tagEndIf:
+		return "{{/if}}"
+	case tagEndEach:
+		return "{{/each}}"
+	default:
+		return "tag"
+	}
+}
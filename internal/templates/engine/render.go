@@ -0,0 +1,419 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scope holds the names bound by an enclosing loop
+type scope map[string]interface{}
+
+type renderer struct {
+	root       map[string]interface{}
+	scopes     []scope
+	out        strings.Builder
+	iterations int
+	// escapeHTML escapes output values for HTML content
+	escapeHTML bool
+}
+
+func newRenderer(data map[string]interface{}) *renderer {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return &renderer{root: data}
+}
+
+func (r *renderer) write(s string) error {
+	if r.out.Len()+len(s) > MaxOutputSize {
+		return ErrOutputLimit
+	}
+	r.out.WriteString(s)
+	return nil
+}
+
+func (r *renderer) renderNodes(nodes []node) error {
+	for _, n := range nodes {
+		if err := r.renderNode(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *renderer) renderNode(n node) error {
+	switch n := n.(type) {
+	case *textNode:
+		return r.write(n.text)
+	case *outputNode:
+		value, err := r.eval(n.expr)
+		if err != nil {
+			return renderError(n.pos, err)
+		}
+		if r.escapeHTML && !escapedOutput(n.expr) {
+			return r.write(html.EscapeString(toString(value)))
+		}
+		return r.write(toString(value))
+	case *ifNode:
+		for _, branch := range n.branches {
+			value, err := r.eval(branch.cond)
+			if err != nil {
+				return renderError(n.pos, err)
+			}
+			if truthy(value) {
+				return r.renderNodes(branch.body)
+			}
+		}
+		return r.renderNodes(n.elseBody)
+	case *eachNode:
+		return r.renderEach(n)
+	default:
+		return fmt.Errorf("%w: unknown node %T", ErrRender, n)
+	}
+}
+
+func (r *renderer) renderEach(n *eachNode) error {
+	value, err := r.eval(n.source)
+	if err != nil {
+		return renderError(n.pos, err)
+	}
+
+	items := iterable(value)
+	if len(items) == 0 {
+		return r.renderNodes(n.elseBody)
+	}
+
+	for i, item := range items {
+		r.iterations++
+		if r.iterations > MaxLoopIterations {
+			return ErrIterationLimit
+		}
+
+		s := scope{
+			"this":   item,
+			"@index": i,
+			"@first": i == 0,
+			"@last":  i == len(items)-1,
+		}
+		if n.alias != "" {
+			s[n.alias] = item
+		}
+
+		r.scopes = append(r.scopes, s)
+		err := r.renderNodes(n.body)
+		r.scopes = r.scopes[:len(r.scopes)-1]
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapedOutput reports whether an output expression ends in the raw or escape
+// filter, so HTML rendering leaves its value as is
+func escapedOutput(e expr) bool {
+	pipe, ok := e.(*pipeExpr)
+	if !ok || len(pipe.filters) == 0 {
+		return false
+	}
+	last := pipe.filters[len(pipe.filters)-1].name
+	return last == "raw" || last == "escape"
+}
+
+func renderError(pos Position, err error) error {
+	return fmt.Errorf("%w: line %d, column %d: %w", ErrRender, pos.Line, pos.Column, err)
+}
+
+func (r *renderer) eval(e expr) (interface{}, error) {
+	switch e := e.(type) {
+	case *literalExpr:
+		return e.value, nil
+	case *pathExpr:
+		value, _ := r.lookup(e.segments)
+		return value, nil
+	case *pipeExpr:
+		value, err := r.eval(e.operand)
+		if err != nil {
+			return nil, err
+		}
+		for _, call := range e.filters {
+			args := make([]interface{}, len(call.args))
+			for i, arg := range call.args {
+				if args[i], err = r.eval(arg); err != nil {
+					return nil, err
+				}
+			}
+			if value, err = call.filter.apply(value, args); err != nil {
+				return nil, fmt.Errorf("filter %q: %w", call.name, err)
+			}
+		}
+		return value, nil
+	case *notExpr:
+		value, err := r.eval(e.operand)
+		if err != nil {
+			return nil, err
+		}
+		return !truthy(value), nil
+	case *binaryExpr:
+		left, err := r.eval(e.left)
+		if err != nil {
+			return nil, err
+		}
+		switch e.op {
+		case "and":
+			if !truthy(left) {
+				return false, nil
+			}
+			right, err := r.eval(e.right)
+			return truthy(right), err
+		case "or":
+			if truthy(left) {
+				return true, nil
+			}
+			right, err := r.eval(e.right)
+			return truthy(right), err
+		}
+		right, err := r.eval(e.right)
+		if err != nil {
+			return nil, err
+		}
+		return compareValues(e.op, left, right), nil
+	default:
+		return nil, fmt.Errorf("unknown expression %T", e)
+	}
+}
+
+// lookup resolves a path against loop scopes, innermost first, then the root data
+func (r *renderer) lookup(segments []string) (interface{}, bool) {
+	var current interface{}
+	found := false
+	for i := len(r.scopes) - 1; i >= 0; i-- {
+		if value, ok := r.scopes[i][segments[0]]; ok {
+			current, found = value, true
+			break
+		}
+	}
+	if !found {
+		if segments[0] == "this" {
+			current, found = r.root, true
+		} else if current, found = r.root[segments[0]]; !found {
+			return nil, false
+		}
+	}
+
+	for _, segment := range segments[1:] {
+		next, ok := child(current, segment)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return current, true
+}
+
+// child reads a map key or list index. Only plain data is traversed; struct
+// fields and methods are never accessed.
+func child(value interface{}, key string) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		child, ok := v[key]
+		return child, ok
+	case map[string]string:
+		child, ok := v[key]
+		return child, ok
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(v) {
+			return nil, false
+		}
+		return v[index], true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		child := rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+		if !child.IsValid() {
+			return nil, false
+		}
+		return child.Interface(), true
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= rv.Len() {
+			return nil, false
+		}
+		return rv.Index(index).Interface(), true
+	}
+	return nil, false
+}
+
+// iterable converts a list, or a map in key order, into loop items
+func iterable(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		items := make([]interface{}, len(keys))
+		for i, k := range keys {
+			items[i] = map[string]interface{}{"key": k, "value": v[k]}
+		}
+		return items
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items
+}
+
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+	if f, ok := toNumber(value); ok {
+		return f != 0
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() > 0
+	}
+	return true
+}
+
+func compareValues(op string, left, right interface{}) bool {
+	cmp, comparable := order(left, right)
+	switch op {
+	case "==":
+		return comparable && cmp == 0 || !comparable && reflect.DeepEqual(left, right)
+	case "!=":
+		return !(comparable && cmp == 0 || !comparable && reflect.DeepEqual(left, right))
+	}
+	if !comparable {
+		return false
+	}
+	switch op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// order compares numbers numerically and strings lexically
+func order(a, b interface{}) (int, bool) {
+	if af, ok := toNumber(a); ok {
+		if bf, ok := toNumber(b); ok {
+			switch {
+			case af < bf:
+				return -1, true
+			case af > bf:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	as, aok := a.(string)
+	bs, bok := b.(string)
+	if aok && bok {
+		return strings.Compare(as, bs), true
+	}
+	ab, aok := a.(bool)
+	bb, bok := b.(bool)
+	if aok && bok && ab == bb {
+		return 0, true
+	}
+	return 0, false
+}
+
+// toNumber converts numeric values and numeric strings to float64
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+	}
+	return 0, false
+}
+
+// toString formats a value for output
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int, int32, int64, uint, uint32, uint64:
+		return fmt.Sprint(v)
+	case json.Number:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
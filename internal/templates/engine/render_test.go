@@ -0,0 +1,50 @@
+package engine
+
+import "testing"
+
+func TestRenderHTMLEscapesValues(t *testing.T) {
+	data := map[string]interface{}{
+		"name":   `<script>alert("x")</script>`,
+		"banner": `<b>Sale</b>`,
+		"tags":   []interface{}{"a&b", "<c>"},
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		html   string
+		text   string
+	}{
+		{name: "value", source: `<p>Hi {{ name }}</p>`, html: `<p>Hi &lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</p>`, text: `<p>Hi <script>alert("x")</script></p>`},
+		{name: "filtered value", source: `{{ banner | upper }}`, html: `&lt;B&gt;SALE&lt;/B&gt;`, text: `<B>SALE</B>`},
+		{name: "raw opts out", source: `{{ banner | raw }}`, html: `<b>Sale</b>`, text: `<b>Sale</b>`},
+		{name: "raw before another filter", source: `{{ banner | raw | upper }}`, html: `&lt;B&gt;SALE&lt;/B&gt;`, text: `<B>SALE</B>`},
+		{name: "escape is not doubled", source: `{{ banner | escape }}`, html: `&lt;b&gt;Sale&lt;/b&gt;`, text: `&lt;b&gt;Sale&lt;/b&gt;`},
+		{name: "loop values", source: `{{#each tags}}[{{ this }}]{{/each}}`, html: `[a&amp;b][&lt;c&gt;]`, text: `[a&b][<c>]`},
+		{name: "template markup is kept", source: `<a href="/x">{{ "&" }}</a>`, html: `<a href="/x">&amp;</a>`, text: `<a href="/x">&</a>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := Compile(tt.source)
+			if err != nil {
+				t.Fatalf("Compile: %v", err)
+			}
+
+			html, err := tmpl.RenderHTML(data)
+			if err != nil {
+				t.Fatalf("RenderHTML: %v", err)
+			}
+			if html != tt.html {
+				t.Errorf("RenderHTML = %q, want %q", html, tt.html)
+			}
+
+			text, err := tmpl.Render(data)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if text != tt.text {
+				t.Errorf("Render = %q, want %q", text, tt.text)
+			}
+		})
+	}
+}
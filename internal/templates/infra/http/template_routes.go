@@ -1,17 +1,25 @@
 package templateroutes
 
 import (
+	"errors"
 	"net/http"
 
 	"getnoti.com/internal/shared/handler"
 	"getnoti.com/internal/shared/middleware"
 	"getnoti.com/internal/shared/utils"
+	"getnoti.com/internal/templates/engine"
 	repository "getnoti.com/internal/templates/repos"
 	repos "getnoti.com/internal/templates/repos/implementations"
+	templateServices "getnoti.com/internal/templates/services"
 	createtemplate "getnoti.com/internal/templates/usecases/create_template"
 	gettemplate "getnoti.com/internal/templates/usecases/get_template"
+	gettemplateversions "getnoti.com/internal/templates/usecases/get_template_versions"
 	gettemplates "getnoti.com/internal/templates/usecases/get_templates"
+	publishtemplate "getnoti.com/internal/templates/usecases/publish_template"
+	rendertemplate "getnoti.com/internal/templates/usecases/render_template"
+	rollbacktemplate "getnoti.com/internal/templates/usecases/rollback_template"
 	updatetemplate "getnoti.com/internal/templates/usecases/update_template"
+	tenantDomain "getnoti.com/internal/tenants/domain"
 	"getnoti.com/pkg/db"
 	"github.com/go-chi/chi/v5"
 )
@@ -58,7 +66,7 @@ func (h *Handlers) CreateTemplate(w http.ResponseWriter, r *http.Request) {
 
 	res, err := createTemplateController.CreateTemplate(r.Context(), req)
 	if err != nil {
-		h.BaseHandler.HandleError(w, "Failed to create template", err, http.StatusInternalServerError)
+		h.BaseHandler.HandleError(w, "Failed to create template", err, templateErrorStatus(err))
 		return
 	}
 
@@ -86,7 +94,7 @@ func (h *Handlers) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
 
 	res, err := updateTemplateController.UpdateTemplate(r.Context(), req)
 	if err != nil {
-		h.BaseHandler.HandleError(w, "Failed to update template", err, http.StatusInternalServerError)
+		h.BaseHandler.HandleError(w, "Failed to update template", err, templateErrorStatus(err))
 		return
 	}
 
@@ -145,6 +153,160 @@ func (h *Handlers) GetTemplates(w http.ResponseWriter, r *http.Request) {
 	h.BaseHandler.RespondWithJSON(w, res)
 }
 
+func (h *Handlers) GetTemplateVersions(w http.ResponseWriter, r *http.Request) {
+	templateRepo, err := h.getTemplateRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	getTemplateVersionsUseCase := gettemplateversions.NewGetTemplateVersionsUseCase(templateRepo)
+	getTemplateVersionsController := gettemplateversions.NewGetTemplateVersionsController(getTemplateVersionsUseCase)
+
+	var req gettemplateversions.GetTemplateVersionsRequest
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := getTemplateVersionsController.GetTemplateVersions(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get template versions", err, templateErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) PublishTemplate(w http.ResponseWriter, r *http.Request) {
+	templateRepo, err := h.getTemplateRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	publishTemplateUseCase := publishtemplate.NewPublishTemplateUseCase(templateRepo)
+	publishTemplateController := publishtemplate.NewPublishTemplateController(publishTemplateUseCase)
+
+	// The body is optional; without a version the current draft is published
+	var req publishtemplate.PublishTemplateRequest
+	if r.ContentLength != 0 && !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := publishTemplateController.PublishTemplate(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to publish template", err, templateErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) RollbackTemplate(w http.ResponseWriter, r *http.Request) {
+	templateRepo, err := h.getTemplateRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	rollbackTemplateUseCase := rollbacktemplate.NewRollbackTemplateUseCase(templateRepo)
+	rollbackTemplateController := rollbacktemplate.NewRollbackTemplateController(rollbackTemplateUseCase)
+
+	// The body is optional; without a version the previous version is restored
+	var req rollbacktemplate.RollbackTemplateRequest
+	if r.ContentLength != 0 && !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := rollbackTemplateController.RollbackTemplate(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to roll back template", err, templateErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) RenderTemplate(w http.ResponseWriter, r *http.Request) {
+	templateRepo, err := h.getTemplateRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	renderTemplateUseCase := rendertemplate.NewRenderTemplateUseCase(templateRepo)
+	renderTemplateController := rendertemplate.NewRenderTemplateController(renderTemplateUseCase)
+
+	// The body is optional; without one every channel is rendered with no variables
+	var req rendertemplate.RenderTemplateRequest
+	if r.ContentLength != 0 && !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	if err := utils.AddIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process ID", err, http.StatusInternalServerError)
+		return
+	}
+	// A stored template is rendered as saved, never with inline content
+	req.Template = nil
+
+	res, err := renderTemplateController.RenderTemplate(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to render template", err, templateErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+func (h *Handlers) RenderInlineTemplate(w http.ResponseWriter, r *http.Request) {
+	templateRepo, err := h.getTemplateRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	renderTemplateUseCase := rendertemplate.NewRenderTemplateUseCase(templateRepo)
+	renderTemplateController := rendertemplate.NewRenderTemplateController(renderTemplateUseCase)
+
+	var req rendertemplate.RenderTemplateRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	// Inline content is rendered as given, never a stored template
+	req.ID = ""
+
+	res, err := renderTemplateController.RenderTemplate(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to render template", err, templateErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+// templateErrorStatus maps template errors to HTTP status codes
+func templateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, engine.ErrCompile), errors.Is(err, engine.ErrTemplateTooLarge), errors.Is(err, templateServices.ErrUnknownChannel),
+		errors.Is(err, tenantDomain.ErrInvalidLocale), errors.Is(err, rendertemplate.ErrTemplateRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, repos.ErrTemplateNotFound), errors.Is(err, repos.ErrTemplateVersionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, rollbacktemplate.ErrNoPreviousVersion):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
 // NewRouter sets up the router with all routes
 func NewRouter(dbManager *db.Manager) *chi.Mux {
 	b := handler.NewBaseHandler(dbManager)
@@ -153,9 +315,14 @@ func NewRouter(dbManager *db.Manager) *chi.Mux {
 
 	// Set up routes
 	r.Post("/", h.CreateTemplate)
+	r.Post("/render", h.RenderInlineTemplate)
 	r.Put("/{id}", h.UpdateTemplate)
 	r.Get("/", h.GetTemplates)
 	r.Get("/{id}", h.GetTemplate)
+	r.Get("/{id}/versions", h.GetTemplateVersions)
+	r.Post("/{id}/publish", h.PublishTemplate)
+	r.Post("/{id}/rollback", h.RollbackTemplate)
+	r.Post("/{id}/render", h.RenderTemplate)
 
 	return r
 }
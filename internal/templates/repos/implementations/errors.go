@@ -3,8 +3,9 @@ package repos
 import "errors"
 
 var (
-    ErrTemplateNotFound = errors.New("template not found")
-    ErrTemplateCreateFailed = errors.New("template creation failed")
-    ErrTemplateUpdateFailed = errors.New("template update failed")
-    ErrUnexpected = errors.New("unexpected error occurred")
+	ErrTemplateNotFound        = errors.New("template not found")
+	ErrTemplateVersionNotFound = errors.New("template version not found")
+	ErrTemplateCreateFailed    = errors.New("template creation failed")
+	ErrTemplateUpdateFailed    = errors.New("template update failed")
+	ErrUnexpected              = errors.New("unexpected error occurred")
 )
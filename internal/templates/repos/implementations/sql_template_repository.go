@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"getnoti.com/internal/templates/domain"
 	"getnoti.com/internal/templates/repos"
+	tenantDomain "getnoti.com/internal/tenants/domain"
 	"getnoti.com/pkg/db"
 )
 
@@ -20,50 +21,76 @@ func NewTemplateRepository(db db.Database) repos.TemplateRepository {
 	return &sqlTemplateRepository{db: db}
 }
 
-// CreateTemplate inserts a new template into the database
-func (r *sqlTemplateRepository) CreateTemplate(ctx context.Context, tmpl *domain.Template) error {
-	query := `INSERT INTO templates (id, name, content, is_public, variables) VALUES (?, ?, ?, ?,?)`
+const templateColumns = `id, name, content, is_public, variables, variants, translations, draft_version, published_version`
+
+// CreateTemplate inserts a new template and its initial version in one transaction
+func (r *sqlTemplateRepository) CreateTemplate(ctx context.Context, tmpl *domain.Template, version *domain.TemplateVersion) error {
 	variables, err := json.Marshal(tmpl.Variables)
 	if err != nil {
 		return fmt.Errorf("failed to marshal variables: %w", err)
 	}
-	_, err = r.db.Exec(ctx, query, tmpl.ID, tmpl.Name, tmpl.Content, tmpl.IsPublic, variables)
+
+	variants, err := marshalVariants(tmpl.Variants)
+	if err != nil {
+		return err
+	}
+	translations, err := marshalTranslations(tmpl.Translations)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO templates (id, name, content, is_public, variables, variants, translations, draft_version, published_version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = tx.Exec(ctx, query, tmpl.ID, tmpl.Name, tmpl.Content, tmpl.IsPublic, variables, variants, translations, tmpl.DraftVersion, tmpl.PublishedVersion)
 	if err != nil {
 		return fmt.Errorf("failed to create template: %w", err)
 	}
+
+	if err := insertTemplateVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 	return nil
 }
 
 // GetTemplateByID retrieves a template by its ID
 func (r *sqlTemplateRepository) GetTemplateByID(ctx context.Context, templateID string) (*domain.Template, error) {
-	query := `SELECT id, name, content, is_public, variables FROM templates WHERE id = ?`
+	query := `SELECT ` + templateColumns + ` FROM templates WHERE id = ?`
 	row := r.db.QueryRow(ctx, query, templateID)
-	tmpl := &domain.Template{}
-	var variables []byte
-	err := row.Scan(&tmpl.ID, &tmpl.Name, &tmpl.Content, &tmpl.IsPublic, &variables)
+	tmpl, err := scanTemplate(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrTemplateNotFound
 		}
 		return nil, fmt.Errorf("failed to get template: %w", err)
 	}
-
-	err = json.Unmarshal(variables, &tmpl.Variables)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
-	}
-
 	return tmpl, nil
 }
 
 // UpdateTemplate updates an existing template in the database
 func (r *sqlTemplateRepository) UpdateTemplate(ctx context.Context, tmpl *domain.Template) error {
-	query := `UPDATE templates SET name = ?, content = ?, is_public = ?, variables = ? WHERE id = ?`
+	query := `UPDATE templates SET name = ?, content = ?, is_public = ?, variables = ?, variants = ?, translations = ?, draft_version = ?, published_version = ? WHERE id = ?`
 	variables, err := json.Marshal(tmpl.Variables)
 	if err != nil {
 		return fmt.Errorf("failed to marshal variables: %w", err)
 	}
-	_, err = r.db.Exec(ctx, query, tmpl.Name, tmpl.Content, tmpl.IsPublic, variables, tmpl.ID)
+	variants, err := marshalVariants(tmpl.Variants)
+	if err != nil {
+		return err
+	}
+	translations, err := marshalTranslations(tmpl.Translations)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(ctx, query, tmpl.Name, tmpl.Content, tmpl.IsPublic, variables, variants, translations, tmpl.DraftVersion, tmpl.PublishedVersion, tmpl.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update template: %w", err)
 	}
@@ -72,7 +99,7 @@ func (r *sqlTemplateRepository) UpdateTemplate(ctx context.Context, tmpl *domain
 
 // GetTemplatesByTenantID retrieves templates by tenant ID
 func (r *sqlTemplateRepository) GetTemplates(ctx context.Context) ([]domain.Template, error) {
-	query := `SELECT id, name, content, is_public, variables FROM templates`
+	query := `SELECT ` + templateColumns + ` FROM templates`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query templates: %w", err)
@@ -81,24 +108,208 @@ func (r *sqlTemplateRepository) GetTemplates(ctx context.Context) ([]domain.Temp
 
 	var templates []domain.Template
 	for rows.Next() {
-		var tmpl domain.Template
-		var variables []byte
-		err := rows.Scan(&tmpl.ID, &tmpl.Name, &tmpl.Content, &tmpl.IsPublic, &variables)
+		tmpl, err := scanTemplate(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan template: %w", err)
 		}
+		templates = append(templates, *tmpl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating template rows: %w", err)
+	}
+	return templates, nil
+}
 
-		err = json.Unmarshal(variables, &tmpl.Variables)
-		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
+// CreateTemplateVersion inserts a new version and updates the template row in one transaction
+func (r *sqlTemplateRepository) CreateTemplateVersion(ctx context.Context, tmpl *domain.Template, version *domain.TemplateVersion) error {
+	variables, err := json.Marshal(tmpl.Variables)
+	if err != nil {
+		return fmt.Errorf("failed to marshal variables: %w", err)
+	}
+
+	variants, err := marshalVariants(tmpl.Variants)
+	if err != nil {
+		return err
+	}
+	translations, err := marshalTranslations(tmpl.Translations)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertTemplateVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	query := `UPDATE templates SET name = ?, content = ?, is_public = ?, variables = ?, variants = ?, translations = ?, draft_version = ?, published_version = ? WHERE id = ?`
+	_, err = tx.Exec(ctx, query, tmpl.Name, tmpl.Content, tmpl.IsPublic, variables, variants, translations, tmpl.DraftVersion, tmpl.PublishedVersion, tmpl.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetTemplateVersion retrieves a single version of a template
+func (r *sqlTemplateRepository) GetTemplateVersion(ctx context.Context, templateID string, version int) (*domain.TemplateVersion, error) {
+	query := `SELECT id, template_id, version, content, variables, variants, translations, created_at FROM template_versions WHERE template_id = ? AND version = ?`
+	row := r.db.QueryRow(ctx, query, templateID, version)
+	v, err := scanTemplateVersion(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTemplateVersionNotFound
 		}
+		return nil, fmt.Errorf("failed to get template version: %w", err)
+	}
+	return v, nil
+}
 
-		templates = append(templates, tmpl)
+// GetTemplateVersions retrieves the version history of a template, newest first
+func (r *sqlTemplateRepository) GetTemplateVersions(ctx context.Context, templateID string) ([]domain.TemplateVersion, error) {
+	query := `SELECT id, template_id, version, content, variables, variants, translations, created_at FROM template_versions WHERE template_id = ? ORDER BY version DESC`
+	rows, err := r.db.Query(ctx, query, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query template versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []domain.TemplateVersion{}
+	for rows.Next() {
+		v, err := scanTemplateVersion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template version: %w", err)
+		}
+		versions = append(versions, *v)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating template rows: %w", err)
+		return nil, fmt.Errorf("error iterating template version rows: %w", err)
 	}
-	return templates, nil
+	return versions, nil
 }
 
+// SetPublishedVersion moves the template's published pointer
+func (r *sqlTemplateRepository) SetPublishedVersion(ctx context.Context, templateID string, version int) error {
+	result, err := r.db.Exec(ctx, `UPDATE templates SET published_version = ? WHERE id = ?`, version, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to publish template version: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+func insertTemplateVersion(ctx context.Context, tx db.Transaction, version *domain.TemplateVersion) error {
+	variables, err := json.Marshal(version.Variables)
+	if err != nil {
+		return fmt.Errorf("failed to marshal variables: %w", err)
+	}
+
+	variants, err := marshalVariants(version.Variants)
+	if err != nil {
+		return err
+	}
+	translations, err := marshalTranslations(version.Translations)
+	if err != nil {
+		return err
+	}
 
+	query := `INSERT INTO template_versions (id, template_id, version, content, variables, variants, translations, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = tx.Exec(ctx, query, version.ID, version.TemplateID, version.Version, version.Content, variables, variants, translations, version.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create template version: %w", err)
+	}
+	return nil
+}
+
+// marshalVariants stores templates without variants as an empty object
+func marshalVariants(variants map[tenantDomain.ChannelType]domain.ChannelContent) ([]byte, error) {
+	if variants == nil {
+		variants = map[tenantDomain.ChannelType]domain.ChannelContent{}
+	}
+	data, err := json.Marshal(variants)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal variants: %w", err)
+	}
+	return data, nil
+}
+
+// marshalTranslations stores templates without translations as an empty object
+func marshalTranslations(translations map[string]domain.TemplateTranslation) ([]byte, error) {
+	if translations == nil {
+		translations = map[string]domain.TemplateTranslation{}
+	}
+	data, err := json.Marshal(translations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal translations: %w", err)
+	}
+	return data, nil
+}
+
+func scanTemplate(scanner interface{}) (*domain.Template, error) {
+	tmpl := &domain.Template{}
+	var variables, variants, translations []byte
+	dest := []interface{}{&tmpl.ID, &tmpl.Name, &tmpl.Content, &tmpl.IsPublic, &variables, &variants, &translations, &tmpl.DraftVersion, &tmpl.PublishedVersion}
+
+	var err error
+	switch s := scanner.(type) {
+	case *sql.Row:
+		err = s.Scan(dest...)
+	case *sql.Rows:
+		err = s.Scan(dest...)
+	default:
+		return nil, fmt.Errorf("unsupported scanner type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(variables, &tmpl.Variables); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
+	}
+	if err := json.Unmarshal(variants, &tmpl.Variants); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variants: %w", err)
+	}
+	if err := json.Unmarshal(translations, &tmpl.Translations); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal translations: %w", err)
+	}
+	return tmpl, nil
+}
+
+func scanTemplateVersion(scanner interface{}) (*domain.TemplateVersion, error) {
+	v := &domain.TemplateVersion{}
+	var variables, variants, translations []byte
+	dest := []interface{}{&v.ID, &v.TemplateID, &v.Version, &v.Content, &variables, &variants, &translations, &v.CreatedAt}
+
+	var err error
+	switch s := scanner.(type) {
+	case *sql.Row:
+		err = s.Scan(dest...)
+	case *sql.Rows:
+		err = s.Scan(dest...)
+	default:
+		return nil, fmt.Errorf("unsupported scanner type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(variables, &v.Variables); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
+	}
+	if err := json.Unmarshal(variants, &v.Variants); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variants: %w", err)
+	}
+	if err := json.Unmarshal(translations, &v.Translations); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal translations: %w", err)
+	}
+	return v, nil
+}
@@ -1,13 +1,21 @@
 package repos
 
 import (
-    "context"
-    "getnoti.com/internal/templates/domain"
+	"context"
+	"getnoti.com/internal/templates/domain"
 )
 
 type TemplateRepository interface {
-    CreateTemplate(ctx context.Context, tmpl *domain.Template) error
-    GetTemplateByID(ctx context.Context, templateID string) (*domain.Template, error)
-    UpdateTemplate(ctx context.Context, tmpl *domain.Template) error
-    GetTemplates(ctx context.Context)([]domain.Template, error)
+	// CreateTemplate stores a new template together with its initial version
+	CreateTemplate(ctx context.Context, tmpl *domain.Template, version *domain.TemplateVersion) error
+	GetTemplateByID(ctx context.Context, templateID string) (*domain.Template, error)
+	UpdateTemplate(ctx context.Context, tmpl *domain.Template) error
+	GetTemplates(ctx context.Context) ([]domain.Template, error)
+
+	// CreateTemplateVersion stores a new version and updates the template's content and pointers atomically
+	CreateTemplateVersion(ctx context.Context, tmpl *domain.Template, version *domain.TemplateVersion) error
+	GetTemplateVersion(ctx context.Context, templateID string, version int) (*domain.TemplateVersion, error)
+	// GetTemplateVersions returns the template's versions, newest first
+	GetTemplateVersions(ctx context.Context, templateID string) ([]domain.TemplateVersion, error)
+	SetPublishedVersion(ctx context.Context, templateID string, version int) error
 }
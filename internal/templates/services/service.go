@@ -2,84 +2,429 @@ package templates
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"getnoti.com/internal/notifications/domain"
 	templates "getnoti.com/internal/templates/domain"
+	"getnoti.com/internal/templates/engine"
 	"getnoti.com/internal/templates/repos"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	tenantRepos "getnoti.com/internal/tenants/repos"
 	tenantServices "getnoti.com/internal/tenants/services"
 	"getnoti.com/pkg/logger"
 )
 
+var (
+	ErrUnknownChannel = errors.New("unknown channel")
+)
+
 type TemplateService struct {
-	repo          repos.TemplateRepository
-	tenantService *tenantServices.TenantService
-	logger        logger.Logger
+	tenantService     *tenantServices.TenantService
+	logger            logger.Logger
+	repositoryFactory interface {
+		GetTemplateRepositoryForTenant(tenantID string) (repos.TemplateRepository, error)
+		GetUserRepositoryForTenant(tenantID string) (tenantRepos.UserRepository, error)
+		GetTenantPreferenceRepositoryForTenant(tenantID string) (tenantRepos.TenantPreferenceRepository, error)
+	}
 }
 
 func NewTemplateService(
-	repo repos.TemplateRepository,
 	tenantService *tenantServices.TenantService,
 	logger logger.Logger,
+	repositoryFactory interface {
+		GetTemplateRepositoryForTenant(tenantID string) (repos.TemplateRepository, error)
+		GetUserRepositoryForTenant(tenantID string) (tenantRepos.UserRepository, error)
+		GetTenantPreferenceRepositoryForTenant(tenantID string) (tenantRepos.TenantPreferenceRepository, error)
+	},
 ) *TemplateService {
 	return &TemplateService{
-		repo:          repo,
-		tenantService: tenantService,
-		logger:        logger,
+		tenantService:     tenantService,
+		logger:            logger,
+		repositoryFactory: repositoryFactory,
 	}
 }
 
-func (s *TemplateService) GetContent(ctx context.Context, tenantID, templateID string, variables []domain.TemplateVariable) (string, error) {
+// ContentRequest identifies the template content to render for a send
+type ContentRequest struct {
+	TemplateID string
+	// UserID is the recipient; their locale selects the translation
+	UserID  string
+	Channel string
+	// Version pins a template version; zero renders the published version
+	Version   int
+	Variables []domain.TemplateVariable
+}
+
+// RenderedContent is the output of rendering a template for a send
+type RenderedContent struct {
+	Channel tenantDomain.ChannelType
+	Subject string
+	HTML    string
+	Title   string
+	// Content is the plain-text body: the email text part, or the SMS, push or in-app body.
+	// For chat it may instead be Slack blocks or an Adaptive Card as JSON.
+	Content string
+	// Locale is the translation that was rendered; empty when the base content was used
+	Locale string
+	// Version is the template version that produced the content
+	Version int
+}
+
+// GetContent renders a template version for a send on the requested channel, in
+// the recipient's locale, falling back to the tenant default locale and then
+// the base content.
+func (s *TemplateService) GetContent(ctx context.Context, tenantID string, req ContentRequest) (*RenderedContent, error) {
 	s.logger.DebugContext(ctx, "Getting template content",
 		logger.String("tenant_id", tenantID),
-		logger.String("template_id", templateID))
+		logger.String("template_id", req.TemplateID),
+		logger.String("channel", req.Channel),
+		logger.Int("version", req.Version))
 
 	// Validate tenant access
 	err := s.tenantService.ValidateTenantAccess(ctx, tenantID)
 	if err != nil {
-		return "", fmt.Errorf("tenant validation failed: %w", err)
+		return nil, fmt.Errorf("tenant validation failed: %w", err)
+	}
+
+	repo, err := s.repositoryFactory.GetTemplateRepositoryForTenant(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template repository: %w", err)
 	}
 
 	// Get the template
-	template, err := s.repo.GetTemplateByID(ctx, templateID)
+	template, err := repo.GetTemplateByID(ctx, req.TemplateID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if template == nil {
-		return "", errors.New("template not found")
+		return nil, errors.New("template not found")
+	}
+
+	version := req.Version
+	if version == 0 {
+		version = template.PublishedVersion
 	}
+	templateVersion, err := repo.GetTemplateVersion(ctx, req.TemplateID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template version %d: %w", version, err)
+	}
+
+	// Render the immutable version, not the template's editable draft
+	versioned := *template
+	versioned.Content = templateVersion.Content
+	versioned.Variables = templateVersion.Variables
+	versioned.Variants = templateVersion.Variants
+	versioned.Translations = templateVersion.Translations
 
-	// Replace variables in the template
-	content, err := s.replaceVariables(template, variables)
+	locales := s.resolveLocales(ctx, tenantID, req.UserID)
+
+	rendered, err := RenderChannel(&versioned, tenantDomain.NormalizeChannel(req.Channel), BuildTemplateData(req.Variables), locales...)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to render template",
+			logger.String("tenant_id", tenantID),
+			logger.String("template_id", req.TemplateID),
+			logger.String("channel", req.Channel),
+			logger.Int("version", version),
+			logger.Err(err))
+		return nil, err
+	}
+	rendered.Version = version
 
 	s.logger.DebugContext(ctx, "Template content processed successfully",
 		logger.String("tenant_id", tenantID),
-		logger.String("template_id", templateID))
+		logger.String("template_id", req.TemplateID),
+		logger.String("locale", rendered.Locale),
+		logger.Int("version", version))
+
+	return rendered, nil
+}
+
+// resolveLocales returns the locales to try in order: the user's locale, then
+// the tenant default. Lookup failures only narrow the fallback chain.
+func (s *TemplateService) resolveLocales(ctx context.Context, tenantID, userID string) []string {
+	var locales []string
+
+	if userID != "" {
+		if userRepo, err := s.repositoryFactory.GetUserRepositoryForTenant(tenantID); err == nil {
+			if user, err := userRepo.GetUserByID(ctx, userID); err == nil && user.Locale != "" {
+				locales = append(locales, user.Locale)
+			} else if err != nil {
+				s.logger.DebugContext(ctx, "Could not load user locale",
+					logger.String("tenant_id", tenantID),
+					logger.String("user_id", userID),
+					logger.Err(err))
+			}
+		}
+	}
 
-	return content, err
+	if prefRepo, err := s.repositoryFactory.GetTenantPreferenceRepositoryForTenant(tenantID); err == nil {
+		if pref, err := prefRepo.GetTenantPreferenceByTenantID(ctx, tenantID); err == nil && pref.DefaultLocale != "" {
+			locales = append(locales, pref.DefaultLocale)
+		}
+	}
+
+	return locales
+}
+
+// RenderChannel renders the template's content for a channel with data. The
+// first of the locales with a matching translation is used, otherwise the base content.
+// Declared variables the content reads must be present unless every use supplies a default.
+func RenderChannel(template *templates.Template, channel tenantDomain.ChannelType, data map[string]interface{}, locales ...string) (*RenderedContent, error) {
+	compiled, locale, err := compileChannel(template, channel, locales...)
+	if err != nil {
+		return nil, err
+	}
+
+	if missing := MissingVariables(compiledParts(compiled), template.Variables, data); len(missing) > 0 {
+		return nil, fmt.Errorf("missing variables: %s", strings.Join(missing, ", "))
+	}
+
+	return renderParts(compiled, channel, locale, data)
+}
+
+// Preview is the output of rendering a template with sample data
+type Preview struct {
+	Channels map[tenantDomain.ChannelType]*RenderedContent
+	// Errors holds channels that could not be rendered and why
+	Errors map[tenantDomain.ChannelType]string `json:",omitempty"`
+	// MissingVariables are used by the rendered content but absent from the data and without a default
+	MissingVariables []string
+	// UnusedVariables are present in the data but never read by the rendered content
+	UnusedVariables []string
 }
 
-func (s *TemplateService) replaceVariables(template *templates.Template, variables []domain.TemplateVariable) (string, error) {
-	content := template.Content
-	variableMap := make(map[string]string)
+// PreviewTemplate renders the template for each channel without requiring
+// variables, so authors can see the output and what the sample data lacks.
+// Variable keys are the flat keys used for sends, e.g. "user.name".
+func PreviewTemplate(template *templates.Template, channels []tenantDomain.ChannelType, variables []domain.TemplateVariable, locales ...string) *Preview {
+	data := BuildTemplateData(variables)
+	preview := &Preview{
+		Channels: make(map[tenantDomain.ChannelType]*RenderedContent, len(channels)),
+		Errors:   map[tenantDomain.ChannelType]string{},
+	}
+
+	var all []*engine.Template
+	for _, channel := range channels {
+		if !channel.IsValid() {
+			preview.Errors[channel] = ErrUnknownChannel.Error()
+			continue
+		}
+		compiled, locale, err := compileChannel(template, channel, locales...)
+		if err != nil {
+			preview.Errors[channel] = err.Error()
+			continue
+		}
+		rendered, err := renderParts(compiled, channel, locale, data)
+		if err != nil {
+			preview.Errors[channel] = err.Error()
+			continue
+		}
+		preview.Channels[channel] = rendered
+		all = append(all, compiledParts(compiled)...)
+	}
+
+	preview.MissingVariables = missingReferences(all, template.Variables, data)
+	preview.UnusedVariables = unusedVariables(all, variables)
+	return preview
+}
+
+// compileChannel compiles the localized variant for a channel and returns its
+// parts with the locale that was selected
+func compileChannel(template *templates.Template, channel tenantDomain.ChannelType, locales ...string) (map[string]*engine.Template, string, error) {
+	variant, locale := template.LocalizedVariant(channel, locales...)
+	parts := variant.Parts()
+
+	compiled := make(map[string]*engine.Template, len(parts))
+	for name, source := range parts {
+		t, err := engine.Compile(source)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to compile template %s: %w", name, err)
+		}
+		compiled[name] = t
+	}
+	return compiled, locale, nil
+}
+
+func compiledParts(compiled map[string]*engine.Template) []*engine.Template {
+	all := make([]*engine.Template, 0, len(compiled))
+	for _, t := range compiled {
+		all = append(all, t)
+	}
+	return all
+}
+
+func renderParts(compiled map[string]*engine.Template, channel tenantDomain.ChannelType, locale string, data map[string]interface{}) (*RenderedContent, error) {
+	output := make(map[string]string, len(compiled))
+	for name, t := range compiled {
+		render := t.Render
+		if name == "html" {
+			render = t.RenderHTML
+		}
+		content, err := render(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render template %s: %w", name, err)
+		}
+		output[name] = content
+	}
+
+	rendered := &RenderedContent{
+		Channel: channel,
+		Subject: output["subject"],
+		HTML:    output["html"],
+		Title:   output["title"],
+		Content: output["body"],
+		Locale:  locale,
+	}
+	if channel == tenantDomain.ChannelTypeEmail {
+		rendered.Content = output["text"]
+	}
+	return rendered, nil
+}
+
+// missingReferences returns the declared variables missing from data, plus any
+// path the templates read without a default that has no value, sorted
+func missingReferences(compiled []*engine.Template, declared []string, data map[string]interface{}) []string {
+	seen := map[string]bool{}
+	missing := []string{}
+	for _, key := range MissingVariables(compiled, declared, data) {
+		seen[key] = true
+		missing = append(missing, key)
+	}
+	for _, t := range compiled {
+		for _, ref := range t.References() {
+			if seen[ref.Path] || ref.HasDefault {
+				continue
+			}
+			if _, ok := engine.Lookup(data, ref.Path); !ok {
+				seen[ref.Path] = true
+				missing = append(missing, ref.Path)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// unusedVariables returns the variable keys that no template reads. A key is
+// used when a template reads it, a path below it, or an object containing it.
+func unusedVariables(compiled []*engine.Template, variables []domain.TemplateVariable) []string {
+	var paths []string
+	for _, t := range compiled {
+		for _, ref := range t.References() {
+			paths = append(paths, ref.Path)
+		}
+	}
+
+	unused := []string{}
 	for _, v := range variables {
-		variableMap[v.Key] = v.Value
+		if v.Key == "" {
+			continue
+		}
+		used := false
+		for _, path := range paths {
+			if readsVariable(path, v.Key) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			unused = append(unused, v.Key)
+		}
 	}
+	sort.Strings(unused)
+	return unused
+}
 
-	missingVariables := []string{}
-	for _, key := range template.Variables {
-		if value, exists := variableMap[key]; exists {
-			content = strings.ReplaceAll(content, "{{"+key+"}}", value)
-		} else {
-			missingVariables = append(missingVariables, key)
+// ValidateTemplate compiles the base content, every channel variant and every translation so syntax
+// errors are reported when a template is saved rather than when it is sent
+func ValidateTemplate(template *templates.Template) error {
+	if _, err := engine.Compile(template.Content); err != nil {
+		return fmt.Errorf("content: %w", err)
+	}
+	if err := validateVariants(template.Variants, ""); err != nil {
+		return err
+	}
+	for locale, translation := range template.Translations {
+		if _, err := engine.Compile(translation.Content); err != nil {
+			return fmt.Errorf("%s content: %w", locale, err)
+		}
+		if err := validateVariants(translation.Variants, locale+" "); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	if len(missingVariables) > 0 {
-		return "", fmt.Errorf("missing variables: %s", strings.Join(missingVariables, ", "))
+func validateVariants(variants map[tenantDomain.ChannelType]templates.ChannelContent, prefix string) error {
+	for channel, variant := range variants {
+		if !channel.IsValid() {
+			return fmt.Errorf("%w: %q", ErrUnknownChannel, channel)
+		}
+		for name, source := range variant.Parts() {
+			if _, err := engine.Compile(source); err != nil {
+				return fmt.Errorf("%s%s %s: %w", prefix, channel, name, err)
+			}
+		}
 	}
+	return nil
+}
 
-	return content, nil
+// MissingVariables returns the declared variables the templates read that have
+// no value in data. Variables the selected content never reads are not required,
+// and a variable is optional when every reference to it has a default.
+func MissingVariables(compiled []*engine.Template, declared []string, data map[string]interface{}) []string {
+	missing := []string{}
+	for _, key := range declared {
+		used, defaulted := false, true
+		for _, t := range compiled {
+			for _, ref := range t.References() {
+				if readsVariable(ref.Path, key) {
+					used = true
+					defaulted = defaulted && ref.HasDefault
+				}
+			}
+		}
+		if !used || defaulted {
+			continue
+		}
+		if _, ok := engine.Lookup(data, key); !ok {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// readsVariable reports whether a template reading path reads the variable key:
+// the key itself, a path below it, or an object containing it
+func readsVariable(path, key string) bool {
+	return path == key || strings.HasPrefix(path, key+".") || strings.HasPrefix(key, path+".")
+}
+
+// BuildTemplateData converts notification variables into template data.
+// Dotted keys become nested objects and JSON object or array values are decoded
+// so templates can loop over them.
+func BuildTemplateData(variables []domain.TemplateVariable) map[string]interface{} {
+	data := make(map[string]interface{}, len(variables))
+	for _, v := range variables {
+		if v.Key == "" {
+			continue
+		}
+		engine.SetPath(data, v.Key, decodeValue(v.Value))
+	}
+	return data
+}
+
+func decodeValue(value string) interface{} {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+			return decoded
+		}
+	}
+	return value
 }
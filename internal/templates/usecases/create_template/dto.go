@@ -1,20 +1,22 @@
 package createtemplate
 
 import (
-    "getnoti.com/internal/templates/domain"
+	"getnoti.com/internal/templates/domain"
+	tenantDomain "getnoti.com/internal/tenants/domain"
 )
 
 type CreateTemplateRequest struct {
-    Name      string
-    Content   string
-    IsPublic  bool
-    Variables []string
+	Name      string
+	Content   string
+	IsPublic  bool
+	Variables []string
+	Variants  map[tenantDomain.ChannelType]domain.ChannelContent
+	// Translations are keyed by BCP 47 locale, e.g. "fr" or "pt-BR"
+	Translations map[string]domain.TemplateTranslation
 }
 
-
-
 type CreateTemplateResponse struct {
-    Success bool
-    Template domain.Template
-    Message string
-}
\ No newline at end of file
+	Success  bool
+	Template domain.Template
+	Message  string
+}
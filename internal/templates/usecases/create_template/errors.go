@@ -3,6 +3,7 @@ package createtemplate
 import "errors"
 
 var (
-    ErrTemplateCreationFailed = errors.New("template creation failed")
-    ErrUnexpected             = errors.New("unexpected error occurred")
+	ErrTemplateCreationFailed = errors.New("template creation failed")
+	ErrTemplateCompileFailed  = errors.New("template content is invalid")
+	ErrUnexpected             = errors.New("unexpected error occurred")
 )
@@ -2,10 +2,12 @@ package createtemplate
 
 import (
 	"context"
+	"fmt"
 
 	"getnoti.com/internal/shared/utils"
 	"getnoti.com/internal/templates/domain"
 	"getnoti.com/internal/templates/repos"
+	templateServices "getnoti.com/internal/templates/services"
 )
 
 type CreateTemplateUseCase interface {
@@ -21,21 +23,35 @@ func NewCreateTemplateUseCase(repository repos.TemplateRepository) CreateTemplat
 }
 
 func (uc *createTemplateUseCase) Execute(ctx context.Context, req CreateTemplateRequest) (CreateTemplateResponse, error) {
-	tmplID:= utils.GenerateUUID()
-	
+	tmplID := utils.GenerateUUID()
+
 	tmpl := &domain.Template{
-		ID:        tmplID,
-		Name:      req.Name,
-		Content:   req.Content,
-		IsPublic:  req.IsPublic,
-		Variables: req.Variables,
+		ID:           tmplID,
+		Name:         req.Name,
+		Content:      req.Content,
+		IsPublic:     req.IsPublic,
+		Variables:    req.Variables,
+		Variants:     req.Variants,
+		Translations: req.Translations,
+		// New templates start at version 1, published so they can be sent right away
+		DraftVersion:     1,
+		PublishedVersion: 1,
+	}
+
+	if err := tmpl.NormalizeTranslations(); err != nil {
+		return CreateTemplateResponse{Success: false}, err
+	}
+
+	// Reject templates that would fail at send time
+	if err := templateServices.ValidateTemplate(tmpl); err != nil {
+		return CreateTemplateResponse{Success: false}, fmt.Errorf("%w: %w", ErrTemplateCompileFailed, err)
 	}
 
-	err := uc.repository.CreateTemplate(ctx, tmpl)
+	err := uc.repository.CreateTemplate(ctx, tmpl, tmpl.NewVersion(utils.GenerateUUID(), 1))
 	if err != nil {
 		return CreateTemplateResponse{Success: false}, err
 	}
 
 	return CreateTemplateResponse{Success: true,
-		Template: *tmpl,}, nil
+		Template: *tmpl}, nil
 }
@@ -0,0 +1,17 @@
+package gettemplateversions
+
+import (
+	"context"
+)
+
+type GetTemplateVersionsController struct {
+	useCase GetTemplateVersionsUseCase
+}
+
+func NewGetTemplateVersionsController(useCase GetTemplateVersionsUseCase) *GetTemplateVersionsController {
+	return &GetTemplateVersionsController{useCase: useCase}
+}
+
+func (c *GetTemplateVersionsController) GetTemplateVersions(ctx context.Context, req GetTemplateVersionsRequest) (GetTemplateVersionsResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
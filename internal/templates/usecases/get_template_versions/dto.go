@@ -0,0 +1,20 @@
+package gettemplateversions
+
+import (
+	"getnoti.com/internal/templates/domain"
+)
+
+type GetTemplateVersionsRequest struct {
+	ID string
+}
+
+func (r *GetTemplateVersionsRequest) SetID(id string) {
+	r.ID = id
+}
+
+type GetTemplateVersionsResponse struct {
+	TemplateID       string                   `json:"template_id"`
+	DraftVersion     int                      `json:"draft_version"`
+	PublishedVersion int                      `json:"published_version"`
+	Versions         []domain.TemplateVersion `json:"versions"`
+}
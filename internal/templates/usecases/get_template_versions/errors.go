@@ -0,0 +1,8 @@
+package gettemplateversions
+
+import "errors"
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrUnexpected       = errors.New("unexpected error occurred")
+)
@@ -0,0 +1,37 @@
+package gettemplateversions
+
+import (
+	"context"
+	"getnoti.com/internal/templates/repos"
+)
+
+type GetTemplateVersionsUseCase interface {
+	Execute(ctx context.Context, req GetTemplateVersionsRequest) (GetTemplateVersionsResponse, error)
+}
+
+type getTemplateVersionsUseCase struct {
+	repository repos.TemplateRepository
+}
+
+func NewGetTemplateVersionsUseCase(repository repos.TemplateRepository) GetTemplateVersionsUseCase {
+	return &getTemplateVersionsUseCase{repository: repository}
+}
+
+func (uc *getTemplateVersionsUseCase) Execute(ctx context.Context, req GetTemplateVersionsRequest) (GetTemplateVersionsResponse, error) {
+	tmpl, err := uc.repository.GetTemplateByID(ctx, req.ID)
+	if err != nil {
+		return GetTemplateVersionsResponse{}, err
+	}
+
+	versions, err := uc.repository.GetTemplateVersions(ctx, tmpl.ID)
+	if err != nil {
+		return GetTemplateVersionsResponse{}, err
+	}
+
+	return GetTemplateVersionsResponse{
+		TemplateID:       tmpl.ID,
+		DraftVersion:     tmpl.DraftVersion,
+		PublishedVersion: tmpl.PublishedVersion,
+		Versions:         versions,
+	}, nil
+}
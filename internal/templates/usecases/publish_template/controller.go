@@ -0,0 +1,17 @@
+package publishtemplate
+
+import (
+	"context"
+)
+
+type PublishTemplateController struct {
+	useCase PublishTemplateUseCase
+}
+
+func NewPublishTemplateController(useCase PublishTemplateUseCase) *PublishTemplateController {
+	return &PublishTemplateController{useCase: useCase}
+}
+
+func (c *PublishTemplateController) PublishTemplate(ctx context.Context, req PublishTemplateRequest) (PublishTemplateResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,21 @@
+package publishtemplate
+
+import (
+	"getnoti.com/internal/templates/domain"
+)
+
+type PublishTemplateRequest struct {
+	ID string
+	// Version to publish; zero publishes the current draft
+	Version int
+}
+
+func (r *PublishTemplateRequest) SetID(id string) {
+	r.ID = id
+}
+
+type PublishTemplateResponse struct {
+	Template domain.Template
+	Success  bool
+	Message  string
+}
@@ -0,0 +1,8 @@
+package publishtemplate
+
+import "errors"
+
+var (
+	ErrTemplatePublishFailed = errors.New("template publish failed")
+	ErrUnexpected            = errors.New("unexpected error occurred")
+)
@@ -0,0 +1,47 @@
+package publishtemplate
+
+import (
+	"context"
+
+	"getnoti.com/internal/templates/repos"
+)
+
+type PublishTemplateUseCase interface {
+	Execute(ctx context.Context, req PublishTemplateRequest) (PublishTemplateResponse, error)
+}
+
+type publishTemplateUseCase struct {
+	repository repos.TemplateRepository
+}
+
+func NewPublishTemplateUseCase(repository repos.TemplateRepository) PublishTemplateUseCase {
+	return &publishTemplateUseCase{repository: repository}
+}
+
+func (uc *publishTemplateUseCase) Execute(ctx context.Context, req PublishTemplateRequest) (PublishTemplateResponse, error) {
+	tmpl, err := uc.repository.GetTemplateByID(ctx, req.ID)
+	if err != nil {
+		return PublishTemplateResponse{Success: false, Message: err.Error()}, err
+	}
+
+	version := req.Version
+	if version == 0 {
+		version = tmpl.DraftVersion
+	}
+
+	// The version must exist; publishing only moves the pointer
+	if _, err := uc.repository.GetTemplateVersion(ctx, tmpl.ID, version); err != nil {
+		return PublishTemplateResponse{Success: false, Message: err.Error()}, err
+	}
+
+	if err := uc.repository.SetPublishedVersion(ctx, tmpl.ID, version); err != nil {
+		return PublishTemplateResponse{Success: false, Message: ErrTemplatePublishFailed.Error()}, err
+	}
+	tmpl.PublishedVersion = version
+
+	return PublishTemplateResponse{
+		Template: *tmpl,
+		Success:  true,
+		Message:  "Template published successfully",
+	}, nil
+}
@@ -0,0 +1,17 @@
+package rendertemplate
+
+import (
+	"context"
+)
+
+type RenderTemplateController struct {
+	useCase RenderTemplateUseCase
+}
+
+func NewRenderTemplateController(useCase RenderTemplateUseCase) *RenderTemplateController {
+	return &RenderTemplateController{useCase: useCase}
+}
+
+func (c *RenderTemplateController) RenderTemplate(ctx context.Context, req RenderTemplateRequest) (RenderTemplateResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
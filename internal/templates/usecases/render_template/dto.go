@@ -0,0 +1,34 @@
+package rendertemplate
+
+import (
+	notificationDomain "getnoti.com/internal/notifications/domain"
+	"getnoti.com/internal/templates/domain"
+	templateServices "getnoti.com/internal/templates/services"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+)
+
+type RenderTemplateRequest struct {
+	// ID of a stored template; empty when Template is given inline
+	ID string
+	// Version of the stored template to render; zero renders the current draft
+	Version int
+	// Template is unsaved content to render instead of a stored template
+	Template *domain.Template
+	// Channels to render; empty renders every channel
+	Channels []tenantDomain.ChannelType
+	// Locale selects a translation, falling back to the base content
+	Locale string
+	// Variables are sample values in the same form as a send
+	Variables []notificationDomain.TemplateVariable
+}
+
+func (r *RenderTemplateRequest) SetID(id string) {
+	r.ID = id
+}
+
+type RenderTemplateResponse struct {
+	Version int `json:",omitempty"`
+	templateServices.Preview
+	Success bool
+	Message string
+}
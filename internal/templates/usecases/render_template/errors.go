@@ -0,0 +1,8 @@
+package rendertemplate
+
+import "errors"
+
+var (
+	ErrTemplateRequired = errors.New("template id or inline template is required")
+	ErrUnexpected       = errors.New("unexpected error occurred")
+)
@@ -0,0 +1,94 @@
+package rendertemplate
+
+import (
+	"context"
+	"fmt"
+
+	"getnoti.com/internal/templates/domain"
+	"getnoti.com/internal/templates/repos"
+	templateServices "getnoti.com/internal/templates/services"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+)
+
+type RenderTemplateUseCase interface {
+	Execute(ctx context.Context, req RenderTemplateRequest) (RenderTemplateResponse, error)
+}
+
+type renderTemplateUseCase struct {
+	repository repos.TemplateRepository
+}
+
+func NewRenderTemplateUseCase(repository repos.TemplateRepository) RenderTemplateUseCase {
+	return &renderTemplateUseCase{repository: repository}
+}
+
+// Execute renders a stored or inline template with sample variables. Nothing is
+// saved and no notification is created, so it is safe to call while editing.
+func (uc *renderTemplateUseCase) Execute(ctx context.Context, req RenderTemplateRequest) (RenderTemplateResponse, error) {
+	locale, err := tenantDomain.NormalizeLocale(req.Locale)
+	if err != nil {
+		return RenderTemplateResponse{Success: false, Message: err.Error()}, fmt.Errorf("%w: %q", err, req.Locale)
+	}
+
+	var tmpl *domain.Template
+	version := 0
+	switch {
+	case req.ID != "":
+		tmpl, version, err = uc.loadVersion(ctx, req.ID, req.Version)
+		if err != nil {
+			return RenderTemplateResponse{Success: false, Message: err.Error()}, err
+		}
+	case req.Template != nil:
+		tmpl = req.Template
+		if err := tmpl.NormalizeTranslations(); err != nil {
+			return RenderTemplateResponse{Success: false, Message: err.Error()}, err
+		}
+		// Inline content has not been validated on save, so report syntax errors up front
+		if err := templateServices.ValidateTemplate(tmpl); err != nil {
+			return RenderTemplateResponse{Success: false, Message: err.Error()}, err
+		}
+	default:
+		return RenderTemplateResponse{Success: false, Message: ErrTemplateRequired.Error()}, ErrTemplateRequired
+	}
+
+	channels := req.Channels
+	if len(channels) == 0 {
+		channels = tenantDomain.ChannelTypes
+	}
+
+	var locales []string
+	if locale != "" {
+		locales = append(locales, locale)
+	}
+
+	preview := templateServices.PreviewTemplate(tmpl, channels, req.Variables, locales...)
+
+	return RenderTemplateResponse{
+		Version: version,
+		Preview: *preview,
+		Success: true,
+		Message: "Template rendered successfully",
+	}, nil
+}
+
+// loadVersion returns the stored template with the content of the requested version
+func (uc *renderTemplateUseCase) loadVersion(ctx context.Context, id string, version int) (*domain.Template, int, error) {
+	tmpl, err := uc.repository.GetTemplateByID(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	if version == 0 {
+		version = tmpl.DraftVersion
+	}
+
+	templateVersion, err := uc.repository.GetTemplateVersion(ctx, id, version)
+	if err != nil {
+		return nil, 0, err
+	}
+	tmpl.Content = templateVersion.Content
+	tmpl.Variables = templateVersion.Variables
+	tmpl.Variants = templateVersion.Variants
+	tmpl.Translations = templateVersion.Translations
+
+	return tmpl, version, nil
+}
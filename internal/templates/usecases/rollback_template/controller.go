@@ -0,0 +1,17 @@
+package rollbacktemplate
+
+import (
+	"context"
+)
+
+type RollbackTemplateController struct {
+	useCase RollbackTemplateUseCase
+}
+
+func NewRollbackTemplateController(useCase RollbackTemplateUseCase) *RollbackTemplateController {
+	return &RollbackTemplateController{useCase: useCase}
+}
+
+func (c *RollbackTemplateController) RollbackTemplate(ctx context.Context, req RollbackTemplateRequest) (RollbackTemplateResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,22 @@
+package rollbacktemplate
+
+import (
+	"getnoti.com/internal/templates/domain"
+)
+
+type RollbackTemplateRequest struct {
+	ID string
+	// Version to roll back to; zero rolls back to the version before the published one
+	Version int
+}
+
+func (r *RollbackTemplateRequest) SetID(id string) {
+	r.ID = id
+}
+
+type RollbackTemplateResponse struct {
+	Template        domain.Template
+	PreviousVersion int
+	Success         bool
+	Message         string
+}
@@ -0,0 +1,9 @@
+package rollbacktemplate
+
+import "errors"
+
+var (
+	ErrNoPreviousVersion      = errors.New("template has no earlier version to roll back to")
+	ErrTemplateRollbackFailed = errors.New("template rollback failed")
+	ErrUnexpected             = errors.New("unexpected error occurred")
+)
@@ -0,0 +1,60 @@
+package rollbacktemplate
+
+import (
+	"context"
+
+	"getnoti.com/internal/templates/repos"
+)
+
+type RollbackTemplateUseCase interface {
+	Execute(ctx context.Context, req RollbackTemplateRequest) (RollbackTemplateResponse, error)
+}
+
+type rollbackTemplateUseCase struct {
+	repository repos.TemplateRepository
+}
+
+func NewRollbackTemplateUseCase(repository repos.TemplateRepository) RollbackTemplateUseCase {
+	return &rollbackTemplateUseCase{repository: repository}
+}
+
+func (uc *rollbackTemplateUseCase) Execute(ctx context.Context, req RollbackTemplateRequest) (RollbackTemplateResponse, error) {
+	tmpl, err := uc.repository.GetTemplateByID(ctx, req.ID)
+	if err != nil {
+		return RollbackTemplateResponse{Success: false, Message: err.Error()}, err
+	}
+
+	target := req.Version
+	if target == 0 {
+		// Default to the newest version older than the one currently published
+		versions, err := uc.repository.GetTemplateVersions(ctx, tmpl.ID)
+		if err != nil {
+			return RollbackTemplateResponse{Success: false, Message: ErrUnexpected.Error()}, err
+		}
+		for _, v := range versions {
+			if v.Version < tmpl.PublishedVersion {
+				target = v.Version
+				break
+			}
+		}
+		if target == 0 {
+			return RollbackTemplateResponse{Success: false, Message: ErrNoPreviousVersion.Error()}, ErrNoPreviousVersion
+		}
+	} else if _, err := uc.repository.GetTemplateVersion(ctx, tmpl.ID, target); err != nil {
+		return RollbackTemplateResponse{Success: false, Message: err.Error()}, err
+	}
+
+	if err := uc.repository.SetPublishedVersion(ctx, tmpl.ID, target); err != nil {
+		return RollbackTemplateResponse{Success: false, Message: ErrTemplateRollbackFailed.Error()}, err
+	}
+
+	previous := tmpl.PublishedVersion
+	tmpl.PublishedVersion = target
+
+	return RollbackTemplateResponse{
+		Template:        *tmpl,
+		PreviousVersion: previous,
+		Success:         true,
+		Message:         "Template rolled back successfully",
+	}, nil
+}
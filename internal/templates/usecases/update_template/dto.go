@@ -2,14 +2,19 @@ package updatetemplate
 
 import (
 	"getnoti.com/internal/templates/domain"
+	tenantDomain "getnoti.com/internal/tenants/domain"
 )
 
 type UpdateTemplateRequest struct {
-	ID        string
-	Name      *string
-	Content   *string
-	IsPublic  *bool
-	Variables *[]string
+	ID           string
+	Name         *string
+	Content      *string
+	IsPublic     *bool
+	Variables    *[]string
+	Variants     *map[tenantDomain.ChannelType]domain.ChannelContent
+	Translations *map[string]domain.TemplateTranslation
+	// Publish makes a new content version live immediately instead of leaving it as a draft
+	Publish bool
 }
 
 func (r *UpdateTemplateRequest) SetID(id string) {
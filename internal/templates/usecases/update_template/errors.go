@@ -3,7 +3,8 @@ package updatetemplate
 import "errors"
 
 var (
-    ErrTemplateUpdateFailed = errors.New("template update failed")
-    ErrTemplateNotFound     = errors.New("template not found")
-    ErrUnexpected           = errors.New("unexpected error occurred")
-)
\ No newline at end of file
+	ErrTemplateUpdateFailed  = errors.New("template update failed")
+	ErrTemplateNotFound      = errors.New("template not found")
+	ErrTemplateCompileFailed = errors.New("template content is invalid")
+	ErrUnexpected            = errors.New("unexpected error occurred")
+)
@@ -3,56 +3,95 @@ package updatetemplate
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
 
-	postgres "getnoti.com/internal/templates/repos/implementations"
+	"getnoti.com/internal/shared/utils"
 	"getnoti.com/internal/templates/repos"
+	postgres "getnoti.com/internal/templates/repos/implementations"
+	templateServices "getnoti.com/internal/templates/services"
 )
 
 type UpdateTemplateUseCase interface {
-    Execute(ctx context.Context, req UpdateTemplateRequest) (UpdateTemplateResponse,error)
+	Execute(ctx context.Context, req UpdateTemplateRequest) (UpdateTemplateResponse, error)
 }
 
 type updateTemplateUseCase struct {
-    repository repos.TemplateRepository
+	repository repos.TemplateRepository
 }
 
 func NewUpdateTemplateUseCase(repository repos.TemplateRepository) UpdateTemplateUseCase {
-    return &updateTemplateUseCase{repository: repository}
+	return &updateTemplateUseCase{repository: repository}
 }
 
-func (uc *updateTemplateUseCase) Execute(ctx context.Context, req UpdateTemplateRequest) (UpdateTemplateResponse,error) {
-    // Check if the template exists
-    existingTemplate, err := uc.repository.GetTemplateByID(ctx, req.ID)
-    if err != nil {
-        if errors.Is(err, postgres.ErrTemplateNotFound) {
-            return UpdateTemplateResponse{Success: false, Message: ErrTemplateNotFound.Error()},err
-        }
-        return UpdateTemplateResponse{Success: false, Message: ErrUnexpected.Error()},err
-    }
-
-    // Update the template with provided fields, retain existing values for fields not provided
-    if req.Name != nil {
-        existingTemplate.Name = *req.Name
-    }
-    if req.Content != nil {
-        existingTemplate.Content = *req.Content
-    }
-    if req.IsPublic != nil {
-        existingTemplate.IsPublic = *req.IsPublic
-    }
-    if req.Variables != nil {
-       
-        existingTemplate.Variables = *req.Variables
-    }
-
-    err = uc.repository.UpdateTemplate(ctx, existingTemplate)
-    if err != nil {
-        return UpdateTemplateResponse{Success: false, Message: ErrTemplateUpdateFailed.Error()},err
-    }
-
-    return UpdateTemplateResponse{
-        Template: *existingTemplate,
-        Success:  true,
-        Message:  "Template updated successfully",
-    },nil
+func (uc *updateTemplateUseCase) Execute(ctx context.Context, req UpdateTemplateRequest) (UpdateTemplateResponse, error) {
+	// Check if the template exists
+	existingTemplate, err := uc.repository.GetTemplateByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrTemplateNotFound) {
+			return UpdateTemplateResponse{Success: false, Message: ErrTemplateNotFound.Error()}, err
+		}
+		return UpdateTemplateResponse{Success: false, Message: ErrUnexpected.Error()}, err
+	}
+
+	previousContent := existingTemplate.Content
+	previousVariables := existingTemplate.Variables
+	previousVariants := existingTemplate.Variants
+	previousTranslations := existingTemplate.Translations
+
+	// Update the template with provided fields, retain existing values for fields not provided
+	if req.Name != nil {
+		existingTemplate.Name = *req.Name
+	}
+	if req.Content != nil {
+		existingTemplate.Content = *req.Content
+	}
+	if req.IsPublic != nil {
+		existingTemplate.IsPublic = *req.IsPublic
+	}
+	if req.Variables != nil {
+
+		existingTemplate.Variables = *req.Variables
+	}
+	if req.Variants != nil {
+		existingTemplate.Variants = *req.Variants
+	}
+	if req.Translations != nil {
+		existingTemplate.Translations = *req.Translations
+		if err := existingTemplate.NormalizeTranslations(); err != nil {
+			return UpdateTemplateResponse{Success: false, Message: err.Error()}, err
+		}
+	}
+
+	// Reject templates that would fail at send time
+	if err := templateServices.ValidateTemplate(existingTemplate); err != nil {
+		return UpdateTemplateResponse{Success: false, Message: err.Error()}, fmt.Errorf("%w: %w", ErrTemplateCompileFailed, err)
+	}
+
+	// Content changes never overwrite a version; they are saved as a new draft version
+	if existingTemplate.Content != previousContent ||
+		!reflect.DeepEqual(existingTemplate.Variables, previousVariables) ||
+		!reflect.DeepEqual(existingTemplate.Variants, previousVariants) ||
+		!reflect.DeepEqual(existingTemplate.Translations, previousTranslations) {
+		existingTemplate.DraftVersion++
+		if req.Publish {
+			existingTemplate.PublishedVersion = existingTemplate.DraftVersion
+		}
+		version := existingTemplate.NewVersion(utils.GenerateUUID(), existingTemplate.DraftVersion)
+		err = uc.repository.CreateTemplateVersion(ctx, existingTemplate, version)
+	} else {
+		if req.Publish {
+			existingTemplate.PublishedVersion = existingTemplate.DraftVersion
+		}
+		err = uc.repository.UpdateTemplate(ctx, existingTemplate)
+	}
+	if err != nil {
+		return UpdateTemplateResponse{Success: false, Message: ErrTemplateUpdateFailed.Error()}, err
+	}
+
+	return UpdateTemplateResponse{
+		Template: *existingTemplate,
+		Success:  true,
+		Message:  "Template updated successfully",
+	}, nil
 }
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// APIKeyPrefix marks raw keys issued by the service
+	APIKeyPrefix = "noti_"
+
+	apiKeySecretBytes   = 32
+	apiKeyDisplayLength = len(APIKeyPrefix) + 8
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyInvalid  = errors.New("invalid api key")
+	ErrAPIKeyRevoked  = errors.New("api key has been revoked")
+	ErrAPIKeyExpired  = errors.New("api key has expired")
+)
+
+// APIKey is a tenant credential used to authenticate API requests.
+// Only the SHA-256 hash of the raw key is stored.
+type APIKey struct {
+	ID         string     `json:"id"`
+	TenantID   string     `json:"tenantId"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"keyPrefix"`
+	KeyHash    string     `json:"-"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// NewAPIKey generates a new API key for a tenant and returns it along with the raw key.
+// The raw key is only available at creation time.
+func NewAPIKey(tenantID, name string, expiresAt *time.Time) (*APIKey, string, error) {
+	if tenantID == "" {
+		return nil, "", fmt.Errorf("%w: tenant ID cannot be empty", ErrAPIKeyInvalid)
+	}
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name cannot be empty", ErrAPIKeyInvalid)
+	}
+
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	rawKey := APIKeyPrefix + hex.EncodeToString(secret)
+
+	now := time.Now()
+	return &APIKey{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		Name:      name,
+		KeyPrefix: rawKey[:apiKeyDisplayLength],
+		KeyHash:   HashAPIKey(rawKey),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, rawKey, nil
+}
+
+// HashAPIKey returns the stored representation of a raw API key
+func HashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsWellFormedAPIKey reports whether a raw key has the expected shape
+func IsWellFormedAPIKey(rawKey string) bool {
+	return strings.HasPrefix(rawKey, APIKeyPrefix) && len(rawKey) == len(APIKeyPrefix)+apiKeySecretBytes*2
+}
+
+// IsRevoked reports whether the key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired reports whether the key has passed its expiry time
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// Validate checks that the key can be used to authenticate
+func (k *APIKey) Validate(now time.Time) error {
+	if k.IsRevoked() {
+		return ErrAPIKeyRevoked
+	}
+	if k.IsExpired(now) {
+		return ErrAPIKeyExpired
+	}
+	return nil
+}
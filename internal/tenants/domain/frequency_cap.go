@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidFrequencyCap = errors.New("invalid frequency cap")
+
+// CapPolicy decides what happens to a notification over a frequency cap
+type CapPolicy string
+
+const (
+	// CapPolicyDefer holds the notification until the cap's window ends
+	CapPolicyDefer CapPolicy = "defer"
+	// CapPolicyDrop suppresses the notification
+	CapPolicyDrop CapPolicy = "drop"
+)
+
+// FrequencyCap limits how many notifications a user receives in a window, such
+// as 3 SMS per hour or 1 marketing email per day. An empty Channel or Category
+// matches every channel or category. Windows are fixed and aligned to UTC, so a
+// daily cap resets at midnight UTC.
+type FrequencyCap struct {
+	Channel       ChannelType `json:"channel,omitempty"`
+	Category      string      `json:"category,omitempty"`
+	Limit         int         `json:"limit"`
+	WindowMinutes int         `json:"windowMinutes"`
+	Policy        CapPolicy   `json:"policy"`
+}
+
+// Validate checks that the cap has a positive limit and window and a known policy
+func (c FrequencyCap) Validate() error {
+	if c.Channel != "" && !NormalizeChannel(string(c.Channel)).IsValid() {
+		return fmt.Errorf("%w: unsupported channel %s", ErrInvalidFrequencyCap, c.Channel)
+	}
+	if c.Limit <= 0 {
+		return fmt.Errorf("%w: limit must be greater than 0", ErrInvalidFrequencyCap)
+	}
+	if c.WindowMinutes <= 0 {
+		return fmt.Errorf("%w: windowMinutes must be greater than 0", ErrInvalidFrequencyCap)
+	}
+	if c.Policy != CapPolicyDefer && c.Policy != CapPolicyDrop {
+		return fmt.Errorf("%w: policy must be %q or %q", ErrInvalidFrequencyCap, CapPolicyDefer, CapPolicyDrop)
+	}
+	return nil
+}
+
+// Matches reports whether the cap counts notifications on the channel and
+// category. Channels are compared case-insensitively.
+func (c FrequencyCap) Matches(channel, category string) bool {
+	return (c.Channel == "" || NormalizeChannel(string(c.Channel)) == NormalizeChannel(channel)) && (c.Category == "" || c.Category == category)
+}
+
+// Key identifies the cap's counters. Changing a cap's scope or window starts
+// new counters.
+func (c FrequencyCap) Key() string {
+	channel, category := string(NormalizeChannel(string(c.Channel))), c.Category
+	if channel == "" {
+		channel = "*"
+	}
+	if category == "" {
+		category = "*"
+	}
+	return fmt.Sprintf("%s:%s:%d", channel, category, c.WindowMinutes)
+}
+
+// Window returns the start and end of the cap's window containing t
+func (c FrequencyCap) Window(t time.Time) (time.Time, time.Time) {
+	length := time.Duration(c.WindowMinutes) * time.Minute
+	start := t.UTC().Truncate(length)
+	return start, start.Add(length)
+}
+
+// Describe is the cap in words, used as the reason a notification was capped
+func (c FrequencyCap) Describe() string {
+	scope := "notifications"
+	if c.Category != "" {
+		scope = c.Category + " " + scope
+	}
+	if c.Channel != "" {
+		scope = string(c.Channel) + " " + scope
+	}
+	return fmt.Sprintf("%d %s per %d minutes", c.Limit, scope, c.WindowMinutes)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidLocale = errors.New("invalid locale")
+
+// localePattern accepts BCP 47 style tags such as "en", "pt-BR", "zh-Hant-TW" or "en_US"
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+// NormalizeLocale returns the canonical form of a locale tag, e.g. "pt_br" becomes "pt-BR".
+// An empty locale is returned unchanged.
+func NormalizeLocale(locale string) (string, error) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" {
+		return "", nil
+	}
+	if !localePattern.MatchString(locale) {
+		return "", ErrInvalidLocale
+	}
+
+	subtags := strings.Split(strings.ReplaceAll(locale, "_", "-"), "-")
+	subtags[0] = strings.ToLower(subtags[0])
+	for i := 1; i < len(subtags); i++ {
+		switch len(subtags[i]) {
+		case 2:
+			// Region
+			subtags[i] = strings.ToUpper(subtags[i])
+		case 4:
+			// Script
+			subtags[i] = strings.ToUpper(subtags[i][:1]) + strings.ToLower(subtags[i][1:])
+		default:
+			subtags[i] = strings.ToLower(subtags[i])
+		}
+	}
+	return strings.Join(subtags, "-"), nil
+}
+
+// LocaleFallbacks returns the locale followed by its less specific forms,
+// e.g. "zh-Hant-TW" gives "zh-Hant-TW", "zh-Hant" and "zh"
+func LocaleFallbacks(locale string) []string {
+	if locale == "" {
+		return nil
+	}
+	subtags := strings.Split(locale, "-")
+	fallbacks := make([]string, 0, len(subtags))
+	for i := len(subtags); i > 0; i-- {
+		fallbacks = append(fallbacks, strings.Join(subtags[:i], "-"))
+	}
+	return fallbacks
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// The release image is built from scratch and has no zoneinfo of its own
+	_ "time/tzdata"
+)
+
+var (
+	ErrInvalidTimezone   = errors.New("invalid timezone")
+	ErrInvalidQuietHours = errors.New("invalid quiet hours")
+)
+
+// quietHoursLayout is the "HH:MM" format of quiet hours boundaries
+const quietHoursLayout = "15:04"
+
+// QuietHours is a daily do-not-disturb window in the recipient's timezone.
+// Start and End are "HH:MM"; a window whose End is earlier than its Start
+// spans midnight, e.g. 22:00 to 07:00.
+type QuietHours struct {
+	Enabled bool   `json:"enabled"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+// Validate checks that an enabled window has valid, distinct boundaries
+func (q QuietHours) Validate() error {
+	if !q.Enabled {
+		return nil
+	}
+	start, err := time.Parse(quietHoursLayout, q.Start)
+	if err != nil {
+		return fmt.Errorf("%w: start must be HH:MM", ErrInvalidQuietHours)
+	}
+	end, err := time.Parse(quietHoursLayout, q.End)
+	if err != nil {
+		return fmt.Errorf("%w: end must be HH:MM", ErrInvalidQuietHours)
+	}
+	if start.Equal(end) {
+		return fmt.Errorf("%w: start and end must differ", ErrInvalidQuietHours)
+	}
+	return nil
+}
+
+// EndsAt reports whether t falls inside the window in loc and, if so, when the
+// window ends. Disabled or invalid windows never contain t.
+func (q QuietHours) EndsAt(t time.Time, loc *time.Location) (time.Time, bool) {
+	if q.Validate() != nil || !q.Enabled {
+		return time.Time{}, false
+	}
+	start, _ := time.Parse(quietHoursLayout, q.Start)
+	end, _ := time.Parse(quietHoursLayout, q.End)
+
+	local := t.In(loc)
+	now := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	inside := now >= from && now < to
+	if from > to {
+		inside = now >= from || now < to
+	}
+	if !inside {
+		return time.Time{}, false
+	}
+
+	endsAt := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+	if !endsAt.After(local) {
+		endsAt = endsAt.AddDate(0, 0, 1)
+	}
+	return endsAt, true
+}
+
+// LoadTimezone returns the location for an IANA timezone name such as
+// "Europe/Berlin". An empty name is UTC.
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, name)
+	}
+	return loc, nil
+}
@@ -9,10 +9,14 @@ import (
 type TenantPreference struct {
 	ID             string                        `json:"id"`
 	TenantID       string                        `json:"tenantId"`
-	Enabled        bool                          `json:"enabled"`          // Master toggle for all notifications
-	ChannelPrefs   map[ChannelType]bool          `json:"channelPrefs"`     // Enable/disable specific channels
-	CategoryPrefs  map[string]CategoryPreference `json:"categoryPrefs"`    // Preferences by notification category
-	DigestSettings DigestSettings                `json:"digestSettings"`   // Default digest configuration
+	Enabled        bool                          `json:"enabled"`        // Master toggle for all notifications
+	ChannelPrefs   map[ChannelType]bool          `json:"channelPrefs"`   // Enable/disable specific channels
+	CategoryPrefs  map[string]CategoryPreference `json:"categoryPrefs"`  // Preferences by notification category
+	DigestSettings DigestSettings                `json:"digestSettings"` // Default digest configuration
+	DefaultLocale  string                        `json:"defaultLocale"`  // Locale for users without one
+	Timezone       string                        `json:"timezone"`       // IANA timezone for users without one; empty is UTC
+	QuietHours     QuietHours                    `json:"quietHours"`     // Do-not-disturb window for users without one
+	FrequencyCaps  []FrequencyCap                `json:"frequencyCaps"`  // Per-user send limits, all of which apply
 	CreatedAt      time.Time                     `json:"createdAt"`
 	UpdatedAt      time.Time                     `json:"updatedAt"`
 }
@@ -28,8 +32,10 @@ func NewTenantPreference(tenantID string) *TenantPreference {
 			ChannelTypePush:    true,
 			ChannelTypeWebPush: true,
 			ChannelTypeInApp:   true,
+			ChannelTypeChat:    true,
 		},
 		CategoryPrefs: map[string]CategoryPreference{},
+		FrequencyCaps: []FrequencyCap{},
 		DigestSettings: DigestSettings{
 			Enabled:            false,
 			Type:               DigestTypeNone,
@@ -48,6 +54,24 @@ func (tp *TenantPreference) Validate() error {
 	if tp.TenantID == "" {
 		return errors.New("tenant ID cannot be empty")
 	}
-	
+
+	if _, err := NormalizeLocale(tp.DefaultLocale); err != nil {
+		return err
+	}
+
+	if _, err := LoadTimezone(tp.Timezone); err != nil {
+		return err
+	}
+
+	if err := tp.QuietHours.Validate(); err != nil {
+		return err
+	}
+
+	for _, frequencyCap := range tp.FrequencyCaps {
+		if err := frequencyCap.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
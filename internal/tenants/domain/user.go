@@ -5,4 +5,6 @@ type User struct {
 	Email       string
 	PhoneNumber string
 	DeviceID    string
+	// Locale is the user's preferred language, e.g. "en" or "pt-BR"
+	Locale string
 }
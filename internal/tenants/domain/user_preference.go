@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -10,13 +11,40 @@ type ChannelType string
 
 // Different notification channels
 const (
-	ChannelTypeEmail     ChannelType = "email"
-	ChannelTypeSMS       ChannelType = "sms"
-	ChannelTypePush      ChannelType = "push"
-	ChannelTypeWebPush   ChannelType = "web-push"
-	ChannelTypeInApp     ChannelType = "in-app"
+	ChannelTypeEmail   ChannelType = "email"
+	ChannelTypeSMS     ChannelType = "sms"
+	ChannelTypePush    ChannelType = "push"
+	ChannelTypeWebPush ChannelType = "web-push"
+	ChannelTypeInApp   ChannelType = "in-app"
+	ChannelTypeChat    ChannelType = "chat"
 )
 
+// ChannelTypes lists every supported channel
+var ChannelTypes = []ChannelType{
+	ChannelTypeEmail,
+	ChannelTypeSMS,
+	ChannelTypePush,
+	ChannelTypeWebPush,
+	ChannelTypeInApp,
+	ChannelTypeChat,
+}
+
+// IsValid reports whether the channel type is a known channel
+func (c ChannelType) IsValid() bool {
+	for _, channel := range ChannelTypes {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeChannel returns the channel type for a channel name as sent by
+// callers, e.g. "SMS" becomes "sms"
+func NormalizeChannel(channel string) ChannelType {
+	return ChannelType(strings.ToLower(strings.TrimSpace(channel)))
+}
+
 // DigestType represents how notification digests should be delivered
 type DigestType string
 
@@ -32,32 +60,60 @@ type UserPreference struct {
 	ID             string                        `json:"id"`
 	UserID         string                        `json:"userId"`
 	TenantID       string                        `json:"tenantId"`
-	Enabled        bool                          `json:"enabled"`          // Master toggle for all notifications
-	ChannelPrefs   map[ChannelType]bool          `json:"channelPrefs"`     // Enable/disable specific channels
-	CategoryPrefs  map[string]CategoryPreference `json:"categoryPrefs"`    // Preferences by notification category
-	DigestSettings DigestSettings                `json:"digestSettings"`   // Digest configuration
+	Enabled        bool                          `json:"enabled"`              // Master toggle for all notifications
+	ChannelPrefs   map[ChannelType]bool          `json:"channelPrefs"`         // Enable/disable specific channels
+	CategoryPrefs  map[string]CategoryPreference `json:"categoryPrefs"`        // Preferences by notification category
+	DigestSettings DigestSettings                `json:"digestSettings"`       // Digest configuration
+	Timezone       string                        `json:"timezone,omitempty"`   // IANA timezone; empty uses the tenant default
+	QuietHours     *QuietHours                   `json:"quietHours,omitempty"` // Do-not-disturb window; nil uses the tenant default
 	CreatedAt      time.Time                     `json:"createdAt"`
 	UpdatedAt      time.Time                     `json:"updatedAt"`
 }
 
 // CategoryPreference represents preferences for a specific notification category
 type CategoryPreference struct {
-	Enabled       bool                     `json:"enabled"`
-	ChannelPrefs  map[ChannelType]bool     `json:"channelPrefs"` // Override channel preferences for this category
-	DigestEnabled bool                     `json:"digestEnabled"`
-	DigestType    DigestType               `json:"digestType"`
+	Enabled       bool                 `json:"enabled"`
+	ChannelPrefs  map[ChannelType]bool `json:"channelPrefs"` // Override channel preferences for this category
+	DigestEnabled bool                 `json:"digestEnabled"`
+	DigestType    DigestType           `json:"digestType"`
 }
 
 // DigestSettings represents a user's digest configuration
 type DigestSettings struct {
-	Enabled            bool       `json:"enabled"`
-	Type               DigestType `json:"type"`
-	IntervalMinutes    int        `json:"intervalMinutes"` // Used when Type is DigestTypeInterval
-	DeliveryHour       int        `json:"deliveryHour"`    // Hour of the day (0-23) for delivering digests
-	PreferredDayOfWeek int        `json:"preferredDayOfWeek"` // Day of the week (0=Sun, 6=Sat) for weekly digests
+	Enabled            bool        `json:"enabled"`
+	Type               DigestType  `json:"type"`
+	IntervalMinutes    int         `json:"intervalMinutes"`    // Used when Type is DigestTypeInterval
+	DeliveryHour       int         `json:"deliveryHour"`       // Hour of the day (0-23) for delivering digests
+	PreferredDayOfWeek int         `json:"preferredDayOfWeek"` // Day of the week (0=Sun, 6=Sat) for weekly digests
 	PreferredChannel   ChannelType `json:"preferredChannel"`
 }
 
+// NextDelivery returns when a digest opened at from is delivered: after
+// IntervalMinutes for interval digests, or at the next DeliveryHour (on
+// PreferredDayOfWeek for weekly digests) in loc. Digests without a type are
+// delivered at from.
+func (d DigestSettings) NextDelivery(from time.Time, loc *time.Location) time.Time {
+	switch d.Type {
+	case DigestTypeInterval:
+		return from.Add(time.Duration(d.IntervalMinutes) * time.Minute)
+	case DigestTypeDaily, DigestTypeWeekly:
+		local := from.In(loc)
+		next := time.Date(local.Year(), local.Month(), local.Day(), d.DeliveryHour, 0, 0, 0, loc)
+		if d.Type == DigestTypeWeekly {
+			next = next.AddDate(0, 0, (d.PreferredDayOfWeek-int(next.Weekday())+7)%7)
+		}
+		for !next.After(local) {
+			if d.Type == DigestTypeWeekly {
+				next = next.AddDate(0, 0, 7)
+			} else {
+				next = next.AddDate(0, 0, 1)
+			}
+		}
+		return next
+	}
+	return from
+}
+
 // NewUserPreference creates a new UserPreference with default values
 func NewUserPreference(userID, tenantID string) *UserPreference {
 	return &UserPreference{
@@ -70,6 +126,7 @@ func NewUserPreference(userID, tenantID string) *UserPreference {
 			ChannelTypePush:    true,
 			ChannelTypeWebPush: true,
 			ChannelTypeInApp:   true,
+			ChannelTypeChat:    true,
 		},
 		CategoryPrefs: map[string]CategoryPreference{},
 		DigestSettings: DigestSettings{
@@ -90,11 +147,21 @@ func (up *UserPreference) Validate() error {
 	if up.UserID == "" {
 		return errors.New("user ID cannot be empty")
 	}
-	
+
 	if up.TenantID == "" {
 		return errors.New("tenant ID cannot be empty")
 	}
-	
+
+	if _, err := LoadTimezone(up.Timezone); err != nil {
+		return err
+	}
+
+	if up.QuietHours != nil {
+		if err := up.QuietHours.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// Validate digest settings
 	if up.DigestSettings.Enabled {
 		switch up.DigestSettings.Type {
@@ -115,6 +182,6 @@ func (up *UserPreference) Validate() error {
 			}
 		}
 	}
-	
+
 	return nil
 }
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrInvalidWebPushSubscription = errors.New("invalid web push subscription")
+
+// WebPushSubscription is a browser's push subscription for a user, as returned
+// by PushManager.subscribe(). P256dh and Auth are the subscription's base64url
+// encoded keys used to encrypt payloads for the browser.
+type WebPushSubscription struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userId"`
+	Endpoint  string    `json:"endpoint"`
+	P256dh    string    `json:"p256dh"`
+	Auth      string    `json:"auth"`
+	UserAgent string    `json:"userAgent,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Validate checks the endpoint is an HTTPS URL and the keys have the sizes RFC 8291 requires
+func (s WebPushSubscription) Validate() error {
+	if s.UserID == "" {
+		return fmt.Errorf("%w: user ID cannot be empty", ErrInvalidWebPushSubscription)
+	}
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("%w: endpoint must be an https URL", ErrInvalidWebPushSubscription)
+	}
+	if key, err := DecodeWebPushKey(s.P256dh); err != nil || len(key) != 65 || key[0] != 0x04 {
+		return fmt.Errorf("%w: p256dh must be an uncompressed P-256 public key", ErrInvalidWebPushSubscription)
+	}
+	if secret, err := DecodeWebPushKey(s.Auth); err != nil || len(secret) != 16 {
+		return fmt.Errorf("%w: auth must be a 16 byte secret", ErrInvalidWebPushSubscription)
+	}
+	return nil
+}
+
+// DecodeWebPushKey decodes a base64url key, with or without padding as browsers vary
+func DecodeWebPushKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
@@ -0,0 +1,146 @@
+package apikeyroutes
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"getnoti.com/internal/container"
+	"getnoti.com/internal/shared/handler"
+	"getnoti.com/internal/shared/middleware"
+	"getnoti.com/internal/tenants/domain"
+	"getnoti.com/pkg/db"
+	"github.com/go-chi/chi/v5"
+)
+
+var errAPIKeyIDRequired = errors.New("api key ID is required")
+
+// CreateAPIKeyRequest is the body accepted when issuing a new key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeyResponse returns a newly issued key. The raw key is only shown once.
+type APIKeyResponse struct {
+	APIKey *domain.APIKey `json:"api_key"`
+	Key    string         `json:"key"`
+}
+
+type Handlers struct {
+	BaseHandler      *handler.BaseHandler
+	ServiceContainer *container.ServiceContainer
+}
+
+func NewHandlers(baseHandler *handler.BaseHandler, serviceContainer *container.ServiceContainer) *Handlers {
+	return &Handlers{
+		BaseHandler:      baseHandler,
+		ServiceContainer: serviceContainer,
+	}
+}
+
+// CreateAPIKey handles HTTP requests to issue a new API key for the tenant
+func (h *Handlers) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	var req CreateAPIKeyRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	key, rawKey, err := h.ServiceContainer.GetAPIKeyService().CreateAPIKey(r.Context(), tenantID, req.Name, req.ExpiresAt)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to create API key", err, apiKeyErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSONStatus(w, http.StatusCreated, APIKeyResponse{APIKey: key, Key: rawKey})
+}
+
+// GetAPIKeys handles HTTP requests to list the tenant's API keys
+func (h *Handlers) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	keys, err := h.ServiceContainer.GetAPIKeyService().ListAPIKeys(r.Context(), tenantID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to list API keys", err, http.StatusInternalServerError)
+		return
+	}
+
+	if keys == nil {
+		keys = []*domain.APIKey{}
+	}
+
+	h.BaseHandler.RespondWithJSON(w, map[string]interface{}{
+		"api_keys": keys,
+	})
+}
+
+// RevokeAPIKey handles HTTP requests to revoke an API key
+func (h *Handlers) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	keyID := chi.URLParam(r, "id")
+
+	if keyID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errAPIKeyIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.ServiceContainer.GetAPIKeyService().RevokeAPIKey(r.Context(), tenantID, keyID); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to revoke API key", err, apiKeyErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, map[string]string{
+		"message": "API key revoked successfully",
+	})
+}
+
+// RotateAPIKey handles HTTP requests to replace an API key with a new one
+func (h *Handlers) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	keyID := chi.URLParam(r, "id")
+
+	if keyID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errAPIKeyIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	key, rawKey, err := h.ServiceContainer.GetAPIKeyService().RotateAPIKey(r.Context(), tenantID, keyID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to rotate API key", err, apiKeyErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSONStatus(w, http.StatusCreated, APIKeyResponse{APIKey: key, Key: rawKey})
+}
+
+// apiKeyErrorStatus maps service errors to HTTP status codes
+func apiKeyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrAPIKeyRevoked):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrAPIKeyInvalid):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// NewRouter sets up the router with all API key routes
+func NewRouter(serviceContainer *container.ServiceContainer, dbManager *db.Manager) *chi.Mux {
+	b := handler.NewBaseHandler(dbManager)
+	h := NewHandlers(b, serviceContainer)
+
+	r := chi.NewRouter()
+
+	// Set up routes
+	r.Post("/", h.CreateAPIKey)
+	r.Get("/", h.GetAPIKeys)
+	r.Delete("/{id}", h.RevokeAPIKey)
+	r.Post("/{id}/rotate", h.RotateAPIKey)
+
+	return r
+}
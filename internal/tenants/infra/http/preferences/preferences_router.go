@@ -1,6 +1,7 @@
 package preferencesroutes
 
 import (
+	"errors"
 	"net/http"
 
 	"getnoti.com/internal/shared/handler"
@@ -8,7 +9,9 @@ import (
 	"getnoti.com/internal/shared/utils"
 	repository "getnoti.com/internal/tenants/repos"
 	repos "getnoti.com/internal/tenants/repos/implementations"
+	gettenantpreferences "getnoti.com/internal/tenants/usecases/get_tenant_preferences"
 	getuserpreferences "getnoti.com/internal/tenants/usecases/get_user_preferences"
+	updatetenantpreferences "getnoti.com/internal/tenants/usecases/update_tenant_preferences"
 	updateuserpreferences "getnoti.com/internal/tenants/usecases/update_user_preferences"
 	"github.com/go-chi/chi/v5"
 )
@@ -36,7 +39,7 @@ func (h *Handlers) getRepos(r *http.Request) (repository.UserPreferenceRepositor
 	// Initialize repositories
 	userPrefRepo := repos.NewUserPreferenceRepository(database)
 	userRepo := repos.NewUserRepository(database)
-	
+
 	return userPrefRepo, userRepo, nil
 }
 
@@ -52,7 +55,7 @@ func (h *Handlers) GetUserPreferences(w http.ResponseWriter, r *http.Request) {
 	getUserPrefsController := getuserpreferences.NewGetUserPreferencesController(getUserPrefsUseCase)
 
 	var req getuserpreferences.GetUserPreferencesRequest
-	
+
 	// Get user ID from URL parameter
 	userID := chi.URLParam(r, "userID")
 	if userID == "" {
@@ -115,16 +118,85 @@ func (h *Handlers) UpdateUserPreferences(w http.ResponseWriter, r *http.Request)
 	h.BaseHandler.RespondWithJSON(w, res)
 }
 
+// Helper function to retrieve the tenant preference repository
+func (h *Handlers) getTenantPreferenceRepo(r *http.Request) (repository.TenantPreferenceRepository, error) {
+	database, err := h.BaseHandler.GetTenantDB(r)
+	if err != nil {
+		return nil, err
+	}
+	return repos.NewTenantPreferenceRepository(database), nil
+}
+
+// GetTenantPreferences retrieves the tenant's default preferences
+func (h *Handlers) GetTenantPreferences(w http.ResponseWriter, r *http.Request) {
+	tenantPrefRepo, err := h.getTenantPreferenceRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	getTenantPrefsUseCase := gettenantpreferences.NewGetTenantPreferencesUseCase(tenantPrefRepo)
+	getTenantPrefsController := gettenantpreferences.NewGetTenantPreferencesController(getTenantPrefsUseCase)
+
+	var req gettenantpreferences.GetTenantPreferencesRequest
+	if err := utils.AddTenantIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process tenant ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := getTenantPrefsController.GetTenantPreferences(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get tenant preferences", err, http.StatusInternalServerError)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+// UpdateTenantPreferences updates the tenant's default preferences
+func (h *Handlers) UpdateTenantPreferences(w http.ResponseWriter, r *http.Request) {
+	tenantPrefRepo, err := h.getTenantPreferenceRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	updateTenantPrefsUseCase := updatetenantpreferences.NewUpdateTenantPreferencesUseCase(tenantPrefRepo)
+	updateTenantPrefsController := updatetenantpreferences.NewUpdateTenantPreferencesController(updateTenantPrefsUseCase)
+
+	var req updatetenantpreferences.UpdateTenantPreferencesRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	// The tenant always comes from the authenticated request, never the body
+	if err := utils.AddTenantIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process tenant ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := updateTenantPrefsController.UpdateTenantPreferences(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, updatetenantpreferences.ErrInvalidPreferences) {
+			status = http.StatusBadRequest
+		}
+		h.BaseHandler.HandleError(w, "Failed to update tenant preferences", err, status)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
 // NewRouter sets up the router with all routes
 func NewRouter(baseHandler *handler.BaseHandler) http.Handler {
 	h := NewHandlers(baseHandler)
 	r := chi.NewRouter()
 
-	// Apply the tenant middleware to all routes
-	r.Use(middleware.WithTenantID)
-
 	r.Get("/users/{userID}/preferences", h.GetUserPreferences)
 	r.Put("/users/{userID}/preferences", h.UpdateUserPreferences)
+	r.Get("/tenant", h.GetTenantPreferences)
+	r.Put("/tenant", h.UpdateTenantPreferences)
 
 	return r
 }
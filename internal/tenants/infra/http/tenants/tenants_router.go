@@ -40,19 +40,18 @@
 //     return tenantsRepo, nil
 // }
 
-
 // func (h *Handlers) CreateTenant(w http.ResponseWriter, r *http.Request) {
 //     // 1. Parse the request
 //     var req createtenant.CreateTenantRequest
 //     if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
 //         return
 //     }
-    
+
 //     // 2. Generate UUID if tenant ID not provided
 //     if req.ID == "" {
 //         req.ID = uuid.New().String()
 //     }
-    
+
 //     // 3. Create database if config not provided
 //     if req.DBConfig == nil {
 //         database, dbConfigMap, err := h.BaseHandler.DBManager.CreateNewTenantDatabase(req.ID)
@@ -60,13 +59,13 @@
 //             h.BaseHandler.HandleError(w, "Failed to create tenant database", err, http.StatusInternalServerError)
 //             return
 //         }
-        
+
 //         // Convert the config map to DBCredentials
 //         req.DBConfig = &domain.DBCredentials{
 //             Type: dbConfigMap["type"].(string),
 //             DSN:  dbConfigMap["dsn"].(string),
 //         }
-        
+
 //         // Run migrations on the new database
 //         if err := migration.Migrate(req.DBConfig.DSN, req.DBConfig.Type, false); err != nil {
 //             h.BaseHandler.HandleError(w, "Failed to run migrations", err, http.StatusInternalServerError)
@@ -79,32 +78,32 @@
 //             "type": req.DBConfig.Type,
 //             "dsn":  req.DBConfig.DSN,
 //         }
-        
+
 //         // Validate connection
 //         conn, err := h.BaseHandler.DBManager.GetDatabaseConnectionWithConfig(req.ID, dbConfig)
 //         if err != nil {
 //             h.BaseHandler.HandleError(w, "Invalid database configuration", err, http.StatusBadRequest)
 //             return
 //         }
-        
+
 //         // Run migrations
 //         if err := migration.Migrate(req.DBConfig.DSN, req.DBConfig.Type, false); err != nil {
 //             h.BaseHandler.HandleError(w, "Failed to run migrations", err, http.StatusInternalServerError)
 //             return
 //         }
 //     }
-    
+
 //     // 4. Create tenant in repository
 //     tenantRepo := repos.NewTenantRepository(h.mainDB, h.credentialManager)
 //     createTenantUseCase := createtenant.NewCreateTenantUseCase(tenantRepo)
 //     createTenantController := createtenant.NewCreateTenantController(createTenantUseCase)
-    
+
 //     res, err := createTenantController.CreateTenant(r.Context(), req)
 //     if err != nil {
 //         h.BaseHandler.HandleError(w, "Failed to create tenant", err, http.StatusInternalServerError)
 //         return
 //     }
-    
+
 //     h.BaseHandler.RespondWithJSON(w, res)
 // }
 
@@ -187,7 +186,7 @@
 // 	r := chi.NewRouter()
 
 // 	// Set up routes
-// 	r.Post("/", h.CreateTenant)
+// 	r.With(adminAuth).Post("/", h.CreateTenant)
 // 	r.With(tenantMiddleware.WithTenantID).Put("/", h.UpdateTenant)
 // 	r.With(tenantMiddleware.WithTenantID).Get("/me", h.GetTenant)
 // 	r.With(tenantMiddleware.WithTenantID).Get("/{id}", h.GetTenant)
@@ -199,255 +198,341 @@
 package tenantroutes
 
 import (
-    "fmt"
-    "net/http"
-
-    "getnoti.com/internal/shared/handler"
-    tenantMiddleware "getnoti.com/internal/shared/middleware"
-    "getnoti.com/internal/shared/utils"
-    "getnoti.com/internal/tenants/domain"
-    repository "getnoti.com/internal/tenants/repos"
-    repos "getnoti.com/internal/tenants/repos/implementations"
-    "getnoti.com/internal/tenants/usecases/create_tenant"
-    "getnoti.com/internal/tenants/usecases/get_tenant"
-    "getnoti.com/internal/tenants/usecases/get_tenants"
-    "getnoti.com/internal/tenants/usecases/update_tenant"
-    "getnoti.com/pkg/credentials"
-    "getnoti.com/pkg/db"
-    "getnoti.com/pkg/migration"
-    "github.com/go-chi/chi/v5"
-    "github.com/google/uuid"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"getnoti.com/internal/shared/handler"
+	tenantMiddleware "getnoti.com/internal/shared/middleware"
+	"getnoti.com/internal/shared/utils"
+	"getnoti.com/internal/tenants/domain"
+	repository "getnoti.com/internal/tenants/repos"
+	repos "getnoti.com/internal/tenants/repos/implementations"
+	tenantServices "getnoti.com/internal/tenants/services"
+	"getnoti.com/internal/tenants/usecases/create_tenant"
+	"getnoti.com/internal/tenants/usecases/get_tenant"
+	"getnoti.com/internal/tenants/usecases/get_tenants"
+	"getnoti.com/internal/tenants/usecases/update_tenant"
+	"getnoti.com/pkg/credentials"
+	"getnoti.com/pkg/db"
+	"getnoti.com/pkg/migration"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
 // TenantInfo stores tenant database configuration information
 type TenantInfo struct {
-    ID       string
-    DBConfig *domain.DBCredentials
+	ID       string
+	DBConfig *domain.DBCredentials
 }
 
+// initialAPIKeyName names the key issued when a tenant is created
+const initialAPIKeyName = "default"
+
 type Handlers struct {
-    BaseHandler       *handler.BaseHandler
-    mainDB            db.Database
-    credentialManager *credentials.Manager
+	BaseHandler       *handler.BaseHandler
+	mainDB            db.Database
+	credentialManager *credentials.Manager
+	apiKeyService     *tenantServices.APIKeyService
 }
 
-func NewHandlers(baseHandler *handler.BaseHandler, mainDB db.Database, credentialManager *credentials.Manager) *Handlers {
-    return &Handlers{
-        BaseHandler:       baseHandler,
-        mainDB:            mainDB,
-        credentialManager: credentialManager,
-    }
+func NewHandlers(baseHandler *handler.BaseHandler, mainDB db.Database, credentialManager *credentials.Manager, apiKeyService *tenantServices.APIKeyService) *Handlers {
+	return &Handlers{
+		BaseHandler:       baseHandler,
+		mainDB:            mainDB,
+		credentialManager: credentialManager,
+		apiKeyService:     apiKeyService,
+	}
 }
 
 // Helper function to retrieve tenant repository
 func (h *Handlers) getTenantsRepo(r *http.Request) (repository.TenantsRepository, error) {
-    tenantsRepo := repos.NewTenantRepository(h.mainDB, h.credentialManager)
-    return tenantsRepo, nil
+	tenantsRepo := repos.NewTenantRepository(h.mainDB, h.credentialManager)
+	return tenantsRepo, nil
 }
 
 func (h *Handlers) CreateTenant(w http.ResponseWriter, r *http.Request) {
-    // 1. Parse the request body
-    var req createtenant.CreateTenantRequest
-    if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
-        return
-    }
-    
-    // 2. Process tenant database configuration
-    tenantInfo := &TenantInfo{
-        ID:       req.ID,
-        DBConfig: req.DBConfig,
-    }
-    
-    // Generate tenant ID if not provided
-    if tenantInfo.ID == "" {
-        tenantInfo.ID = uuid.New().String()
-        req.ID = tenantInfo.ID
-    }
-    
-    // 3. Get or create database connection and configure it
-    _, err := h.getDatabaseConnection(tenantInfo)
-    if err != nil {
-        h.BaseHandler.HandleError(w, "Failed to configure tenant database", err, http.StatusInternalServerError)
-        return
-    }
-    
-    // Update request with final configuration
-    req.DBConfig = tenantInfo.DBConfig
-    
-    // 4. Create tenant in repository
-    tenantRepo := repos.NewTenantRepository(h.mainDB, h.credentialManager)
-    createTenantUseCase := createtenant.NewCreateTenantUseCase(tenantRepo)
-    createTenantController := createtenant.NewCreateTenantController(createTenantUseCase)
-    
-    res, err := createTenantController.CreateTenant(r.Context(), req)
-    if err != nil {
-        h.BaseHandler.HandleError(w, "Failed to create tenant", err, http.StatusInternalServerError)
-        return
-    }
-    
-    h.BaseHandler.RespondWithJSON(w, res)
+	// 1. Parse the request body
+	var req createtenant.CreateTenantRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	// 2. Process tenant database configuration
+	tenantInfo := &TenantInfo{
+		ID:       req.ID,
+		DBConfig: req.DBConfig,
+	}
+
+	// Generate tenant ID if not provided
+	if tenantInfo.ID == "" {
+		tenantInfo.ID = uuid.New().String()
+		req.ID = tenantInfo.ID
+	}
+
+	// 3. Get or create database connection and configure it
+	_, err := h.getDatabaseConnection(tenantInfo)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to configure tenant database", err, http.StatusInternalServerError)
+		return
+	}
+
+	// Update request with final configuration
+	req.DBConfig = tenantInfo.DBConfig
+
+	// 4. Create tenant in repository
+	tenantRepo := repos.NewTenantRepository(h.mainDB, h.credentialManager)
+	createTenantUseCase := createtenant.NewCreateTenantUseCase(tenantRepo)
+	createTenantController := createtenant.NewCreateTenantController(createTenantUseCase)
+
+	res, err := createTenantController.CreateTenant(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to create tenant", err, http.StatusInternalServerError)
+		return
+	}
+
+	// 5. Issue the tenant's initial API key
+	_, rawKey, err := h.apiKeyService.CreateAPIKey(r.Context(), res.ID, initialAPIKeyName, nil)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to create tenant API key", err, http.StatusInternalServerError)
+		return
+	}
+	res.APIKey = rawKey
+
+	h.BaseHandler.RespondWithJSON(w, res)
 }
 
 // UpdateTenant handles the updating of an existing tenant
 func (h *Handlers) UpdateTenant(w http.ResponseWriter, r *http.Request) {
-    tenantRepo, err := h.getTenantsRepo(r)
-    if err != nil {
-        h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
-        return
-    }
-
-    updateTenantUseCase := updatetenant.NewUpdateTenantUseCase(tenantRepo)
-    updateTenantController := updatetenant.NewUpdateTenantController(updateTenantUseCase)
-
-    var req updatetenant.UpdateTenantRequest
-    if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
-        return
-    }
-
-    // If DB config is provided, validate the connection
-    if req.DBConfig != nil {
-        tenantInfo := &TenantInfo{
-            ID:       req.ID,
-            DBConfig: req.DBConfig,
-        }
-        _, err := h.validateDatabaseConfig(tenantInfo)
-        if err != nil {
-            h.BaseHandler.HandleError(w, "Invalid database configuration", err, http.StatusBadRequest)
-            return
-        }
-    }
-
-    res, err := updateTenantController.UpdateTenant(r.Context(), req)
-    if err != nil {
-        h.BaseHandler.HandleError(w, "Failed to update tenant", err, http.StatusInternalServerError)
-        return
-    }
-
-    h.BaseHandler.RespondWithJSON(w, res)
+	tenantRepo, err := h.getTenantsRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	updateTenantUseCase := updatetenant.NewUpdateTenantUseCase(tenantRepo)
+	updateTenantController := updatetenant.NewUpdateTenantController(updateTenantUseCase)
+
+	var req updatetenant.UpdateTenantRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	// The tenant being updated is always the one that owns the API key
+	tenantID, err := utils.GetTenantIDFromReq(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get tenant ID", err, http.StatusUnauthorized)
+		return
+	}
+	req.ID = tenantID
+
+	// If DB config is provided, validate the connection
+	if req.DBConfig != nil {
+		tenantInfo := &TenantInfo{
+			ID:       req.ID,
+			DBConfig: req.DBConfig,
+		}
+		_, err := h.validateDatabaseConfig(tenantInfo)
+		if err != nil {
+			h.BaseHandler.HandleError(w, "Invalid database configuration", err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	res, err := updateTenantController.UpdateTenant(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to update tenant", err, http.StatusInternalServerError)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
 }
 
 // GetTenant retrieves a tenant by ID
 func (h *Handlers) GetTenant(w http.ResponseWriter, r *http.Request) {
-    tenantRepo, err := h.getTenantsRepo(r)
-    if err != nil {
-        h.BaseHandler.HandleError(w, "Failed to get tenant repository", err, http.StatusInternalServerError)
-        return
-    }
-
-    getTenantUseCase := gettenant.NewGetTenantUseCase(tenantRepo)
-    getTenantController := gettenant.NewGetTenantController(getTenantUseCase)
-    id, err := utils.GetIDFromReq(r)
-    if err != nil {
-        h.BaseHandler.HandleError(w, "Failed to get tenant ID", err, http.StatusBadRequest)
-        return
-    }
-
-    req := gettenant.GetTenantRequest{TenantID: id}
-
-    res, err := getTenantController.GetTenant(r.Context(), req)
-    if err != nil {
-        h.BaseHandler.HandleError(w, "Failed to get tenant", err, http.StatusInternalServerError)
-        return
-    }
-
-    h.BaseHandler.RespondWithJSON(w, res)
+	tenantRepo, err := h.getTenantsRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get tenant repository", err, http.StatusInternalServerError)
+		return
+	}
+
+	getTenantUseCase := gettenant.NewGetTenantUseCase(tenantRepo)
+	getTenantController := gettenant.NewGetTenantController(getTenantUseCase)
+	id, err := utils.GetIDFromReq(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get tenant ID", err, http.StatusBadRequest)
+		return
+	}
+
+	// Tenants may only read their own record
+	if tenantID, _ := utils.GetTenantIDFromReq(r); id != tenantID {
+		http.Error(w, "Tenant not found", http.StatusNotFound)
+		return
+	}
+
+	req := gettenant.GetTenantRequest{TenantID: id}
+
+	res, err := getTenantController.GetTenant(r.Context(), req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get tenant", err, http.StatusInternalServerError)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
 }
 
 // GetTenants retrieves all tenants
 func (h *Handlers) GetTenants(w http.ResponseWriter, r *http.Request) {
-    tenantsRepo, err := h.getTenantsRepo(r)
-    if err != nil {
-        h.BaseHandler.HandleError(w, "Failed to get tenant repository", err, http.StatusInternalServerError)
-        return
-    }
-    getTenantsUseCase := gettenants.NewGetTenantsUseCase(tenantsRepo)
-    getTenantsController := gettenants.NewGetTenantsController(getTenantsUseCase)
-
-    res, err := getTenantsController.GetTenants(r.Context(), gettenants.GetTenantsRequest{})
-    if err != nil {
-        h.BaseHandler.HandleError(w, "Failed to get tenants", err, http.StatusInternalServerError)
-        return
-    }
-
-    h.BaseHandler.RespondWithJSON(w, res)
+	tenantsRepo, err := h.getTenantsRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get tenant repository", err, http.StatusInternalServerError)
+		return
+	}
+	getTenantsUseCase := gettenants.NewGetTenantsUseCase(tenantsRepo)
+	getTenantsController := gettenants.NewGetTenantsController(getTenantsUseCase)
+
+	res, err := getTenantsController.GetTenants(r.Context(), gettenants.GetTenantsRequest{})
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get tenants", err, http.StatusInternalServerError)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+// IssueTenantAPIKeyRequest is the optional body accepted when an operator issues a tenant key
+type IssueTenantAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// IssueTenantAPIKey lets an operator issue a key for an existing tenant, such as
+// a tenant created before API keys existed that has no key to manage others with
+func (h *Handlers) IssueTenantAPIKey(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := utils.GetIDFromReq(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get tenant ID", err, http.StatusBadRequest)
+		return
+	}
+
+	var req IssueTenantAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Name == "" {
+		req.Name = initialAPIKeyName
+	}
+
+	tenantRepo, err := h.getTenantsRepo(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get tenant repository", err, http.StatusInternalServerError)
+		return
+	}
+	if _, err := tenantRepo.GetTenantByID(r.Context(), tenantID); err != nil {
+		h.BaseHandler.HandleError(w, "Tenant not found", err, http.StatusNotFound)
+		return
+	}
+
+	key, rawKey, err := h.apiKeyService.CreateAPIKey(r.Context(), tenantID, req.Name, req.ExpiresAt)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrAPIKeyInvalid) {
+			status = http.StatusBadRequest
+		}
+		h.BaseHandler.HandleError(w, "Failed to create tenant API key", err, status)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSONStatus(w, http.StatusCreated, map[string]interface{}{
+		"api_key": key,
+		"key":     rawKey,
+	})
 }
 
 // Database connection related helper methods
 func (h *Handlers) getDatabaseConnection(tenantInfo *TenantInfo) (db.Database, error) {
-    // If DB config is provided, validate it
-    if tenantInfo.DBConfig != nil {
-        return h.validateDatabaseConfig(tenantInfo)
-    }
-    
-    // Create new tenant database with default config
-    database, dbConfigMap, err := h.BaseHandler.DBManager.CreateNewTenantDatabase(tenantInfo.ID)
-    if err != nil {
-        return nil, fmt.Errorf("failed to create tenant database: %w", err)
-    }
-    
-    // Set DB config in tenant info
-    tenantInfo.DBConfig = &domain.DBCredentials{
-        Type: dbConfigMap["type"].(string),
-        DSN:  dbConfigMap["dsn"].(string),
-    }
-    
-    // Run migrations on the new database
-    if err := migration.Migrate(tenantInfo.DBConfig.DSN, tenantInfo.DBConfig.Type, false); err != nil {
-        return nil, fmt.Errorf("failed to run migrations: %w", err)
-    }
-    
-    return database, nil
+	// If DB config is provided, validate it
+	if tenantInfo.DBConfig != nil {
+		return h.validateDatabaseConfig(tenantInfo)
+	}
+
+	// Create new tenant database with default config
+	database, dbConfigMap, err := h.BaseHandler.DBManager.CreateNewTenantDatabase(tenantInfo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tenant database: %w", err)
+	}
+
+	// Set DB config in tenant info
+	tenantInfo.DBConfig = &domain.DBCredentials{
+		Type: dbConfigMap["type"].(string),
+		DSN:  dbConfigMap["dsn"].(string),
+	}
+
+	// Run migrations on the new database
+	if err := migration.Migrate(tenantInfo.DBConfig.DSN, tenantInfo.DBConfig.Type, false); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return database, nil
 }
 
 func (h *Handlers) validateDatabaseConfig(tenantInfo *TenantInfo) (db.Database, error) {
-    dbConfig := h.convertDBCredentialsToMap(tenantInfo.DBConfig)
-    
-    // Validate connection
-    database, err := h.BaseHandler.DBManager.GetDatabaseConnectionWithConfig(tenantInfo.ID, dbConfig)
-    if err != nil {
-        return nil, fmt.Errorf("invalid database configuration: %w", err)
-    }
-    
-    // Run migrations
-    if err := migration.Migrate(tenantInfo.DBConfig.DSN, tenantInfo.DBConfig.Type, false); err != nil {
-        return nil, fmt.Errorf("failed to run migrations: %w", err)
-    }
-    
-    return database, nil
+	dbConfig := h.convertDBCredentialsToMap(tenantInfo.DBConfig)
+
+	// Validate connection
+	database, err := h.BaseHandler.DBManager.GetDatabaseConnectionWithConfig(tenantInfo.ID, dbConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+
+	// Run migrations
+	if err := migration.Migrate(tenantInfo.DBConfig.DSN, tenantInfo.DBConfig.Type, false); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return database, nil
 }
 
 // Helper function to convert DBCredentials to map[string]interface{}
 func (h *Handlers) convertDBCredentialsToMap(dbCredentials *domain.DBCredentials) map[string]interface{} {
-    if dbCredentials.DSN != "" {
-        return map[string]interface{}{
-            "type": dbCredentials.Type,
-            "dsn":  dbCredentials.DSN,
-        }
-    }
-
-    return map[string]interface{}{
-        "type":     dbCredentials.Type,
-        "host":     dbCredentials.Host,
-        "port":     dbCredentials.Port,
-        "username": dbCredentials.Username,
-        "password": dbCredentials.Password,
-        "database": dbCredentials.DBName,
-    }
+	if dbCredentials.DSN != "" {
+		return map[string]interface{}{
+			"type": dbCredentials.Type,
+			"dsn":  dbCredentials.DSN,
+		}
+	}
+
+	return map[string]interface{}{
+		"type":     dbCredentials.Type,
+		"host":     dbCredentials.Host,
+		"port":     dbCredentials.Port,
+		"username": dbCredentials.Username,
+		"password": dbCredentials.Password,
+		"database": dbCredentials.DBName,
+	}
 }
 
 // NewRouter sets up the router with all routes
-func NewRouter(mainDB db.Database, dbManager *db.Manager, credentialManager *credentials.Manager) *chi.Mux {
-    b := handler.NewBaseHandler(dbManager)
-    h := NewHandlers(b, mainDB, credentialManager)
-
-    r := chi.NewRouter()
-
-    // Set up routes
-    r.Post("/", h.CreateTenant)
-    r.With(tenantMiddleware.WithTenantID).Put("/", h.UpdateTenant)
-    r.With(tenantMiddleware.WithTenantID).Get("/me", h.GetTenant)
-    r.With(tenantMiddleware.WithTenantID).Get("/{id}", h.GetTenant)
-    r.Get("/", h.GetTenants)
-
-    return r
+// Creating and listing tenants and issuing keys for any tenant need the operator's admin key.
+func NewRouter(mainDB db.Database, dbManager *db.Manager, credentialManager *credentials.Manager, apiKeyService *tenantServices.APIKeyService, adminKey string) *chi.Mux {
+	b := handler.NewBaseHandler(dbManager)
+	h := NewHandlers(b, mainDB, credentialManager, apiKeyService)
+	tenantAuth := tenantMiddleware.WithAPIKey(apiKeyService)
+	adminAuth := tenantMiddleware.WithAdminKey(adminKey)
+
+	r := chi.NewRouter()
+
+	// Set up routes
+	r.Post("/", h.CreateTenant)
+	r.With(tenantAuth).Put("/", h.UpdateTenant)
+	r.With(tenantAuth).Get("/me", h.GetTenant)
+	r.With(tenantAuth).Get("/{id}", h.GetTenant)
+	r.With(adminAuth).Get("/", h.GetTenants)
+	r.With(adminAuth).Post("/{id}/api-keys", h.IssueTenantAPIKey)
+
+	return r
 }
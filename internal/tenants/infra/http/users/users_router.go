@@ -1,6 +1,7 @@
 package userroutes
 
 import (
+	"errors"
 	"net/http"
 
 	"getnoti.com/internal/shared/handler"
@@ -9,7 +10,10 @@ import (
 	repository "getnoti.com/internal/tenants/repos"
 	repos "getnoti.com/internal/tenants/repos/implementations"
 	createuser "getnoti.com/internal/tenants/usecases/create_user"
+	deletewebpushsubscription "getnoti.com/internal/tenants/usecases/delete_web_push_subscription"
 	getusers "getnoti.com/internal/tenants/usecases/get_users"
+	getwebpushsubscriptions "getnoti.com/internal/tenants/usecases/get_web_push_subscriptions"
+	registerwebpushsubscription "getnoti.com/internal/tenants/usecases/register_web_push_subscription"
 	updateuser "getnoti.com/internal/tenants/usecases/update_user"
 	"getnoti.com/pkg/db"
 	"github.com/go-chi/chi/v5"
@@ -54,7 +58,6 @@ func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
 		return
 	}
-	
 
 	res, err := createUserController.CreateUser(r.Context(), req)
 	if err != nil {
@@ -149,6 +152,127 @@ func (h *Handlers) GetUsers(w http.ResponseWriter, r *http.Request) {
 	h.BaseHandler.RespondWithJSON(w, res)
 }
 
+// Helper function to retrieve the web push subscription and user repositories
+func (h *Handlers) getWebPushRepos(r *http.Request) (repository.WebPushSubscriptionRepository, repository.UserRepository, error) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	// Retrieve the database connection
+	database, err := h.BaseHandler.DBManager.GetDatabaseConnection(tenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Initialize repositories
+	subscriptionRepo := repos.NewWebPushSubscriptionRepository(database)
+	userRepo := repos.NewUserRepository(database)
+	return subscriptionRepo, userRepo, nil
+}
+
+// RegisterWebPushSubscription stores a browser push subscription for the user
+func (h *Handlers) RegisterWebPushSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionRepo, userRepo, err := h.getWebPushRepos(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	registerUseCase := registerwebpushsubscription.NewRegisterWebPushSubscriptionUseCase(subscriptionRepo, userRepo)
+	registerController := registerwebpushsubscription.NewRegisterWebPushSubscriptionController(registerUseCase)
+
+	var req registerwebpushsubscription.RegisterWebPushSubscriptionRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	req.UserID = chi.URLParam(r, "id")
+
+	if err := utils.AddTenantIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process tenant ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := registerController.RegisterWebPushSubscription(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, registerwebpushsubscription.ErrInvalidSubscription):
+			status = http.StatusBadRequest
+		case errors.Is(err, registerwebpushsubscription.ErrUserNotFound):
+			status = http.StatusNotFound
+		}
+		h.BaseHandler.HandleError(w, "Failed to register web push subscription", err, status)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+// GetWebPushSubscriptions lists the user's browser push subscriptions
+func (h *Handlers) GetWebPushSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptionRepo, userRepo, err := h.getWebPushRepos(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	getSubscriptionsUseCase := getwebpushsubscriptions.NewGetWebPushSubscriptionsUseCase(subscriptionRepo, userRepo)
+	getSubscriptionsController := getwebpushsubscriptions.NewGetWebPushSubscriptionsController(getSubscriptionsUseCase)
+
+	req := getwebpushsubscriptions.GetWebPushSubscriptionsRequest{
+		UserID: chi.URLParam(r, "id"),
+	}
+
+	if err := utils.AddTenantIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process tenant ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := getSubscriptionsController.GetWebPushSubscriptions(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, getwebpushsubscriptions.ErrUserNotFound) {
+			status = http.StatusNotFound
+		}
+		h.BaseHandler.HandleError(w, "Failed to get web push subscriptions", err, status)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
+// DeleteWebPushSubscription removes one of the user's browser push subscriptions
+func (h *Handlers) DeleteWebPushSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionRepo, _, err := h.getWebPushRepos(r)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retrieve database connection", err, http.StatusInternalServerError)
+		return
+	}
+
+	deleteUseCase := deletewebpushsubscription.NewDeleteWebPushSubscriptionUseCase(subscriptionRepo)
+	deleteController := deletewebpushsubscription.NewDeleteWebPushSubscriptionController(deleteUseCase)
+
+	req := deletewebpushsubscription.DeleteWebPushSubscriptionRequest{
+		UserID:         chi.URLParam(r, "id"),
+		SubscriptionID: chi.URLParam(r, "subscriptionID"),
+	}
+
+	if err := utils.AddTenantIDToRequest(r, &req); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to process tenant ID", err, http.StatusInternalServerError)
+		return
+	}
+
+	res, err := deleteController.DeleteWebPushSubscription(r.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, deletewebpushsubscription.ErrSubscriptionNotFound) {
+			status = http.StatusNotFound
+		}
+		h.BaseHandler.HandleError(w, "Failed to delete web push subscription", err, status)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, res)
+}
+
 func NewRouter(dbManager *db.Manager) *chi.Mux {
 	b := handler.NewBaseHandler(dbManager)
 	h := NewHandlers(b)
@@ -160,6 +284,9 @@ func NewRouter(dbManager *db.Manager) *chi.Mux {
 	r.Put("/{id}", h.UpdateUser)
 	r.Get("/{id}", h.GetUser)
 	r.Get("/", h.GetUsers)
+	r.Post("/{id}/web-push-subscriptions", h.RegisterWebPushSubscription)
+	r.Get("/{id}/web-push-subscriptions", h.GetWebPushSubscriptions)
+	r.Delete("/{id}/web-push-subscriptions/{subscriptionID}", h.DeleteWebPushSubscription)
 
 	return r
 }
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"getnoti.com/internal/tenants/domain"
+)
+
+type APIKeyRepository interface {
+	// Create a new API key
+	CreateAPIKey(ctx context.Context, key *domain.APIKey) error
+
+	// Get an API key by the hash of its raw value
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+
+	// Get an API key belonging to a tenant
+	GetAPIKeyByID(ctx context.Context, tenantID, keyID string) (*domain.APIKey, error)
+
+	// List all API keys for a tenant
+	ListAPIKeys(ctx context.Context, tenantID string) ([]*domain.APIKey, error)
+
+	// Mark an API key as revoked
+	RevokeAPIKey(ctx context.Context, tenantID, keyID string, revokedAt time.Time) error
+
+	// Record when an API key was last used
+	UpdateLastUsed(ctx context.Context, keyID string, usedAt time.Time) error
+}
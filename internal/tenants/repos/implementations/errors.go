@@ -0,0 +1,7 @@
+package repos
+
+import "errors"
+
+var (
+	ErrWebPushSubscriptionNotFound = errors.New("web push subscription not found")
+)
@@ -0,0 +1,156 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/tenants/domain"
+	repository "getnoti.com/internal/tenants/repos"
+	"getnoti.com/pkg/db"
+)
+
+type sqlAPIKeyRepository struct {
+	db db.Database
+}
+
+// NewAPIKeyRepository creates a new SQL API key repository backed by the main database
+func NewAPIKeyRepository(db db.Database) repository.APIKeyRepository {
+	return &sqlAPIKeyRepository{db: db}
+}
+
+func (r *sqlAPIKeyRepository) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	query := `INSERT INTO api_keys (id, tenant_id, name, key_prefix, key_hash, expires_at, created_at, updated_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.Exec(ctx, query, key.ID, key.TenantID, key.Name, key.KeyPrefix, key.KeyHash,
+		nullTime(key.ExpiresAt), key.CreatedAt, key.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *sqlAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `SELECT id, tenant_id, name, key_prefix, key_hash, last_used_at, expires_at, revoked_at, created_at, updated_at
+              FROM api_keys WHERE key_hash = ?`
+
+	key, err := r.scanAPIKey(r.db.QueryRow(ctx, query, keyHash))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+func (r *sqlAPIKeyRepository) GetAPIKeyByID(ctx context.Context, tenantID, keyID string) (*domain.APIKey, error) {
+	query := `SELECT id, tenant_id, name, key_prefix, key_hash, last_used_at, expires_at, revoked_at, created_at, updated_at
+              FROM api_keys WHERE id = ? AND tenant_id = ?`
+
+	key, err := r.scanAPIKey(r.db.QueryRow(ctx, query, keyID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+func (r *sqlAPIKeyRepository) ListAPIKeys(ctx context.Context, tenantID string) ([]*domain.APIKey, error) {
+	query := `SELECT id, tenant_id, name, key_prefix, key_hash, last_used_at, expires_at, revoked_at, created_at, updated_at
+              FROM api_keys WHERE tenant_id = ? ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := r.scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *sqlAPIKeyRepository) RevokeAPIKey(ctx context.Context, tenantID, keyID string, revokedAt time.Time) error {
+	query := `UPDATE api_keys SET revoked_at = ?, updated_at = ? WHERE id = ? AND tenant_id = ? AND revoked_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, revokedAt, revokedAt, keyID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *sqlAPIKeyRepository) UpdateLastUsed(ctx context.Context, keyID string, usedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = ? WHERE id = ?`
+
+	if _, err := r.db.Exec(ctx, query, usedAt, keyID); err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", err)
+	}
+
+	return nil
+}
+
+// scanAPIKey scans an API key from a database row
+func (r *sqlAPIKeyRepository) scanAPIKey(scanner interface{}) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var lastUsedAt, expiresAt, revokedAt sql.NullTime
+
+	var err error
+	switch s := scanner.(type) {
+	case *sql.Row:
+		err = s.Scan(&key.ID, &key.TenantID, &key.Name, &key.KeyPrefix, &key.KeyHash,
+			&lastUsedAt, &expiresAt, &revokedAt, &key.CreatedAt, &key.UpdatedAt)
+	case *sql.Rows:
+		err = s.Scan(&key.ID, &key.TenantID, &key.Name, &key.KeyPrefix, &key.KeyHash,
+			&lastUsedAt, &expiresAt, &revokedAt, &key.CreatedAt, &key.UpdatedAt)
+	default:
+		return nil, fmt.Errorf("unsupported scanner type")
+	}
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to scan api key: %w", err)
+	}
+
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return &key, nil
+}
+
+// nullTime converts an optional time into a nullable column value
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
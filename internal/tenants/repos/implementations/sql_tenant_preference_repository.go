@@ -37,10 +37,20 @@ func (r *sqlTenantPreferenceRepository) CreateTenantPreference(ctx context.Conte
 		return fmt.Errorf("failed to marshal digest settings: %w", err)
 	}
 
+	quietHours, err := json.Marshal(preference.QuietHours)
+	if err != nil {
+		return fmt.Errorf("failed to marshal quiet hours: %w", err)
+	}
+
+	frequencyCaps, err := marshalFrequencyCaps(preference.FrequencyCaps)
+	if err != nil {
+		return err
+	}
+
 	// Insert into the database
 	query := `
-		INSERT INTO tenant_preferences (id, tenant_id, enabled, channel_preferences, category_preferences, digest_settings)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO tenant_preferences (id, tenant_id, enabled, channel_preferences, category_preferences, digest_settings, default_locale, timezone, quiet_hours, frequency_caps)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	_, err = r.db.Exec(ctx, query,
 		preference.ID,
@@ -49,8 +59,12 @@ func (r *sqlTenantPreferenceRepository) CreateTenantPreference(ctx context.Conte
 		channelPrefs,
 		categoryPrefs,
 		digestSettings,
+		preference.DefaultLocale,
+		preference.Timezone,
+		quietHours,
+		frequencyCaps,
 	)
-	
+
 	if err != nil {
 		return fmt.Errorf("failed to create tenant preference: %w", err)
 	}
@@ -61,14 +75,14 @@ func (r *sqlTenantPreferenceRepository) CreateTenantPreference(ctx context.Conte
 // GetTenantPreferenceByTenantID gets tenant preferences by tenant ID
 func (r *sqlTenantPreferenceRepository) GetTenantPreferenceByTenantID(ctx context.Context, tenantID string) (domain.TenantPreference, error) {
 	var preference domain.TenantPreference
-	var channelPrefsJSON, categoryPrefsJSON, digestSettingsJSON []byte
+	var channelPrefsJSON, categoryPrefsJSON, digestSettingsJSON, quietHoursJSON, frequencyCapsJSON []byte
 
 	query := `
-		SELECT id, tenant_id, enabled, channel_preferences, category_preferences, digest_settings, created_at, updated_at
+		SELECT id, tenant_id, enabled, channel_preferences, category_preferences, digest_settings, default_locale, timezone, quiet_hours, frequency_caps, created_at, updated_at
 		FROM tenant_preferences
 		WHERE tenant_id = ?
 	`
-	
+
 	err := r.db.QueryRow(ctx, query, tenantID).Scan(
 		&preference.ID,
 		&preference.TenantID,
@@ -76,10 +90,14 @@ func (r *sqlTenantPreferenceRepository) GetTenantPreferenceByTenantID(ctx contex
 		&channelPrefsJSON,
 		&categoryPrefsJSON,
 		&digestSettingsJSON,
+		&preference.DefaultLocale,
+		&preference.Timezone,
+		&quietHoursJSON,
+		&frequencyCapsJSON,
 		&preference.CreatedAt,
 		&preference.UpdatedAt,
 	)
-	
+
 	if err != nil {
 		return domain.TenantPreference{}, fmt.Errorf("failed to get tenant preference: %w", err)
 	}
@@ -99,6 +117,15 @@ func (r *sqlTenantPreferenceRepository) GetTenantPreferenceByTenantID(ctx contex
 		return domain.TenantPreference{}, fmt.Errorf("failed to unmarshal digest settings: %w", err)
 	}
 
+	if err = json.Unmarshal(quietHoursJSON, &preference.QuietHours); err != nil {
+		return domain.TenantPreference{}, fmt.Errorf("failed to unmarshal quiet hours: %w", err)
+	}
+
+	preference.FrequencyCaps = []domain.FrequencyCap{}
+	if err = json.Unmarshal(frequencyCapsJSON, &preference.FrequencyCaps); err != nil {
+		return domain.TenantPreference{}, fmt.Errorf("failed to unmarshal frequency caps: %w", err)
+	}
+
 	return preference, nil
 }
 
@@ -120,24 +147,50 @@ func (r *sqlTenantPreferenceRepository) UpdateTenantPreference(ctx context.Conte
 		return fmt.Errorf("failed to marshal digest settings: %w", err)
 	}
 
+	quietHours, err := json.Marshal(preference.QuietHours)
+	if err != nil {
+		return fmt.Errorf("failed to marshal quiet hours: %w", err)
+	}
+
+	frequencyCaps, err := marshalFrequencyCaps(preference.FrequencyCaps)
+	if err != nil {
+		return err
+	}
+
 	// Update in the database
 	query := `
 		UPDATE tenant_preferences
-		SET enabled = ?, channel_preferences = ?, category_preferences = ?, digest_settings = ?
+		SET enabled = ?, channel_preferences = ?, category_preferences = ?, digest_settings = ?, default_locale = ?, timezone = ?, quiet_hours = ?, frequency_caps = ?
 		WHERE tenant_id = ?
 	`
-	
+
 	_, err = r.db.Exec(ctx, query,
 		preference.Enabled,
 		channelPrefs,
 		categoryPrefs,
 		digestSettings,
+		preference.DefaultLocale,
+		preference.Timezone,
+		quietHours,
+		frequencyCaps,
 		preference.TenantID,
 	)
-	
+
 	if err != nil {
 		return fmt.Errorf("failed to update tenant preference: %w", err)
 	}
 
 	return nil
 }
+
+// marshalFrequencyCaps stores caps as a JSON array, empty when the tenant has none
+func marshalFrequencyCaps(caps []domain.FrequencyCap) ([]byte, error) {
+	if caps == nil {
+		caps = []domain.FrequencyCap{}
+	}
+	data, err := json.Marshal(caps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal frequency caps: %w", err)
+	}
+	return data, nil
+}
@@ -20,9 +20,9 @@ func NewUserRepository(db db.Database) repository.UserRepository {
 
 func (r *sqlUserRepository) CreateUser(ctx context.Context, user domain.User) error {
 	now := time.Now()
-	query := `INSERT INTO users (id, email, phone_number, device_id, created_at, updated_at) 
-              VALUES (?, ?, ?, ?, ?, ?)`
-	_, err := r.db.Exec(ctx, query, user.ID, user.Email, user.PhoneNumber, user.DeviceID, now, now)
+	query := `INSERT INTO users (id, email, phone_number, device_id, locale, created_at, updated_at) 
+              VALUES (?, ?, ?, ?, ?, ?, ?)`
+	_, err := r.db.Exec(ctx, query, user.ID, user.Email, user.PhoneNumber, user.DeviceID, user.Locale, now, now)
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -30,10 +30,10 @@ func (r *sqlUserRepository) CreateUser(ctx context.Context, user domain.User) er
 }
 
 func (r *sqlUserRepository) GetUserByID(ctx context.Context, userid string) (domain.User, error) {
-	query := `SELECT id, email, phone_number, device_id FROM users WHERE id = ?`
+	query := `SELECT id, email, phone_number, device_id, locale FROM users WHERE id = ?`
 	row := r.db.QueryRow(ctx, query, userid)
 	var user domain.User
-	err := row.Scan(&user.ID, &user.Email, &user.PhoneNumber, &user.DeviceID)
+	err := row.Scan(&user.ID, &user.Email, &user.PhoneNumber, &user.DeviceID, &user.Locale)
 	if err != nil {
 		return domain.User{}, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -42,8 +42,8 @@ func (r *sqlUserRepository) GetUserByID(ctx context.Context, userid string) (dom
 
 func (r *sqlUserRepository) UpdateUser(ctx context.Context, user domain.User) error {
 	now := time.Now()
-	query := `UPDATE users SET email = ?, phone_number = ?, device_id = ?, updated_at = ? WHERE id = ?`
-	_, err := r.db.Exec(ctx, query, user.Email, user.PhoneNumber, user.DeviceID, now, user.ID)
+	query := `UPDATE users SET email = ?, phone_number = ?, device_id = ?, locale = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.Exec(ctx, query, user.Email, user.PhoneNumber, user.DeviceID, user.Locale, now, user.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
@@ -51,7 +51,7 @@ func (r *sqlUserRepository) UpdateUser(ctx context.Context, user domain.User) er
 }
 
 func (r *sqlUserRepository) GetUsers(ctx context.Context) ([]domain.User, error) {
-	query := `SELECT id, email, phone_number, device_id FROM users`
+	query := `SELECT id, email, phone_number, device_id, locale FROM users`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query users: %w", err)
@@ -61,7 +61,7 @@ func (r *sqlUserRepository) GetUsers(ctx context.Context) ([]domain.User, error)
 	var users []domain.User
 	for rows.Next() {
 		var user domain.User
-		err := rows.Scan(&user.ID, &user.Email, &user.PhoneNumber, &user.DeviceID)
+		err := rows.Scan(&user.ID, &user.Email, &user.PhoneNumber, &user.DeviceID, &user.Locale)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
@@ -0,0 +1,120 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/tenants/domain"
+	repository "getnoti.com/internal/tenants/repos"
+	"getnoti.com/pkg/db"
+)
+
+type sqlWebPushSubscriptionRepository struct {
+	db db.Database
+}
+
+// NewWebPushSubscriptionRepository creates a new SQL-based web push subscription repository
+func NewWebPushSubscriptionRepository(db db.Database) repository.WebPushSubscriptionRepository {
+	return &sqlWebPushSubscriptionRepository{db: db}
+}
+
+// SaveWebPushSubscription inserts the subscription, or refreshes the one already
+// stored for its endpoint. A browser that resubscribes keeps its endpoint but may
+// rotate its keys or change user, so the stored row is updated in place and
+// subscription.ID is set to the stored ID.
+func (r *sqlWebPushSubscriptionRepository) SaveWebPushSubscription(ctx context.Context, subscription *domain.WebPushSubscription) error {
+	now := time.Now()
+	subscription.UpdatedAt = now
+
+	query := `INSERT INTO web_push_subscriptions (id, user_id, endpoint, p256dh, auth, user_agent, created_at, updated_at)
+              VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+              ON CONFLICT (endpoint) DO UPDATE SET user_id = excluded.user_id, p256dh = excluded.p256dh, auth = excluded.auth,
+              user_agent = excluded.user_agent, updated_at = excluded.updated_at`
+	_, err := r.db.Exec(ctx, query,
+		subscription.ID,
+		subscription.UserID,
+		subscription.Endpoint,
+		subscription.P256dh,
+		subscription.Auth,
+		nullString(subscription.UserAgent),
+		now,
+		now,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save web push subscription: %w", err)
+	}
+
+	query = `SELECT id, created_at FROM web_push_subscriptions WHERE endpoint = ?`
+	if err := r.db.QueryRow(ctx, query, subscription.Endpoint).Scan(&subscription.ID, &subscription.CreatedAt); err != nil {
+		return fmt.Errorf("failed to get web push subscription: %w", err)
+	}
+	return nil
+}
+
+// GetWebPushSubscriptionsByUserID returns the user's subscriptions, oldest first
+func (r *sqlWebPushSubscriptionRepository) GetWebPushSubscriptionsByUserID(ctx context.Context, userID string) ([]domain.WebPushSubscription, error) {
+	query := `SELECT id, user_id, endpoint, p256dh, auth, user_agent, created_at, updated_at
+              FROM web_push_subscriptions WHERE user_id = ? ORDER BY created_at`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query web push subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []domain.WebPushSubscription{}
+	for rows.Next() {
+		var subscription domain.WebPushSubscription
+		var userAgent sql.NullString
+		err := rows.Scan(
+			&subscription.ID,
+			&subscription.UserID,
+			&subscription.Endpoint,
+			&subscription.P256dh,
+			&subscription.Auth,
+			&userAgent,
+			&subscription.CreatedAt,
+			&subscription.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan web push subscription: %w", err)
+		}
+		subscription.UserAgent = userAgent.String
+		subscriptions = append(subscriptions, subscription)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read web push subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// DeleteWebPushSubscription removes one of the user's subscriptions
+func (r *sqlWebPushSubscriptionRepository) DeleteWebPushSubscription(ctx context.Context, userID, id string) error {
+	query := `DELETE FROM web_push_subscriptions WHERE id = ? AND user_id = ?`
+	result, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete web push subscription: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("%w: %s", ErrWebPushSubscriptionNotFound, id)
+	}
+	return nil
+}
+
+// DeleteWebPushSubscriptionByEndpoint removes the subscription for an endpoint;
+// an endpoint that is already gone is not an error
+func (r *sqlWebPushSubscriptionRepository) DeleteWebPushSubscriptionByEndpoint(ctx context.Context, endpoint string) error {
+	query := `DELETE FROM web_push_subscriptions WHERE endpoint = ?`
+	if _, err := r.db.Exec(ctx, query, endpoint); err != nil {
+		return fmt.Errorf("failed to delete web push subscription: %w", err)
+	}
+	return nil
+}
+
+func nullString(s string) sql.NullString {
+	if s == "" {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: s, Valid: true}
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"getnoti.com/internal/tenants/domain"
+)
+
+type WebPushSubscriptionRepository interface {
+	// Save stores the subscription, replacing the keys and owner of an existing
+	// subscription with the same endpoint
+	SaveWebPushSubscription(ctx context.Context, subscription *domain.WebPushSubscription) error
+
+	// Get all subscriptions for a user, oldest first
+	GetWebPushSubscriptionsByUserID(ctx context.Context, userID string) ([]domain.WebPushSubscription, error)
+
+	// Delete one of a user's subscriptions
+	DeleteWebPushSubscription(ctx context.Context, userID, id string) error
+
+	// Delete the subscription for an endpoint the push service no longer accepts
+	DeleteWebPushSubscriptionByEndpoint(ctx context.Context, endpoint string) error
+}
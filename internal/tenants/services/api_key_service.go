@@ -0,0 +1,128 @@
+package tenants
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"getnoti.com/internal/tenants/domain"
+	repository "getnoti.com/internal/tenants/repos"
+	"getnoti.com/pkg/logger"
+)
+
+// lastUsedResolution limits how often a key's last-used timestamp is written
+const lastUsedResolution = time.Minute
+
+// APIKeyService manages tenant API keys and resolves tenants from raw keys
+type APIKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	logger     logger.Logger
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, logger logger.Logger) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+	}
+}
+
+// CreateAPIKey issues a new key for a tenant. The raw key is returned once and never stored.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, tenantID, name string, expiresAt *time.Time) (*domain.APIKey, string, error) {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", fmt.Errorf("%w: expiry must be in the future", domain.ErrAPIKeyInvalid)
+	}
+
+	key, rawKey, err := domain.NewAPIKey(tenantID, name, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.apiKeyRepo.CreateAPIKey(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	s.logger.InfoContext(ctx, "API key created",
+		logger.String("tenant_id", tenantID),
+		logger.String("api_key_id", key.ID),
+		logger.String("key_prefix", key.KeyPrefix))
+
+	return key, rawKey, nil
+}
+
+// ListAPIKeys returns all keys for a tenant, including revoked ones
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, tenantID string) ([]*domain.APIKey, error) {
+	return s.apiKeyRepo.ListAPIKeys(ctx, tenantID)
+}
+
+// RevokeAPIKey revokes a tenant's key so it can no longer authenticate
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, tenantID, keyID string) error {
+	if err := s.apiKeyRepo.RevokeAPIKey(ctx, tenantID, keyID, time.Now()); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "API key revoked",
+		logger.String("tenant_id", tenantID),
+		logger.String("api_key_id", keyID))
+
+	return nil
+}
+
+// RotateAPIKey replaces a key with a new one of the same name and lifetime, revoking the old key
+func (s *APIKeyService) RotateAPIKey(ctx context.Context, tenantID, keyID string) (*domain.APIKey, string, error) {
+	existing, err := s.apiKeyRepo.GetAPIKeyByID(ctx, tenantID, keyID)
+	if err != nil {
+		return nil, "", err
+	}
+	if existing.IsRevoked() {
+		return nil, "", domain.ErrAPIKeyRevoked
+	}
+
+	var expiresAt *time.Time
+	if existing.ExpiresAt != nil {
+		next := time.Now().Add(existing.ExpiresAt.Sub(existing.CreatedAt))
+		expiresAt = &next
+	}
+
+	key, rawKey, err := s.CreateAPIKey(ctx, tenantID, existing.Name, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.RevokeAPIKey(ctx, tenantID, keyID); err != nil {
+		return nil, "", fmt.Errorf("failed to revoke rotated api key: %w", err)
+	}
+
+	return key, rawKey, nil
+}
+
+// AuthenticateAPIKey resolves the tenant that owns a raw key
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, rawKey string) (string, error) {
+	if !domain.IsWellFormedAPIKey(rawKey) {
+		return "", domain.ErrAPIKeyInvalid
+	}
+
+	key, err := s.apiKeyRepo.GetAPIKeyByHash(ctx, domain.HashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return "", domain.ErrAPIKeyInvalid
+		}
+		return "", err
+	}
+
+	now := time.Now()
+	if err := key.Validate(now); err != nil {
+		return "", err
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.apiKeyRepo.UpdateLastUsed(ctx, key.ID, now); err != nil {
+			s.logger.WarnContext(ctx, "Failed to record API key usage",
+				logger.String("api_key_id", key.ID),
+				logger.Err(err))
+		}
+	}
+
+	return key.TenantID, nil
+}
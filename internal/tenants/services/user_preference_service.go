@@ -3,6 +3,7 @@ package tenants
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"getnoti.com/internal/tenants/domain"
 	repository "getnoti.com/internal/tenants/repos"
@@ -12,10 +13,11 @@ import (
 
 // UserPreferenceService handles checking user preferences for notifications
 type UserPreferenceService struct {
-	dbManager          *db.Manager
-	logger             logger.Logger
-	repositoryFactory  interface {
+	dbManager         *db.Manager
+	logger            logger.Logger
+	repositoryFactory interface {
 		GetUserPreferenceRepositoryForTenant(tenantID string) (repository.UserPreferenceRepository, error)
+		GetTenantPreferenceRepositoryForTenant(tenantID string) (repository.TenantPreferenceRepository, error)
 	}
 }
 
@@ -25,6 +27,7 @@ func NewUserPreferenceService(
 	logger logger.Logger,
 	repositoryFactory interface {
 		GetUserPreferenceRepositoryForTenant(tenantID string) (repository.UserPreferenceRepository, error)
+		GetTenantPreferenceRepositoryForTenant(tenantID string) (repository.TenantPreferenceRepository, error)
 	},
 ) *UserPreferenceService {
 	return &UserPreferenceService{
@@ -34,27 +37,48 @@ func NewUserPreferenceService(
 	}
 }
 
+// PreferenceDecision is the outcome of checking a notification against a user's preferences
+type PreferenceDecision struct {
+	Allowed bool
+	// Reason explains which preference blocked the notification
+	Reason string
+}
+
 // ShouldSendNotification checks if a notification should be sent based on user preferences
 func (s *UserPreferenceService) ShouldSendNotification(
-	ctx context.Context, 
-	userID string, 
-	tenantID string, 
-	channel string, 
+	ctx context.Context,
+	userID string,
+	tenantID string,
+	channel string,
 	category string,
 ) (bool, error) {
+	decision, err := s.EvaluateNotification(ctx, userID, tenantID, channel, category)
+	return decision.Allowed, err
+}
+
+// EvaluateNotification checks the master, channel and category preferences in
+// that order and reports the first one that blocks the notification
+func (s *UserPreferenceService) EvaluateNotification(
+	ctx context.Context,
+	userID string,
+	tenantID string,
+	channel string,
+	category string,
+) (PreferenceDecision, error) {
+	allowed := PreferenceDecision{Allowed: true}
 	if userID == "" {
 		// No user ID, so we can't check preferences - default to sending
-		return true, nil
+		return allowed, nil
 	}
 
 	// Get tenant-specific repository
 	userPrefRepo, err := s.repositoryFactory.GetUserPreferenceRepositoryForTenant(tenantID)
 	if err != nil {
-		s.logger.Error("Failed to get user preference repository for tenant", 
-			logger.String("tenant_id", tenantID), 
-			logger.String("error",err.Error()),
+		s.logger.Error("Failed to get user preference repository for tenant",
+			logger.String("tenant_id", tenantID),
+			logger.String("error", err.Error()),
 			logger.Err(err))
-		return true, fmt.Errorf("failed to get tenant database: %w", err)
+		return allowed, fmt.Errorf("failed to get tenant database: %w", err)
 	}
 
 	// Get user preferences
@@ -64,7 +88,7 @@ func (s *UserPreferenceService) ShouldSendNotification(
 		s.logger.InfoContext(ctx, "User preferences not found, defaulting to sending notification",
 			logger.String("user_id", userID),
 			logger.String("tenant_id", tenantID))
-		return true, nil
+		return allowed, nil
 	}
 
 	// First check: is notifications enabled at all?
@@ -72,16 +96,16 @@ func (s *UserPreferenceService) ShouldSendNotification(
 		s.logger.DebugContext(ctx, "Notifications disabled for user",
 			logger.String("user_id", userID),
 			logger.String("tenant_id", tenantID))
-		return false, nil
+		return PreferenceDecision{Reason: "user has disabled all notifications"}, nil
 	}
 
 	// Check channel-level preference
-	channelType := domain.ChannelType(channel)
+	channelType := domain.NormalizeChannel(channel)
 	if channelEnabled, exists := userPreference.ChannelPrefs[channelType]; exists && !channelEnabled {
 		s.logger.DebugContext(ctx, "Channel disabled for user",
 			logger.String("user_id", userID),
 			logger.String("channel", channel))
-		return false, nil
+		return PreferenceDecision{Reason: fmt.Sprintf("user has disabled the %s channel", channel)}, nil
 	}
 
 	// If there's a category, check category-level preferences
@@ -92,7 +116,7 @@ func (s *UserPreferenceService) ShouldSendNotification(
 				s.logger.DebugContext(ctx, "Category disabled for user",
 					logger.String("user_id", userID),
 					logger.String("category", category))
-				return false, nil
+				return PreferenceDecision{Reason: fmt.Sprintf("user has disabled the %s category", category)}, nil
 			}
 
 			// Check for channel-specific setting within this category
@@ -101,11 +125,128 @@ func (s *UserPreferenceService) ShouldSendNotification(
 					logger.String("user_id", userID),
 					logger.String("channel", channel),
 					logger.String("category", category))
-				return false, nil
+				return PreferenceDecision{Reason: fmt.Sprintf("user has disabled the %s channel for the %s category", channel, category)}, nil
 			}
 		}
 	}
 
 	// All checks passed, notification can be sent
-	return true, nil
+	return allowed, nil
+}
+
+// QuietHoursEnd reports whether at falls inside the user's quiet hours and, if
+// so, when they end. The user's timezone and quiet hours fall back to the
+// tenant defaults; without either, there are no quiet hours.
+func (s *UserPreferenceService) QuietHoursEnd(
+	ctx context.Context,
+	userID string,
+	tenantID string,
+	at time.Time,
+) (time.Time, bool, error) {
+	var timezone string
+	var quietHours *domain.QuietHours
+
+	if userID != "" {
+		userPrefRepo, err := s.repositoryFactory.GetUserPreferenceRepositoryForTenant(tenantID)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("failed to get tenant database: %w", err)
+		}
+		if userPreference, err := userPrefRepo.GetUserPreferenceByUserID(ctx, userID); err == nil {
+			timezone = userPreference.Timezone
+			quietHours = userPreference.QuietHours
+		}
+	}
+
+	if timezone == "" || quietHours == nil {
+		tenantPrefRepo, err := s.repositoryFactory.GetTenantPreferenceRepositoryForTenant(tenantID)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("failed to get tenant database: %w", err)
+		}
+		if tenantPreference, err := tenantPrefRepo.GetTenantPreferenceByTenantID(ctx, tenantID); err == nil {
+			if timezone == "" {
+				timezone = tenantPreference.Timezone
+			}
+			if quietHours == nil {
+				quietHours = &tenantPreference.QuietHours
+			}
+		}
+	}
+
+	if quietHours == nil {
+		return time.Time{}, false, nil
+	}
+
+	loc, err := domain.LoadTimezone(timezone)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Invalid timezone, using UTC for quiet hours",
+			logger.String("user_id", userID),
+			logger.String("tenant_id", tenantID),
+			logger.String("timezone", timezone))
+		loc = time.UTC
+	}
+
+	endsAt, inside := quietHours.EndsAt(at, loc)
+	return endsAt, inside, nil
+}
+
+// DigestSettings returns the user's digest settings for a notification category
+// and whether the user collects that category into digests. A category with
+// digests enabled uses its own digest type over the user's.
+func (s *UserPreferenceService) DigestSettings(
+	ctx context.Context,
+	userID string,
+	tenantID string,
+	category string,
+) (domain.DigestSettings, bool, error) {
+	if userID == "" {
+		return domain.DigestSettings{}, false, nil
+	}
+
+	userPrefRepo, err := s.repositoryFactory.GetUserPreferenceRepositoryForTenant(tenantID)
+	if err != nil {
+		return domain.DigestSettings{}, false, fmt.Errorf("failed to get tenant database: %w", err)
+	}
+
+	userPreference, err := userPrefRepo.GetUserPreferenceByUserID(ctx, userID)
+	if err != nil {
+		// Users without preferences receive notifications as they happen
+		return domain.DigestSettings{}, false, nil
+	}
+
+	settings := userPreference.DigestSettings
+	if categoryPref, exists := userPreference.CategoryPrefs[category]; exists && category != "" && categoryPref.DigestEnabled {
+		if categoryPref.DigestType != "" && categoryPref.DigestType != domain.DigestTypeNone {
+			settings.Type = categoryPref.DigestType
+		}
+		return settings, settings.Type != domain.DigestTypeNone, nil
+	}
+
+	return settings, settings.Enabled && settings.Type != domain.DigestTypeNone, nil
+}
+
+// Location returns the user's timezone, falling back to the tenant default and then UTC
+func (s *UserPreferenceService) Location(ctx context.Context, userID string, tenantID string) *time.Location {
+	var timezone string
+
+	if userID != "" {
+		if userPrefRepo, err := s.repositoryFactory.GetUserPreferenceRepositoryForTenant(tenantID); err == nil {
+			if userPreference, err := userPrefRepo.GetUserPreferenceByUserID(ctx, userID); err == nil {
+				timezone = userPreference.Timezone
+			}
+		}
+	}
+
+	if timezone == "" {
+		if tenantPrefRepo, err := s.repositoryFactory.GetTenantPreferenceRepositoryForTenant(tenantID); err == nil {
+			if tenantPreference, err := tenantPrefRepo.GetTenantPreferenceByTenantID(ctx, tenantID); err == nil {
+				timezone = tenantPreference.Timezone
+			}
+		}
+	}
+
+	loc, err := domain.LoadTimezone(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
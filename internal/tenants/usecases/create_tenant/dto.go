@@ -3,14 +3,14 @@ package createtenant
 import "getnoti.com/internal/tenants/domain"
 
 type CreateTenantRequest struct {
-    ID             string
-    Name           string
-    DBConfig *domain.DBCredentials
+	ID       string
+	Name     string
+	DBConfig *domain.DBCredentials
 }
 
-
-
 type CreateTenantResponse struct {
-    ID             string
-    Name           string
+	ID   string
+	Name string
+	// APIKey is the tenant's initial raw API key, returned only on creation
+	APIKey string `json:",omitempty"`
 }
@@ -1,21 +1,20 @@
 package createuser
 
 import (
-   
-    "getnoti.com/internal/tenants/domain"
-    
+	"getnoti.com/internal/tenants/domain"
 )
+
 type CreateUserRequest struct {
-    ID           string
-    Email        string
-    PhoneNumber  string
-    DeviceID     string
-    WebPushToken string
-    Consents     map[string]bool
-    PreferredMode string
+	ID            string
+	Email         string
+	PhoneNumber   string
+	DeviceID      string
+	WebPushToken  string
+	Consents      map[string]bool
+	PreferredMode string
+	Locale        string
 }
 
-
 type CreateUserResponse struct {
-    User domain.User
+	User domain.User
 }
@@ -2,6 +2,8 @@ package createuser
 
 import (
 	"context"
+	"fmt"
+
 	"getnoti.com/internal/shared/utils"
 	"getnoti.com/internal/tenants/domain"
 	repository "getnoti.com/internal/tenants/repos"
@@ -23,16 +25,22 @@ func NewCreateUserUseCase(repo repository.UserRepository) CreateUserUseCase {
 
 func (uc *createUserUseCase) Execute(ctx context.Context, req CreateUserRequest) (CreateUserResponse, error) {
 
+	locale, err := domain.NormalizeLocale(req.Locale)
+	if err != nil {
+		return CreateUserResponse{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+
 	ID := utils.GenerateUUID()
 
 	user := domain.User{
-		ID:            ID,
-		Email:         req.Email,
-		PhoneNumber:   req.PhoneNumber,
-		DeviceID:      req.DeviceID,
+		ID:          ID,
+		Email:       req.Email,
+		PhoneNumber: req.PhoneNumber,
+		DeviceID:    req.DeviceID,
+		Locale:      locale,
 	}
 
-	err := uc.repo.CreateUser(ctx, user)
+	err = uc.repo.CreateUser(ctx, user)
 	if err != nil {
 		return CreateUserResponse{}, err
 	}
@@ -0,0 +1,17 @@
+package deletewebpushsubscription
+
+import (
+	"context"
+)
+
+type DeleteWebPushSubscriptionController struct {
+	useCase DeleteWebPushSubscriptionUseCase
+}
+
+func NewDeleteWebPushSubscriptionController(useCase DeleteWebPushSubscriptionUseCase) *DeleteWebPushSubscriptionController {
+	return &DeleteWebPushSubscriptionController{useCase: useCase}
+}
+
+func (c *DeleteWebPushSubscriptionController) DeleteWebPushSubscription(ctx context.Context, req DeleteWebPushSubscriptionRequest) (DeleteWebPushSubscriptionResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,16 @@
+package deletewebpushsubscription
+
+type DeleteWebPushSubscriptionRequest struct {
+	TenantID       string
+	UserID         string
+	SubscriptionID string
+}
+
+func (r *DeleteWebPushSubscriptionRequest) SetTenantID(id string) {
+	r.TenantID = id
+}
+
+type DeleteWebPushSubscriptionResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,7 @@
+package deletewebpushsubscription
+
+import "errors"
+
+var (
+	ErrSubscriptionNotFound = errors.New("web push subscription not found")
+)
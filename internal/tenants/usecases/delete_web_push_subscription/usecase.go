@@ -0,0 +1,39 @@
+package deletewebpushsubscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	repository "getnoti.com/internal/tenants/repos"
+	repos "getnoti.com/internal/tenants/repos/implementations"
+)
+
+type DeleteWebPushSubscriptionUseCase interface {
+	Execute(ctx context.Context, req DeleteWebPushSubscriptionRequest) (DeleteWebPushSubscriptionResponse, error)
+}
+
+type deleteWebPushSubscriptionUseCase struct {
+	subscriptionRepo repository.WebPushSubscriptionRepository
+}
+
+func NewDeleteWebPushSubscriptionUseCase(subscriptionRepo repository.WebPushSubscriptionRepository) DeleteWebPushSubscriptionUseCase {
+	return &deleteWebPushSubscriptionUseCase{
+		subscriptionRepo: subscriptionRepo,
+	}
+}
+
+func (uc *deleteWebPushSubscriptionUseCase) Execute(ctx context.Context, req DeleteWebPushSubscriptionRequest) (DeleteWebPushSubscriptionResponse, error) {
+	err := uc.subscriptionRepo.DeleteWebPushSubscription(ctx, req.UserID, req.SubscriptionID)
+	if errors.Is(err, repos.ErrWebPushSubscriptionNotFound) {
+		return DeleteWebPushSubscriptionResponse{Success: false}, fmt.Errorf("%w: %s", ErrSubscriptionNotFound, req.SubscriptionID)
+	}
+	if err != nil {
+		return DeleteWebPushSubscriptionResponse{Success: false}, fmt.Errorf("failed to delete web push subscription: %w", err)
+	}
+
+	return DeleteWebPushSubscriptionResponse{
+		Success: true,
+		Message: "Web push subscription deleted successfully",
+	}, nil
+}
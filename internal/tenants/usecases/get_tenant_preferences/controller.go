@@ -0,0 +1,17 @@
+package gettenantpreferences
+
+import (
+	"context"
+)
+
+type GetTenantPreferencesController struct {
+	useCase GetTenantPreferencesUseCase
+}
+
+func NewGetTenantPreferencesController(useCase GetTenantPreferencesUseCase) *GetTenantPreferencesController {
+	return &GetTenantPreferencesController{useCase: useCase}
+}
+
+func (c *GetTenantPreferencesController) GetTenantPreferences(ctx context.Context, req GetTenantPreferencesRequest) (GetTenantPreferencesResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,42 @@
+package gettenantpreferences
+
+import (
+	"getnoti.com/internal/tenants/domain"
+)
+
+type GetTenantPreferencesRequest struct {
+	TenantID string
+}
+
+func (r *GetTenantPreferencesRequest) SetTenantID(id string) {
+	r.TenantID = id
+}
+
+type GetTenantPreferencesResponse struct {
+	ID             string                               `json:"id"`
+	TenantID       string                               `json:"tenantId"`
+	Enabled        bool                                 `json:"enabled"`
+	ChannelPrefs   map[domain.ChannelType]bool          `json:"channelPrefs"`
+	CategoryPrefs  map[string]domain.CategoryPreference `json:"categoryPrefs"`
+	DigestSettings domain.DigestSettings                `json:"digestSettings"`
+	DefaultLocale  string                               `json:"defaultLocale"`
+	Timezone       string                               `json:"timezone"`
+	QuietHours     domain.QuietHours                    `json:"quietHours"`
+	FrequencyCaps  []domain.FrequencyCap                `json:"frequencyCaps"`
+}
+
+// FromDomain converts domain TenantPreference to response DTO
+func FromDomain(pref domain.TenantPreference) GetTenantPreferencesResponse {
+	return GetTenantPreferencesResponse{
+		ID:             pref.ID,
+		TenantID:       pref.TenantID,
+		Enabled:        pref.Enabled,
+		ChannelPrefs:   pref.ChannelPrefs,
+		CategoryPrefs:  pref.CategoryPrefs,
+		DigestSettings: pref.DigestSettings,
+		DefaultLocale:  pref.DefaultLocale,
+		Timezone:       pref.Timezone,
+		QuietHours:     pref.QuietHours,
+		FrequencyCaps:  pref.FrequencyCaps,
+	}
+}
@@ -0,0 +1,7 @@
+package gettenantpreferences
+
+import "errors"
+
+var (
+	ErrPreferencesNotFound = errors.New("tenant preferences not found")
+)
@@ -0,0 +1,43 @@
+package gettenantpreferences
+
+import (
+	"context"
+	"fmt"
+
+	"getnoti.com/internal/tenants/domain"
+	repository "getnoti.com/internal/tenants/repos"
+	"github.com/google/uuid"
+)
+
+type GetTenantPreferencesUseCase interface {
+	Execute(ctx context.Context, req GetTenantPreferencesRequest) (GetTenantPreferencesResponse, error)
+}
+
+type getTenantPreferencesUseCase struct {
+	tenantPrefRepo repository.TenantPreferenceRepository
+}
+
+func NewGetTenantPreferencesUseCase(tenantPrefRepo repository.TenantPreferenceRepository) GetTenantPreferencesUseCase {
+	return &getTenantPreferencesUseCase{
+		tenantPrefRepo: tenantPrefRepo,
+	}
+}
+
+func (uc *getTenantPreferencesUseCase) Execute(ctx context.Context, req GetTenantPreferencesRequest) (GetTenantPreferencesResponse, error) {
+	tenantPref, err := uc.tenantPrefRepo.GetTenantPreferenceByTenantID(ctx, req.TenantID)
+
+	// If tenant preferences not found, create default preferences
+	if err != nil {
+		defaultPref := domain.NewTenantPreference(req.TenantID)
+		defaultPref.ID = uuid.New().String()
+
+		err = uc.tenantPrefRepo.CreateTenantPreference(ctx, *defaultPref)
+		if err != nil {
+			return GetTenantPreferencesResponse{}, fmt.Errorf("failed to create default preferences: %w", err)
+		}
+
+		return FromDomain(*defaultPref), nil
+	}
+
+	return FromDomain(tenantPref), nil
+}
@@ -14,12 +14,14 @@ func (r *GetUserPreferencesRequest) SetTenantID(id string) {
 }
 
 type GetUserPreferencesResponse struct {
-	ID             string                                  `json:"id"`
-	UserID         string                                  `json:"userId"`
-	Enabled        bool                                    `json:"enabled"`
-	ChannelPrefs   map[domain.ChannelType]bool             `json:"channelPrefs"`
-	CategoryPrefs  map[string]domain.CategoryPreference    `json:"categoryPrefs"`
-	DigestSettings domain.DigestSettings                   `json:"digestSettings"`
+	ID             string                               `json:"id"`
+	UserID         string                               `json:"userId"`
+	Enabled        bool                                 `json:"enabled"`
+	ChannelPrefs   map[domain.ChannelType]bool          `json:"channelPrefs"`
+	CategoryPrefs  map[string]domain.CategoryPreference `json:"categoryPrefs"`
+	DigestSettings domain.DigestSettings                `json:"digestSettings"`
+	Timezone       string                               `json:"timezone,omitempty"`
+	QuietHours     *domain.QuietHours                   `json:"quietHours,omitempty"`
 }
 
 // FromDomain converts domain UserPreference to response DTO
@@ -31,5 +33,7 @@ func FromDomain(pref domain.UserPreference) GetUserPreferencesResponse {
 		ChannelPrefs:   pref.ChannelPrefs,
 		CategoryPrefs:  pref.CategoryPrefs,
 		DigestSettings: pref.DigestSettings,
+		Timezone:       pref.Timezone,
+		QuietHours:     pref.QuietHours,
 	}
 }
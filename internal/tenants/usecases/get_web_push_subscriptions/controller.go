@@ -0,0 +1,17 @@
+package getwebpushsubscriptions
+
+import (
+	"context"
+)
+
+type GetWebPushSubscriptionsController struct {
+	useCase GetWebPushSubscriptionsUseCase
+}
+
+func NewGetWebPushSubscriptionsController(useCase GetWebPushSubscriptionsUseCase) *GetWebPushSubscriptionsController {
+	return &GetWebPushSubscriptionsController{useCase: useCase}
+}
+
+func (c *GetWebPushSubscriptionsController) GetWebPushSubscriptions(ctx context.Context, req GetWebPushSubscriptionsRequest) (GetWebPushSubscriptionsResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
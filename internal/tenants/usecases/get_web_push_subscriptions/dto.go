@@ -0,0 +1,18 @@
+package getwebpushsubscriptions
+
+import (
+	"getnoti.com/internal/tenants/domain"
+)
+
+type GetWebPushSubscriptionsRequest struct {
+	TenantID string
+	UserID   string
+}
+
+func (r *GetWebPushSubscriptionsRequest) SetTenantID(id string) {
+	r.TenantID = id
+}
+
+type GetWebPushSubscriptionsResponse struct {
+	Subscriptions []domain.WebPushSubscription `json:"subscriptions"`
+}
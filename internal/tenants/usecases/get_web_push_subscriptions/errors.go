@@ -0,0 +1,7 @@
+package getwebpushsubscriptions
+
+import "errors"
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+)
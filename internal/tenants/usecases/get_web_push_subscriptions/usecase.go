@@ -0,0 +1,41 @@
+package getwebpushsubscriptions
+
+import (
+	"context"
+	"fmt"
+
+	repository "getnoti.com/internal/tenants/repos"
+)
+
+type GetWebPushSubscriptionsUseCase interface {
+	Execute(ctx context.Context, req GetWebPushSubscriptionsRequest) (GetWebPushSubscriptionsResponse, error)
+}
+
+type getWebPushSubscriptionsUseCase struct {
+	subscriptionRepo repository.WebPushSubscriptionRepository
+	userRepo         repository.UserRepository
+}
+
+func NewGetWebPushSubscriptionsUseCase(
+	subscriptionRepo repository.WebPushSubscriptionRepository,
+	userRepo repository.UserRepository,
+) GetWebPushSubscriptionsUseCase {
+	return &getWebPushSubscriptionsUseCase{
+		subscriptionRepo: subscriptionRepo,
+		userRepo:         userRepo,
+	}
+}
+
+func (uc *getWebPushSubscriptionsUseCase) Execute(ctx context.Context, req GetWebPushSubscriptionsRequest) (GetWebPushSubscriptionsResponse, error) {
+	// Validate that the user exists
+	if _, err := uc.userRepo.GetUserByID(ctx, req.UserID); err != nil {
+		return GetWebPushSubscriptionsResponse{}, fmt.Errorf("%w: %w", ErrUserNotFound, err)
+	}
+
+	subscriptions, err := uc.subscriptionRepo.GetWebPushSubscriptionsByUserID(ctx, req.UserID)
+	if err != nil {
+		return GetWebPushSubscriptionsResponse{}, fmt.Errorf("failed to get web push subscriptions: %w", err)
+	}
+
+	return GetWebPushSubscriptionsResponse{Subscriptions: subscriptions}, nil
+}
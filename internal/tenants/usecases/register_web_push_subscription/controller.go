@@ -0,0 +1,17 @@
+package registerwebpushsubscription
+
+import (
+	"context"
+)
+
+type RegisterWebPushSubscriptionController struct {
+	useCase RegisterWebPushSubscriptionUseCase
+}
+
+func NewRegisterWebPushSubscriptionController(useCase RegisterWebPushSubscriptionUseCase) *RegisterWebPushSubscriptionController {
+	return &RegisterWebPushSubscriptionController{useCase: useCase}
+}
+
+func (c *RegisterWebPushSubscriptionController) RegisterWebPushSubscription(ctx context.Context, req RegisterWebPushSubscriptionRequest) (RegisterWebPushSubscriptionResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,27 @@
+package registerwebpushsubscription
+
+import (
+	"getnoti.com/internal/tenants/domain"
+)
+
+// RegisterWebPushSubscriptionRequest accepts the browser's PushSubscription JSON as is
+type RegisterWebPushSubscriptionRequest struct {
+	TenantID  string                  `json:"-"`
+	UserID    string                  `json:"-"`
+	Endpoint  string                  `json:"endpoint"`
+	Keys      WebPushSubscriptionKeys `json:"keys"`
+	UserAgent string                  `json:"userAgent,omitempty"`
+}
+
+type WebPushSubscriptionKeys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+func (r *RegisterWebPushSubscriptionRequest) SetTenantID(id string) {
+	r.TenantID = id
+}
+
+type RegisterWebPushSubscriptionResponse struct {
+	Subscription domain.WebPushSubscription `json:"subscription"`
+}
@@ -0,0 +1,8 @@
+package registerwebpushsubscription
+
+import "errors"
+
+var (
+	ErrUserNotFound        = errors.New("user not found")
+	ErrInvalidSubscription = errors.New("invalid subscription")
+)
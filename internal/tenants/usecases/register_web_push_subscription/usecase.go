@@ -0,0 +1,55 @@
+package registerwebpushsubscription
+
+import (
+	"context"
+	"fmt"
+
+	"getnoti.com/internal/shared/utils"
+	"getnoti.com/internal/tenants/domain"
+	repository "getnoti.com/internal/tenants/repos"
+)
+
+type RegisterWebPushSubscriptionUseCase interface {
+	Execute(ctx context.Context, req RegisterWebPushSubscriptionRequest) (RegisterWebPushSubscriptionResponse, error)
+}
+
+type registerWebPushSubscriptionUseCase struct {
+	subscriptionRepo repository.WebPushSubscriptionRepository
+	userRepo         repository.UserRepository
+}
+
+func NewRegisterWebPushSubscriptionUseCase(
+	subscriptionRepo repository.WebPushSubscriptionRepository,
+	userRepo repository.UserRepository,
+) RegisterWebPushSubscriptionUseCase {
+	return &registerWebPushSubscriptionUseCase{
+		subscriptionRepo: subscriptionRepo,
+		userRepo:         userRepo,
+	}
+}
+
+func (uc *registerWebPushSubscriptionUseCase) Execute(ctx context.Context, req RegisterWebPushSubscriptionRequest) (RegisterWebPushSubscriptionResponse, error) {
+	subscription := domain.WebPushSubscription{
+		ID:        utils.GenerateUUID(),
+		UserID:    req.UserID,
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: req.UserAgent,
+	}
+	if err := subscription.Validate(); err != nil {
+		return RegisterWebPushSubscriptionResponse{}, fmt.Errorf("%w: %w", ErrInvalidSubscription, err)
+	}
+
+	// Validate that the user exists
+	if _, err := uc.userRepo.GetUserByID(ctx, req.UserID); err != nil {
+		return RegisterWebPushSubscriptionResponse{}, fmt.Errorf("%w: %w", ErrUserNotFound, err)
+	}
+
+	// Resubscribing from the same browser refreshes the stored subscription
+	if err := uc.subscriptionRepo.SaveWebPushSubscription(ctx, &subscription); err != nil {
+		return RegisterWebPushSubscriptionResponse{}, fmt.Errorf("failed to save web push subscription: %w", err)
+	}
+
+	return RegisterWebPushSubscriptionResponse{Subscription: subscription}, nil
+}
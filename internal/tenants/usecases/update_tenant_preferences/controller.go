@@ -0,0 +1,17 @@
+package updatetenantpreferences
+
+import (
+	"context"
+)
+
+type UpdateTenantPreferencesController struct {
+	useCase UpdateTenantPreferencesUseCase
+}
+
+func NewUpdateTenantPreferencesController(useCase UpdateTenantPreferencesUseCase) *UpdateTenantPreferencesController {
+	return &UpdateTenantPreferencesController{useCase: useCase}
+}
+
+func (c *UpdateTenantPreferencesController) UpdateTenantPreferences(ctx context.Context, req UpdateTenantPreferencesRequest) (UpdateTenantPreferencesResponse, error) {
+	return c.useCase.Execute(ctx, req)
+}
@@ -0,0 +1,27 @@
+package updatetenantpreferences
+
+import (
+	"getnoti.com/internal/tenants/domain"
+)
+
+// UpdateTenantPreferencesRequest updates the provided fields and keeps the rest
+type UpdateTenantPreferencesRequest struct {
+	TenantID       string                               `json:"tenantId"`
+	Enabled        *bool                                `json:"enabled,omitempty"`
+	ChannelPrefs   map[domain.ChannelType]bool          `json:"channelPrefs,omitempty"`
+	CategoryPrefs  map[string]domain.CategoryPreference `json:"categoryPrefs,omitempty"`
+	DigestSettings *domain.DigestSettings               `json:"digestSettings,omitempty"`
+	DefaultLocale  *string                              `json:"defaultLocale,omitempty"`
+	Timezone       *string                              `json:"timezone,omitempty"`
+	QuietHours     *domain.QuietHours                   `json:"quietHours,omitempty"`
+	FrequencyCaps  []domain.FrequencyCap                `json:"frequencyCaps,omitempty"`
+}
+
+func (r *UpdateTenantPreferencesRequest) SetTenantID(id string) {
+	r.TenantID = id
+}
+
+type UpdateTenantPreferencesResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message,omitempty"`
+}
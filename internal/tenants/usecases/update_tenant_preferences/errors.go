@@ -0,0 +1,7 @@
+package updatetenantpreferences
+
+import "errors"
+
+var (
+	ErrInvalidPreferences = errors.New("invalid tenant preferences")
+)
@@ -0,0 +1,86 @@
+package updatetenantpreferences
+
+import (
+	"context"
+	"fmt"
+
+	"getnoti.com/internal/tenants/domain"
+	repository "getnoti.com/internal/tenants/repos"
+	"github.com/google/uuid"
+)
+
+type UpdateTenantPreferencesUseCase interface {
+	Execute(ctx context.Context, req UpdateTenantPreferencesRequest) (UpdateTenantPreferencesResponse, error)
+}
+
+type updateTenantPreferencesUseCase struct {
+	tenantPrefRepo repository.TenantPreferenceRepository
+}
+
+func NewUpdateTenantPreferencesUseCase(tenantPrefRepo repository.TenantPreferenceRepository) UpdateTenantPreferencesUseCase {
+	return &updateTenantPreferencesUseCase{
+		tenantPrefRepo: tenantPrefRepo,
+	}
+}
+
+func (uc *updateTenantPreferencesUseCase) Execute(ctx context.Context, req UpdateTenantPreferencesRequest) (UpdateTenantPreferencesResponse, error) {
+	// Try to get existing preferences
+	existingPref, err := uc.tenantPrefRepo.GetTenantPreferenceByTenantID(ctx, req.TenantID)
+	var isNewPreference bool
+
+	// If preferences don't exist yet, create new ones
+	if err != nil {
+		existingPref = *domain.NewTenantPreference(req.TenantID)
+		existingPref.ID = uuid.New().String()
+		isNewPreference = true
+	}
+
+	if req.Enabled != nil {
+		existingPref.Enabled = *req.Enabled
+	}
+	if req.ChannelPrefs != nil {
+		existingPref.ChannelPrefs = req.ChannelPrefs
+	}
+	if req.CategoryPrefs != nil {
+		existingPref.CategoryPrefs = req.CategoryPrefs
+	}
+	if req.DigestSettings != nil {
+		existingPref.DigestSettings = *req.DigestSettings
+	}
+	if req.DefaultLocale != nil {
+		locale, err := domain.NormalizeLocale(*req.DefaultLocale)
+		if err != nil {
+			return UpdateTenantPreferencesResponse{Success: false}, fmt.Errorf("%w: %w", ErrInvalidPreferences, err)
+		}
+		existingPref.DefaultLocale = locale
+	}
+	if req.Timezone != nil {
+		existingPref.Timezone = *req.Timezone
+	}
+	if req.QuietHours != nil {
+		existingPref.QuietHours = *req.QuietHours
+	}
+	if req.FrequencyCaps != nil {
+		existingPref.FrequencyCaps = req.FrequencyCaps
+	}
+
+	if err := existingPref.Validate(); err != nil {
+		return UpdateTenantPreferencesResponse{Success: false}, fmt.Errorf("%w: %w", ErrInvalidPreferences, err)
+	}
+
+	var saveErr error
+	if isNewPreference {
+		saveErr = uc.tenantPrefRepo.CreateTenantPreference(ctx, existingPref)
+	} else {
+		saveErr = uc.tenantPrefRepo.UpdateTenantPreference(ctx, existingPref)
+	}
+
+	if saveErr != nil {
+		return UpdateTenantPreferencesResponse{Success: false}, fmt.Errorf("failed to save preferences: %w", saveErr)
+	}
+
+	return UpdateTenantPreferencesResponse{
+		Success: true,
+		Message: "Tenant preferences updated successfully",
+	}, nil
+}
@@ -1,18 +1,17 @@
 package updateuser
 
-
-
 type UpdateUserRequest struct {
-    ID        string
-    Email         string
-    PhoneNumber   string
-    DeviceID      string
-    WebPushToken  string
-    Consents      map[string]bool
-    PreferredMode string
+	ID            string
+	Email         string
+	PhoneNumber   string
+	DeviceID      string
+	WebPushToken  string
+	Consents      map[string]bool
+	PreferredMode string
+	Locale        string
 }
 
 type UpdateUserResponse struct {
-    Success bool
-    Message string
+	Success bool
+	Message string
 }
@@ -5,12 +5,14 @@ import (
 )
 
 type UpdateUserPreferencesRequest struct {
-	UserID         string                                  `json:"userId"`
-	TenantID       string                                  `json:"tenantId"`
-	Enabled        bool                                    `json:"enabled"`
-	ChannelPrefs   map[domain.ChannelType]bool             `json:"channelPrefs,omitempty"`
-	CategoryPrefs  map[string]domain.CategoryPreference    `json:"categoryPrefs,omitempty"`
-	DigestSettings *domain.DigestSettings                  `json:"digestSettings,omitempty"`
+	UserID         string                               `json:"userId"`
+	TenantID       string                               `json:"tenantId"`
+	Enabled        bool                                 `json:"enabled"`
+	ChannelPrefs   map[domain.ChannelType]bool          `json:"channelPrefs,omitempty"`
+	CategoryPrefs  map[string]domain.CategoryPreference `json:"categoryPrefs,omitempty"`
+	DigestSettings *domain.DigestSettings               `json:"digestSettings,omitempty"`
+	Timezone       *string                              `json:"timezone,omitempty"`
+	QuietHours     *domain.QuietHours                   `json:"quietHours,omitempty"`
 }
 
 func (r *UpdateUserPreferencesRequest) SetTenantID(id string) {
@@ -34,41 +34,49 @@ func (uc *updateUserPreferencesUseCase) Execute(ctx context.Context, req UpdateU
 	if err != nil {
 		return UpdateUserPreferencesResponse{Success: false}, fmt.Errorf("failed to get user: %w", err)
 	}
-	
+
 	// Try to get existing preferences
 	existingPref, err := uc.userPrefRepo.GetUserPreferenceByUserID(ctx, req.UserID)
 	var isNewPreference bool
-	
+
 	// If preferences don't exist yet, create new ones
 	if err != nil {
 		existingPref = *domain.NewUserPreference(req.UserID, req.TenantID)
 		existingPref.ID = uuid.New().String()
 		isNewPreference = true
 	}
-	
+
 	// Update with requested changes
 	existingPref.Enabled = req.Enabled
-	
+
 	// Update channel preferences if provided
 	if req.ChannelPrefs != nil {
 		existingPref.ChannelPrefs = req.ChannelPrefs
 	}
-	
+
 	// Update category preferences if provided
 	if req.CategoryPrefs != nil {
 		existingPref.CategoryPrefs = req.CategoryPrefs
 	}
-	
+
 	// Update digest settings if provided
 	if req.DigestSettings != nil {
 		existingPref.DigestSettings = *req.DigestSettings
 	}
-	
+
+	// Update timezone and quiet hours if provided
+	if req.Timezone != nil {
+		existingPref.Timezone = *req.Timezone
+	}
+	if req.QuietHours != nil {
+		existingPref.QuietHours = req.QuietHours
+	}
+
 	// Validate the updated preferences
 	if err := existingPref.Validate(); err != nil {
 		return UpdateUserPreferencesResponse{Success: false}, fmt.Errorf("invalid preferences: %w", err)
 	}
-	
+
 	// Save the preferences
 	var saveErr error
 	if isNewPreference {
@@ -76,11 +84,11 @@ func (uc *updateUserPreferencesUseCase) Execute(ctx context.Context, req UpdateU
 	} else {
 		saveErr = uc.userPrefRepo.UpdateUserPreference(ctx, existingPref)
 	}
-	
+
 	if saveErr != nil {
 		return UpdateUserPreferencesResponse{Success: false}, fmt.Errorf("failed to save preferences: %w", saveErr)
 	}
-	
+
 	return UpdateUserPreferencesResponse{
 		Success: true,
 		Message: "User preferences updated successfully",
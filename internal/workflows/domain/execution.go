@@ -19,26 +19,26 @@ const (
 )
 
 type WorkflowExecution struct {
-	ID          uuid.UUID        `json:"id" db:"id"`
-	WorkflowID  uuid.UUID        `json:"workflow_id" db:"workflow_id"`
-	TenantID    string           `json:"tenant_id" db:"tenant_id"`
-	TriggerID   string           `json:"trigger_id" db:"trigger_id"`
-	Status      ExecutionStatus  `json:"status" db:"status"`
-	Payload     json.RawMessage  `json:"payload" db:"payload"`
-	Context     ExecutionContext `json:"context" db:"context"`
-	Steps       []StepExecution  `json:"steps" db:"steps"`
-	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time        `json:"updated_at" db:"updated_at"`
-	StartedAt   *time.Time       `json:"started_at,omitempty" db:"started_at"`
-	CompletedAt *time.Time       `json:"completed_at,omitempty" db:"completed_at"`
-	ErrorMessage string          `json:"error_message,omitempty" db:"error_message"`
+	ID           uuid.UUID        `json:"id" db:"id"`
+	WorkflowID   uuid.UUID        `json:"workflow_id" db:"workflow_id"`
+	TenantID     string           `json:"tenant_id" db:"tenant_id"`
+	TriggerID    string           `json:"trigger_id" db:"trigger_id"`
+	Status       ExecutionStatus  `json:"status" db:"status"`
+	Payload      json.RawMessage  `json:"payload" db:"payload"`
+	Context      ExecutionContext `json:"context" db:"context"`
+	Steps        []StepExecution  `json:"steps" db:"steps"`
+	CreatedAt    time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at" db:"updated_at"`
+	StartedAt    *time.Time       `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt  *time.Time       `json:"completed_at,omitempty" db:"completed_at"`
+	ErrorMessage string           `json:"error_message,omitempty" db:"error_message"`
 }
 
 type ExecutionContext struct {
-	UserID      string                 `json:"user_id,omitempty"`
-	Subscriber  map[string]interface{} `json:"subscriber,omitempty"`
-	Variables   map[string]interface{} `json:"variables,omitempty"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	UserID     string                 `json:"user_id,omitempty"`
+	Subscriber map[string]interface{} `json:"subscriber,omitempty"`
+	Variables  map[string]interface{} `json:"variables,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
 }
 
 type StepExecution struct {
@@ -111,13 +111,24 @@ func (e *WorkflowExecution) AddStepExecution(stepID string, stepType StepType) *
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
-	
+
 	e.Steps = append(e.Steps, stepExecution)
 	e.UpdatedAt = now
-	
+
 	return &stepExecution
 }
 
+// SyncStepExecution copies the latest state of a step execution back into the execution
+func (e *WorkflowExecution) SyncStepExecution(stepExecution *StepExecution) {
+	for i := range e.Steps {
+		if e.Steps[i].ID == stepExecution.ID {
+			e.Steps[i] = *stepExecution
+			return
+		}
+	}
+	e.Steps = append(e.Steps, *stepExecution)
+}
+
 // NewStepExecution creates a new step execution
 func NewStepExecution(executionID uuid.UUID, stepID string, stepType StepType) *StepExecution {
 	now := time.Now()
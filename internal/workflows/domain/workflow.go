@@ -57,9 +57,22 @@ type WorkflowStep struct {
 }
 
 type Condition struct {
-	Field    string      `json:"field"`
-	Operator string      `json:"operator"` // eq, ne, gt, lt, contains, in, not_in
-	Value    interface{} `json:"value"`
+	Field      string      `json:"field,omitempty"`
+	Operator   string      `json:"operator,omitempty"` // eq, ne, gt, gte, lt, lte, contains, in, not_in
+	Value      interface{} `json:"value,omitempty"`
+	Logic      string      `json:"logic,omitempty"`      // and, or - set on groups only
+	Conditions []Condition `json:"conditions,omitempty"` // nested conditions for a group
+}
+
+// Condition group logic
+const (
+	ConditionLogicAnd = "and"
+	ConditionLogicOr  = "or"
+)
+
+// IsGroup returns true if the condition combines nested conditions
+func (c Condition) IsGroup() bool {
+	return len(c.Conditions) > 0
 }
 
 // NewWorkflow creates a new workflow
@@ -78,7 +91,9 @@ func NewWorkflow(tenantID, name, description string) *Workflow {
 
 // AddStep adds a new step to the workflow
 func (w *Workflow) AddStep(step WorkflowStep) {
-	step.ID = uuid.New().String()
+	if step.ID == "" {
+		step.ID = uuid.New().String()
+	}
 	step.Position = len(w.Steps) + 1
 	step.Enabled = true
 	w.Steps = append(w.Steps, step)
@@ -8,29 +8,29 @@ import (
 )
 
 type CreateWorkflowRequest struct {
-	Name        string                 `json:"name" validate:"required,min=1,max=255"`
-	Description string                 `json:"description" validate:"max=1000"`
-	Trigger     WorkflowTriggerDTO     `json:"trigger" validate:"required"`
-	Steps       []WorkflowStepDTO      `json:"steps" validate:"required,min=1"`
+	Name        string             `json:"name" validate:"required,min=1,max=255"`
+	Description string             `json:"description" validate:"max=1000"`
+	Trigger     WorkflowTriggerDTO `json:"trigger" validate:"required"`
+	Steps       []WorkflowStepDTO  `json:"steps" validate:"required,min=1"`
 }
 
 type UpdateWorkflowRequest struct {
-	Name        string                 `json:"name" validate:"required,min=1,max=255"`
-	Description string                 `json:"description" validate:"max=1000"`
-	Trigger     WorkflowTriggerDTO     `json:"trigger" validate:"required"`
-	Steps       []WorkflowStepDTO      `json:"steps" validate:"required,min=1"`
+	Name        string             `json:"name" validate:"required,min=1,max=255"`
+	Description string             `json:"description" validate:"max=1000"`
+	Trigger     WorkflowTriggerDTO `json:"trigger" validate:"required"`
+	Steps       []WorkflowStepDTO  `json:"steps" validate:"required,min=1"`
 }
 
 type WorkflowResponse struct {
-	ID          string                 `json:"id"`
-	TenantID    string                 `json:"tenant_id"`
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Status      string                 `json:"status"`
-	Trigger     WorkflowTriggerDTO     `json:"trigger"`
-	Steps       []WorkflowStepDTO      `json:"steps"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	ID          string             `json:"id"`
+	TenantID    string             `json:"tenant_id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Status      string             `json:"status"`
+	Trigger     WorkflowTriggerDTO `json:"trigger"`
+	Steps       []WorkflowStepDTO  `json:"steps"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
 }
 
 type WorkflowTriggerDTO struct {
@@ -51,15 +51,17 @@ type WorkflowStepDTO struct {
 }
 
 type ConditionDTO struct {
-	Field    string      `json:"field" validate:"required"`
-	Operator string      `json:"operator" validate:"required,oneof=eq ne gt lt gte lte contains in not_in"`
-	Value    interface{} `json:"value" validate:"required"`
+	Field      string         `json:"field,omitempty" validate:"required_without=Conditions"`
+	Operator   string         `json:"operator,omitempty" validate:"required_without=Conditions,omitempty,oneof=eq ne gt lt gte lte contains in not_in"`
+	Value      interface{}    `json:"value,omitempty"`
+	Logic      string         `json:"logic,omitempty" validate:"omitempty,oneof=and or"`
+	Conditions []ConditionDTO `json:"conditions,omitempty" validate:"dive"`
 }
 
 type TriggerWorkflowRequest struct {
 	TriggerIdentifier string                 `json:"trigger_identifier" validate:"required"`
-	Payload          map[string]interface{} `json:"payload" validate:"required"`
-	Context          ExecutionContextDTO    `json:"context"`
+	Payload           map[string]interface{} `json:"payload" validate:"required"`
+	Context           ExecutionContextDTO    `json:"context"`
 }
 
 type ExecutionContextDTO struct {
@@ -70,19 +72,19 @@ type ExecutionContextDTO struct {
 }
 
 type WorkflowExecutionResponse struct {
-	ID          string                    `json:"id"`
-	WorkflowID  string                    `json:"workflow_id"`
-	TenantID    string                    `json:"tenant_id"`
-	TriggerID   string                    `json:"trigger_id"`
-	Status      string                    `json:"status"`
-	Payload     map[string]interface{}    `json:"payload"`
-	Context     ExecutionContextDTO       `json:"context"`
-	Steps       []StepExecutionResponse   `json:"steps"`
-	CreatedAt   time.Time                 `json:"created_at"`
-	UpdatedAt   time.Time                 `json:"updated_at"`
-	StartedAt   *time.Time                `json:"started_at,omitempty"`
-	CompletedAt *time.Time                `json:"completed_at,omitempty"`
-	ErrorMessage string                   `json:"error_message,omitempty"`
+	ID           string                  `json:"id"`
+	WorkflowID   string                  `json:"workflow_id"`
+	TenantID     string                  `json:"tenant_id"`
+	TriggerID    string                  `json:"trigger_id"`
+	Status       string                  `json:"status"`
+	Payload      map[string]interface{}  `json:"payload"`
+	Context      ExecutionContextDTO     `json:"context"`
+	Steps        []StepExecutionResponse `json:"steps"`
+	CreatedAt    time.Time               `json:"created_at"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+	StartedAt    *time.Time              `json:"started_at,omitempty"`
+	CompletedAt  *time.Time              `json:"completed_at,omitempty"`
+	ErrorMessage string                  `json:"error_message,omitempty"`
 }
 
 type StepExecutionResponse struct {
@@ -131,24 +133,49 @@ type ListExecutionsResponse struct {
 }
 
 // Conversion methods
+
+// ToConditionDTOs converts domain conditions, including nested groups, to DTOs
+func ToConditionDTOs(conditions []domain.Condition) []ConditionDTO {
+	result := make([]ConditionDTO, len(conditions))
+	for i, condition := range conditions {
+		result[i] = ConditionDTO{
+			Field:    condition.Field,
+			Operator: condition.Operator,
+			Value:    condition.Value,
+			Logic:    condition.Logic,
+		}
+		if len(condition.Conditions) > 0 {
+			result[i].Conditions = ToConditionDTOs(condition.Conditions)
+		}
+	}
+	return result
+}
+
+// ToDomainConditions converts condition DTOs, including nested groups, to domain conditions
+func ToDomainConditions(conditions []ConditionDTO) []domain.Condition {
+	result := make([]domain.Condition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = domain.Condition{
+			Field:    condition.Field,
+			Operator: condition.Operator,
+			Value:    condition.Value,
+			Logic:    condition.Logic,
+		}
+		if len(condition.Conditions) > 0 {
+			result[i].Conditions = ToDomainConditions(condition.Conditions)
+		}
+	}
+	return result
+}
 func ToWorkflowResponse(workflow *domain.Workflow) *WorkflowResponse {
 	steps := make([]WorkflowStepDTO, len(workflow.Steps))
 	for i, step := range workflow.Steps {
-		conditions := make([]ConditionDTO, len(step.Conditions))
-		for j, condition := range step.Conditions {
-			conditions[j] = ConditionDTO{
-				Field:    condition.Field,
-				Operator: condition.Operator,
-				Value:    condition.Value,
-			}
-		}
-		
 		steps[i] = WorkflowStepDTO{
 			ID:         step.ID,
 			Type:       string(step.Type),
 			Name:       step.Name,
 			Config:     step.Config,
-			Conditions: conditions,
+			Conditions: ToConditionDTOs(step.Conditions),
 			NextSteps:  step.NextSteps,
 			Position:   step.Position,
 			Enabled:    step.Enabled,
@@ -184,7 +211,7 @@ func ToExecutionResponse(execution *domain.WorkflowExecution) *WorkflowExecution
 		if step.Result != nil {
 			json.Unmarshal(step.Result, &result)
 		}
-		
+
 		steps[i] = StepExecutionResponse{
 			ID:           step.ID.String(),
 			ExecutionID:  step.ExecutionID.String(),
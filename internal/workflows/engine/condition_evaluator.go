@@ -0,0 +1,492 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"getnoti.com/internal/workflows/domain"
+)
+
+// Condition operators
+const (
+	OperatorEq       = "eq"
+	OperatorNe       = "ne"
+	OperatorGt       = "gt"
+	OperatorGte      = "gte"
+	OperatorLt       = "lt"
+	OperatorLte      = "lte"
+	OperatorContains = "contains"
+	OperatorIn       = "in"
+	OperatorNotIn    = "not_in"
+)
+
+// Root segments available to condition field paths
+const (
+	pathRootPayload    = "payload"
+	pathRootSubscriber = "subscriber"
+	pathRootVariables  = "variables"
+	pathRootMetadata   = "metadata"
+	pathRootSteps      = "steps"
+	pathRootUserID     = "user_id"
+)
+
+// ConditionEvaluator evaluates workflow conditions against an execution.
+//
+// Field paths are dotted and start with one of payload, subscriber, variables,
+// metadata, steps or user_id, e.g. "payload.order.total", "subscriber.plan" or
+// "steps.<step_id>.status". Paths with any other root are resolved against the
+// trigger payload. Numeric segments index into arrays.
+type ConditionEvaluator struct {
+	data map[string]interface{}
+}
+
+// NewConditionEvaluator creates a condition evaluator for the given execution
+func NewConditionEvaluator(execution *domain.WorkflowExecution) (*ConditionEvaluator, error) {
+	data := map[string]interface{}{
+		pathRootSubscriber: map[string]interface{}{},
+		pathRootVariables:  map[string]interface{}{},
+		pathRootMetadata:   map[string]interface{}{},
+		pathRootSteps:      map[string]interface{}{},
+	}
+
+	if execution == nil {
+		return &ConditionEvaluator{data: data}, nil
+	}
+
+	var payload interface{}
+	if len(execution.Payload) > 0 {
+		if err := json.Unmarshal(execution.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal execution payload: %w", err)
+		}
+	}
+	data[pathRootPayload] = payload
+	data[pathRootUserID] = execution.Context.UserID
+
+	if execution.Context.Subscriber != nil {
+		data[pathRootSubscriber] = execution.Context.Subscriber
+	}
+	if execution.Context.Variables != nil {
+		data[pathRootVariables] = execution.Context.Variables
+	}
+	if execution.Context.Metadata != nil {
+		data[pathRootMetadata] = execution.Context.Metadata
+	}
+
+	steps := map[string]interface{}{}
+	for _, step := range execution.Steps {
+		entry := map[string]interface{}{
+			"status": string(step.Status),
+		}
+		if len(step.Result) > 0 {
+			var result interface{}
+			if err := json.Unmarshal(step.Result, &result); err == nil {
+				entry["result"] = result
+				// Allow "steps.<id>.<field>" as a shorthand for "steps.<id>.result.<field>"
+				if fields, ok := result.(map[string]interface{}); ok {
+					for k, v := range fields {
+						if _, exists := entry[k]; !exists {
+							entry[k] = v
+						}
+					}
+				}
+			}
+		}
+		if step.ErrorMessage != "" {
+			entry["error"] = step.ErrorMessage
+		}
+		steps[step.StepID] = entry
+	}
+	data[pathRootSteps] = steps
+
+	return &ConditionEvaluator{data: data}, nil
+}
+
+// EvaluateAll returns true if every condition is met. An empty list is always met.
+func (e *ConditionEvaluator) EvaluateAll(conditions []domain.Condition) (bool, error) {
+	return e.evaluateGroup(domain.ConditionLogicAnd, conditions)
+}
+
+// Evaluate evaluates a single condition or condition group
+func (e *ConditionEvaluator) Evaluate(condition domain.Condition) (bool, error) {
+	if condition.IsGroup() {
+		return e.evaluateGroup(condition.Logic, condition.Conditions)
+	}
+
+	if condition.Field == "" {
+		return false, fmt.Errorf("%w: condition field is required", domain.ErrStepValidation)
+	}
+
+	actual, found := e.Resolve(condition.Field)
+	return compare(strings.ToLower(condition.Operator), actual, found, condition.Value)
+}
+
+// Resolve looks up a dotted path in the evaluation data
+func (e *ConditionEvaluator) Resolve(path string) (interface{}, bool) {
+	segments := strings.Split(path, ".")
+
+	var current interface{} = e.data
+	if _, ok := e.data[segments[0]]; !ok {
+		current = e.data[pathRootPayload]
+	}
+
+	for _, segment := range segments {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func (e *ConditionEvaluator) evaluateGroup(logic string, conditions []domain.Condition) (bool, error) {
+	switch strings.ToLower(logic) {
+	case "", domain.ConditionLogicAnd:
+		for _, condition := range conditions {
+			met, err := e.Evaluate(condition)
+			if err != nil || !met {
+				return false, err
+			}
+		}
+		return true, nil
+	case domain.ConditionLogicOr:
+		if len(conditions) == 0 {
+			return true, nil
+		}
+		for _, condition := range conditions {
+			met, err := e.Evaluate(condition)
+			if err != nil {
+				return false, err
+			}
+			if met {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: unsupported condition logic %q", domain.ErrStepValidation, logic)
+	}
+}
+
+// compare applies an operator to a resolved value and the expected value
+func compare(operator string, actual interface{}, found bool, expected interface{}) (bool, error) {
+	switch operator {
+	case OperatorEq:
+		return found && valuesEqual(actual, expected), nil
+	case OperatorNe:
+		return !found || !valuesEqual(actual, expected), nil
+	case OperatorGt, OperatorGte, OperatorLt, OperatorLte:
+		if !found {
+			return false, nil
+		}
+		cmp, ok := orderValues(actual, expected)
+		if !ok {
+			return false, nil
+		}
+		switch operator {
+		case OperatorGt:
+			return cmp > 0, nil
+		case OperatorGte:
+			return cmp >= 0, nil
+		case OperatorLt:
+			return cmp < 0, nil
+		default:
+			return cmp <= 0, nil
+		}
+	case OperatorContains:
+		return found && containsValue(actual, expected), nil
+	case OperatorIn:
+		return found && inValues(actual, expected), nil
+	case OperatorNotIn:
+		return !found || !inValues(actual, expected), nil
+	default:
+		return false, fmt.Errorf("%w: unsupported condition operator %q", domain.ErrStepValidation, operator)
+	}
+}
+
+// valuesEqual compares numbers numerically; strings compare as written, so
+// "007" does not equal "7"
+func valuesEqual(a, b interface{}) bool {
+	if af, ok := toNumber(a); ok {
+		if bf, ok := toNumber(b); ok {
+			return af == bf
+		}
+	}
+	if ab, ok := a.(bool); ok {
+		if bb, ok := toBool(b); ok {
+			return ab == bb
+		}
+	}
+	if as, ok := a.(string); ok {
+		if bs, ok := b.(string); ok {
+			return as == bs
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// orderValues compares two values numerically, as RFC 3339 times, or as strings
+func orderValues(a, b interface{}) (int, bool) {
+	if af, ok := toFloat(a); ok {
+		if bf, ok := toFloat(b); ok {
+			switch {
+			case af < bf:
+				return -1, true
+			case af > bf:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	}
+
+	as, aok := a.(string)
+	bs, bok := b.(string)
+	if !aok || !bok {
+		return 0, false
+	}
+
+	if at, err := time.Parse(time.RFC3339, as); err == nil {
+		if bt, err := time.Parse(time.RFC3339, bs); err == nil {
+			return at.Compare(bt), true
+		}
+	}
+
+	return strings.Compare(as, bs), true
+}
+
+func containsValue(haystack, needle interface{}) bool {
+	switch h := haystack.(type) {
+	case string:
+		n, ok := needle.(string)
+		return ok && strings.Contains(h, n)
+	case []interface{}:
+		for _, item := range h {
+			if valuesEqual(item, needle) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		key, ok := needle.(string)
+		if !ok {
+			return false
+		}
+		_, exists := h[key]
+		return exists
+	default:
+		return false
+	}
+}
+
+func inValues(value, list interface{}) bool {
+	items, ok := list.([]interface{})
+	if !ok {
+		rv := reflect.ValueOf(list)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return valuesEqual(value, list)
+		}
+		items = make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+	}
+
+	for _, item := range items {
+		if valuesEqual(value, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// toFloat converts a number or numeric string to a float
+func toFloat(v interface{}) (float64, bool) {
+	if s, ok := v.(string); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil
+	}
+	return toNumber(v)
+}
+
+// toNumber converts a number to a float; strings are not numbers
+func toNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func toBool(v interface{}) (bool, bool) {
+	switch b := v.(type) {
+	case bool:
+		return b, true
+	case string:
+		parsed, err := strconv.ParseBool(b)
+		return parsed, err == nil
+	default:
+		return false, false
+	}
+}
+
+// ValidateConditions checks that every condition is either a group with
+// known logic or a leaf with a field and a known operator
+func ValidateConditions(conditions []domain.Condition) error {
+	for _, condition := range conditions {
+		if condition.IsGroup() {
+			switch strings.ToLower(condition.Logic) {
+			case "", domain.ConditionLogicAnd, domain.ConditionLogicOr:
+			default:
+				return fmt.Errorf("%w: unsupported condition logic %q", domain.ErrStepValidation, condition.Logic)
+			}
+			if err := ValidateConditions(condition.Conditions); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if condition.Field == "" {
+			return fmt.Errorf("%w: condition field is required", domain.ErrStepValidation)
+		}
+		switch strings.ToLower(condition.Operator) {
+		case OperatorEq, OperatorNe, OperatorGt, OperatorGte, OperatorLt, OperatorLte, OperatorContains, OperatorIn, OperatorNotIn:
+		case "":
+			return fmt.Errorf("%w: condition operator is required for field %s", domain.ErrStepValidation, condition.Field)
+		default:
+			return fmt.Errorf("%w: unsupported condition operator %q", domain.ErrStepValidation, condition.Operator)
+		}
+	}
+	return nil
+}
+
+// ValidateStepConditions checks a step's conditions and, for condition steps,
+// the conditions in its config
+func ValidateStepConditions(step *domain.WorkflowStep) error {
+	if err := ValidateConditions(step.Conditions); err != nil {
+		return fmt.Errorf("step %s: %w", step.Name, err)
+	}
+	if step.Type != domain.StepTypeCondition {
+		return nil
+	}
+
+	conditions, err := parseConditions(step.Config["conditions"])
+	if err != nil {
+		return fmt.Errorf("step %s: %w", step.Name, err)
+	}
+	if len(conditions) == 0 {
+		return fmt.Errorf("%w: condition step %s has no conditions", domain.ErrStepValidation, step.Name)
+	}
+	if err := ValidateConditions(conditions); err != nil {
+		return fmt.Errorf("step %s: %w", step.Name, err)
+	}
+	return nil
+}
+
+// parseConditions decodes conditions stored in a step config value
+func parseConditions(raw interface{}) ([]domain.Condition, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal conditions: %w", err)
+	}
+
+	var conditions []domain.Condition
+	if err := json.Unmarshal(data, &conditions); err != nil {
+		// Accept a single condition or group object as well as a list
+		var condition domain.Condition
+		if err := json.Unmarshal(data, &condition); err != nil {
+			return nil, fmt.Errorf("%w: invalid conditions: %v", domain.ErrStepValidation, err)
+		}
+		conditions = []domain.Condition{condition}
+	}
+
+	return conditions, nil
+}
+
+// stringList converts a step config value into a list of strings
+func stringList(raw interface{}) []string {
+	switch v := raw.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				result = append(result, s)
+			}
+		}
+		return result
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	default:
+		return nil
+	}
+}
+
+// conditionBranch evaluates a condition step's config and returns the outcome
+// along with the step IDs of the branch that was taken and the one that was not.
+//
+// The step config supports "conditions" (a list or a single group), "logic"
+// (and/or for the top-level list), "on_true" and "on_false" (step ID lists).
+// When neither branch is configured the step's NextSteps only run if it is met.
+func conditionBranch(evaluator *ConditionEvaluator, step *domain.WorkflowStep) (bool, []string, []string, error) {
+	conditions, err := parseConditions(step.Config["conditions"])
+	if err != nil {
+		return false, nil, nil, err
+	}
+	if len(conditions) == 0 {
+		return false, nil, nil, fmt.Errorf("%w: condition step %s has no conditions", domain.ErrStepValidation, step.ID)
+	}
+
+	logic, _ := step.Config["logic"].(string)
+	met, err := evaluator.Evaluate(domain.Condition{Logic: logic, Conditions: conditions})
+	if err != nil {
+		return false, nil, nil, err
+	}
+
+	onTrue := stringList(step.Config["on_true"])
+	onFalse := stringList(step.Config["on_false"])
+	if len(onTrue) == 0 && len(onFalse) == 0 {
+		// Without explicit branches the step gates its next steps
+		onTrue = step.NextSteps
+	}
+	if met {
+		return true, onTrue, onFalse, nil
+	}
+	return false, onFalse, onTrue, nil
+}
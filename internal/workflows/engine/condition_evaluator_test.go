@@ -0,0 +1,221 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"getnoti.com/config"
+	"getnoti.com/internal/workflows/domain"
+	"getnoti.com/pkg/logger"
+)
+
+func newTestEvaluator(t *testing.T) *ConditionEvaluator {
+	t.Helper()
+
+	evaluator, err := NewConditionEvaluator(&domain.WorkflowExecution{
+		Payload: json.RawMessage(`{
+			"order": {"total": 120.5, "status": "paid", "items": [{"sku": "A-1"}, {"sku": "B-2"}]},
+			"tags": ["vip", "beta"],
+			"code": "007",
+			"active": true,
+			"placed_at": "2026-03-01T10:00:00Z"
+		}`),
+		Context: domain.ExecutionContext{
+			UserID:     "user-1",
+			Subscriber: map[string]interface{}{"plan": "pro", "age": 30},
+			Variables:  map[string]interface{}{"region": "eu"},
+			Metadata:   map[string]interface{}{"source": "api"},
+		},
+		Steps: []domain.StepExecution{
+			{StepID: "send", Status: domain.ExecutionStatusCompleted, Result: json.RawMessage(`{"provider": "smtp"}`)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewConditionEvaluator: %v", err)
+	}
+	return evaluator
+}
+
+func TestConditionEvaluatorOperators(t *testing.T) {
+	evaluator := newTestEvaluator(t)
+
+	tests := []struct {
+		name      string
+		condition domain.Condition
+		want      bool
+	}{
+		{name: "eq number", condition: domain.Condition{Field: "payload.order.total", Operator: OperatorEq, Value: 120.5}, want: true},
+		{name: "eq int against float", condition: domain.Condition{Field: "subscriber.age", Operator: OperatorEq, Value: 30.0}, want: true},
+		{name: "eq string", condition: domain.Condition{Field: "payload.order.status", Operator: OperatorEq, Value: "paid"}, want: true},
+		{name: "eq string keeps leading zeros", condition: domain.Condition{Field: "payload.code", Operator: OperatorEq, Value: "7"}, want: false},
+		{name: "eq bool from string", condition: domain.Condition{Field: "payload.active", Operator: OperatorEq, Value: "true"}, want: true},
+		{name: "eq missing field", condition: domain.Condition{Field: "payload.missing", Operator: OperatorEq, Value: "x"}, want: false},
+		{name: "case-insensitive operator", condition: domain.Condition{Field: "payload.order.status", Operator: "EQ", Value: "paid"}, want: true},
+		{name: "ne", condition: domain.Condition{Field: "payload.order.status", Operator: OperatorNe, Value: "refunded"}, want: true},
+		{name: "ne missing field", condition: domain.Condition{Field: "payload.missing", Operator: OperatorNe, Value: "x"}, want: true},
+		{name: "gt", condition: domain.Condition{Field: "payload.order.total", Operator: OperatorGt, Value: 100}, want: true},
+		{name: "gt numeric string", condition: domain.Condition{Field: "payload.order.total", Operator: OperatorGt, Value: "200"}, want: false},
+		{name: "gte equal", condition: domain.Condition{Field: "subscriber.age", Operator: OperatorGte, Value: 30}, want: true},
+		{name: "lt", condition: domain.Condition{Field: "subscriber.age", Operator: OperatorLt, Value: 18}, want: false},
+		{name: "lte", condition: domain.Condition{Field: "subscriber.age", Operator: OperatorLte, Value: 30}, want: true},
+		{name: "lt times", condition: domain.Condition{Field: "payload.placed_at", Operator: OperatorLt, Value: "2026-03-01T11:00:00+00:00"}, want: true},
+		{name: "gt strings", condition: domain.Condition{Field: "payload.order.status", Operator: OperatorGt, Value: "open"}, want: true},
+		{name: "gt missing field", condition: domain.Condition{Field: "payload.missing", Operator: OperatorGt, Value: 1}, want: false},
+		{name: "gt incomparable", condition: domain.Condition{Field: "payload.tags", Operator: OperatorGt, Value: 1}, want: false},
+		{name: "contains substring", condition: domain.Condition{Field: "payload.order.status", Operator: OperatorContains, Value: "ai"}, want: true},
+		{name: "contains list item", condition: domain.Condition{Field: "payload.tags", Operator: OperatorContains, Value: "vip"}, want: true},
+		{name: "contains map key", condition: domain.Condition{Field: "payload.order", Operator: OperatorContains, Value: "total"}, want: true},
+		{name: "contains missing", condition: domain.Condition{Field: "payload.tags", Operator: OperatorContains, Value: "gold"}, want: false},
+		{name: "in", condition: domain.Condition{Field: "subscriber.plan", Operator: OperatorIn, Value: []interface{}{"pro", "team"}}, want: true},
+		{name: "in typed slice", condition: domain.Condition{Field: "subscriber.plan", Operator: OperatorIn, Value: []string{"free"}}, want: false},
+		{name: "in single value", condition: domain.Condition{Field: "subscriber.plan", Operator: OperatorIn, Value: "pro"}, want: true},
+		{name: "not_in", condition: domain.Condition{Field: "subscriber.plan", Operator: OperatorNotIn, Value: []interface{}{"free"}}, want: true},
+		{name: "not_in missing field", condition: domain.Condition{Field: "payload.missing", Operator: OperatorNotIn, Value: []interface{}{"free"}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluator.Evaluate(tt.condition)
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Evaluate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionEvaluatorGroups(t *testing.T) {
+	evaluator := newTestEvaluator(t)
+
+	paid := domain.Condition{Field: "payload.order.status", Operator: OperatorEq, Value: "paid"}
+	free := domain.Condition{Field: "subscriber.plan", Operator: OperatorEq, Value: "free"}
+	eu := domain.Condition{Field: "variables.region", Operator: OperatorEq, Value: "eu"}
+
+	tests := []struct {
+		name      string
+		condition domain.Condition
+		want      bool
+	}{
+		{name: "and all met", condition: domain.Condition{Logic: domain.ConditionLogicAnd, Conditions: []domain.Condition{paid, eu}}, want: true},
+		{name: "and one unmet", condition: domain.Condition{Logic: domain.ConditionLogicAnd, Conditions: []domain.Condition{paid, free}}, want: false},
+		{name: "or one met", condition: domain.Condition{Logic: domain.ConditionLogicOr, Conditions: []domain.Condition{free, eu}}, want: true},
+		{name: "or none met", condition: domain.Condition{Logic: domain.ConditionLogicOr, Conditions: []domain.Condition{free}}, want: false},
+		{name: "logic defaults to and", condition: domain.Condition{Conditions: []domain.Condition{paid, free}}, want: false},
+		{
+			name: "nested groups",
+			condition: domain.Condition{Logic: domain.ConditionLogicAnd, Conditions: []domain.Condition{
+				paid,
+				{Logic: domain.ConditionLogicOr, Conditions: []domain.Condition{free, eu}},
+			}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluator.Evaluate(tt.condition)
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Evaluate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if met, err := evaluator.EvaluateAll(nil); err != nil || !met {
+		t.Errorf("EvaluateAll(nil) = %v, %v; want true", met, err)
+	}
+}
+
+func TestConditionEvaluatorErrors(t *testing.T) {
+	evaluator := newTestEvaluator(t)
+
+	tests := []struct {
+		name      string
+		condition domain.Condition
+	}{
+		{name: "unknown operator", condition: domain.Condition{Field: "payload.code", Operator: "matches", Value: "7"}},
+		{name: "missing field", condition: domain.Condition{Operator: OperatorEq, Value: "7"}},
+		{name: "unknown logic", condition: domain.Condition{Logic: "xor", Conditions: []domain.Condition{{Field: "payload.code", Operator: OperatorEq, Value: "007"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := evaluator.Evaluate(tt.condition); !errors.Is(err, domain.ErrStepValidation) {
+				t.Errorf("Evaluate error = %v, want %v", err, domain.ErrStepValidation)
+			}
+			if err := ValidateConditions([]domain.Condition{tt.condition}); !errors.Is(err, domain.ErrStepValidation) {
+				t.Errorf("ValidateConditions error = %v, want %v", err, domain.ErrStepValidation)
+			}
+		})
+	}
+}
+
+func TestConditionEvaluatorResolve(t *testing.T) {
+	evaluator := newTestEvaluator(t)
+
+	tests := []struct {
+		path  string
+		want  interface{}
+		found bool
+	}{
+		{path: "payload.order.status", want: "paid", found: true},
+		{path: "order.status", want: "paid", found: true},
+		{path: "payload.order.items.1.sku", want: "B-2", found: true},
+		{path: "payload.order.items.2.sku", found: false},
+		{path: "payload.order.items.x", found: false},
+		{path: "payload.order.status.value", found: false},
+		{path: "subscriber.plan", want: "pro", found: true},
+		{path: "variables.region", want: "eu", found: true},
+		{path: "metadata.source", want: "api", found: true},
+		{path: "user_id", want: "user-1", found: true},
+		{path: "steps.send.status", want: "completed", found: true},
+		{path: "steps.send.result.provider", want: "smtp", found: true},
+		{path: "steps.send.provider", want: "smtp", found: true},
+		{path: "steps.other.status", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, found := evaluator.Resolve(tt.path)
+			if found != tt.found || (found && got != tt.want) {
+				t.Errorf("Resolve(%q) = %v, %v; want %v, %v", tt.path, got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestExecuteConditionStepUnskipsTakenBranch(t *testing.T) {
+	condition := func(id string, value bool, config map[string]interface{}) domain.WorkflowStep {
+		config["conditions"] = []interface{}{map[string]interface{}{"field": "payload.flag", "operator": "eq", "value": value}}
+		return domain.WorkflowStep{ID: id, Type: domain.StepTypeCondition, Config: config}
+	}
+	// first skips x and y; second takes w, whose branch leads back into y
+	workflow := &domain.Workflow{Steps: []domain.WorkflowStep{
+		condition("first", false, map[string]interface{}{"on_true": []interface{}{"x"}, "on_false": []interface{}{"second"}}),
+		condition("second", true, map[string]interface{}{"on_true": []interface{}{"w"}}),
+		{ID: "x", NextSteps: []string{"y"}},
+		{ID: "w", NextSteps: []string{"y"}},
+		{ID: "y"},
+	}}
+	job := &WorkflowExecutionJob{
+		execution:    &domain.WorkflowExecution{Payload: json.RawMessage(`{"flag": true}`)},
+		workflow:     workflow,
+		logger:       logger.New(&config.Config{Logger: config.LoggerConfig{Level: "error"}}),
+		skippedSteps: make(map[string]bool),
+	}
+
+	for i := range workflow.Steps[:2] {
+		if _, err := job.executeConditionStep(context.Background(), &workflow.Steps[i]); err != nil {
+			t.Fatalf("executeConditionStep(%s): %v", workflow.Steps[i].ID, err)
+		}
+	}
+
+	if !job.skippedSteps["x"] {
+		t.Error("x was not skipped")
+	}
+	if job.skippedSteps["w"] || job.skippedSteps["y"] {
+		t.Errorf("taken branch is still skipped: %v", job.skippedSteps)
+	}
+}
@@ -0,0 +1,165 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	notificationServices "getnoti.com/internal/notifications/services"
+	tenantDomain "getnoti.com/internal/tenants/domain"
+	"getnoti.com/internal/workflows/domain"
+	"getnoti.com/pkg/logger"
+)
+
+// defaultDigestSettings applies to digest steps without a window when the user
+// has no digest settings of their own: an hourly digest
+var defaultDigestSettings = tenantDomain.DigestSettings{
+	Enabled:         true,
+	Type:            tenantDomain.DigestTypeInterval,
+	IntervalMinutes: 60,
+}
+
+// addToDigest adds the execution's event to the user's digest for the step's
+// digest_key and returns the step result. The digest is sent when its window
+// ends: the step's own window if configured, otherwise the user's digest
+// settings, otherwise hourly.
+func addToDigest(ctx context.Context, digestService *notificationServices.DigestService, workflow *domain.Workflow, execution *domain.WorkflowExecution, step *domain.WorkflowStep, log logger.Logger) (map[string]interface{}, error) {
+	if digestService == nil {
+		return nil, fmt.Errorf("digest service is not configured")
+	}
+
+	userID := execution.Context.UserID
+	if userID == "" {
+		return nil, fmt.Errorf("no user_id found in context")
+	}
+
+	templateID, _ := step.Config["template_id"].(string)
+	if templateID == "" {
+		return nil, fmt.Errorf("no template_id specified in step configuration")
+	}
+
+	channel := "email"
+	if c, ok := step.Config["channel"].(string); ok && c != "" {
+		channel = c
+	}
+
+	// Events from every execution of this step share a digest unless the step names one
+	digestKey := workflow.ID.String() + ":" + step.ID
+	if k, ok := step.Config["digest_key"].(string); ok && k != "" {
+		digestKey = k
+	}
+
+	category, _ := step.Config["category"].(string)
+
+	settings, configured, err := digestSettingsFromConfig(step.Config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest configuration: %w", err)
+	}
+	if !configured {
+		settings, configured, err = digestService.UserSettings(ctx, execution.TenantID, userID, category)
+		if err != nil || !configured {
+			settings = defaultDigestSettings
+		}
+	}
+
+	digest, err := digestService.Add(ctx, notificationServices.DigestItem{
+		TenantID:   execution.TenantID,
+		UserID:     userID,
+		Key:        digestKey,
+		Channel:    channel,
+		TemplateID: templateID,
+		Category:   category,
+		Data:       digestItemData(execution),
+	}, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add event to digest: %w", err)
+	}
+
+	log.Info("Event added to digest",
+		logger.String("step_id", step.ID),
+		logger.String("execution_id", execution.ID.String()),
+		logger.String("digest_id", digest.ID),
+		logger.Int("event_count", digest.EventCount))
+
+	return map[string]interface{}{
+		"type":        "digest",
+		"scheduled":   true,
+		"digest_id":   digest.ID,
+		"digest_key":  digestKey,
+		"event_count": digest.EventCount,
+		"send_at":     digest.SendAt.Format(time.RFC3339),
+	}, nil
+}
+
+// digestSettingsFromConfig reads a digest step's window. A step sets either
+// window_minutes, or digest_type ("daily", "weekly" or "interval") with
+// delivery_hour, day_of_week (0=Sun) or interval_minutes as the type needs.
+// It reports false when the step leaves the window to the user's settings.
+func digestSettingsFromConfig(config map[string]interface{}) (tenantDomain.DigestSettings, bool, error) {
+	if minutes, ok := configInt(config, "window_minutes"); ok {
+		if minutes <= 0 {
+			return tenantDomain.DigestSettings{}, false, fmt.Errorf("window_minutes must be greater than 0")
+		}
+		return tenantDomain.DigestSettings{
+			Enabled:         true,
+			Type:            tenantDomain.DigestTypeInterval,
+			IntervalMinutes: minutes,
+		}, true, nil
+	}
+
+	digestType, _ := config["digest_type"].(string)
+	if digestType == "" {
+		return tenantDomain.DigestSettings{}, false, nil
+	}
+
+	settings := tenantDomain.DigestSettings{Enabled: true, Type: tenantDomain.DigestType(digestType)}
+	settings.IntervalMinutes, _ = configInt(config, "interval_minutes")
+	settings.DeliveryHour, _ = configInt(config, "delivery_hour")
+	settings.PreferredDayOfWeek, _ = configInt(config, "day_of_week")
+
+	switch settings.Type {
+	case tenantDomain.DigestTypeInterval:
+		if settings.IntervalMinutes <= 0 {
+			return tenantDomain.DigestSettings{}, false, fmt.Errorf("interval_minutes must be greater than 0")
+		}
+	case tenantDomain.DigestTypeDaily, tenantDomain.DigestTypeWeekly:
+		if settings.DeliveryHour < 0 || settings.DeliveryHour > 23 {
+			return tenantDomain.DigestSettings{}, false, fmt.Errorf("delivery_hour must be between 0 and 23")
+		}
+		if settings.PreferredDayOfWeek < 0 || settings.PreferredDayOfWeek > 6 {
+			return tenantDomain.DigestSettings{}, false, fmt.Errorf("day_of_week must be between 0 and 6")
+		}
+	default:
+		return tenantDomain.DigestSettings{}, false, fmt.Errorf("unsupported digest_type: %s", digestType)
+	}
+	return settings, true, nil
+}
+
+// digestItemData is the event a digest step adds to the digest: the trigger
+// payload, with the execution variables over it
+func digestItemData(execution *domain.WorkflowExecution) map[string]interface{} {
+	data := map[string]interface{}{}
+	var payload interface{}
+	if len(execution.Payload) > 0 && json.Unmarshal(execution.Payload, &payload) == nil {
+		if object, ok := payload.(map[string]interface{}); ok {
+			data = object
+		} else {
+			// Payloads that are not JSON objects are kept under one key
+			data["payload"] = payload
+		}
+	}
+	for key, value := range execution.Context.Variables {
+		data[key] = value
+	}
+	return data
+}
+
+func configInt(config map[string]interface{}, key string) (int, bool) {
+	value, ok := config[key]
+	if !ok {
+		return 0, false
+	}
+	n, ok := toFloat(value)
+	return int(n), ok
+}
@@ -2,9 +2,11 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	notificationServices "getnoti.com/internal/notifications/services"
 	"getnoti.com/internal/workflows/domain"
 	"getnoti.com/internal/workflows/repos"
 	"getnoti.com/pkg/logger"
@@ -13,11 +15,12 @@ import (
 
 // WorkflowRetryJob implements the workerpool.Job interface for retrying failed workflow executions
 type WorkflowRetryJob struct {
-	execution    *domain.WorkflowExecution
-	workflow     *domain.Workflow
-	workflowRepo repos.WorkflowRepository
+	execution     *domain.WorkflowExecution
+	workflow      *domain.Workflow
+	workflowRepo  repos.WorkflowRepository
 	executionRepo repos.ExecutionRepository
-	logger       logger.Logger
+	digestService *notificationServices.DigestService
+	logger        logger.Logger
 }
 
 // NewWorkflowRetryJob creates a new workflow retry job
@@ -26,14 +29,16 @@ func NewWorkflowRetryJob(
 	workflow *domain.Workflow,
 	workflowRepo repos.WorkflowRepository,
 	executionRepo repos.ExecutionRepository,
+	digestService *notificationServices.DigestService,
 	logger logger.Logger,
 ) workerpool.Job {
 	return &WorkflowRetryJob{
-		execution:    execution,
-		workflow:     workflow,
-		workflowRepo: workflowRepo,
+		execution:     execution,
+		workflow:      workflow,
+		workflowRepo:  workflowRepo,
 		executionRepo: executionRepo,
-		logger:       logger,
+		digestService: digestService,
+		logger:        logger,
 	}
 }
 
@@ -45,7 +50,7 @@ func (j *WorkflowRetryJob) Process(ctx context.Context) error {
 		logger.String("tenant_id", j.execution.TenantID))
 	// Reset execution status for retry
 	j.execution.Status = domain.ExecutionStatusRunning
-	
+
 	// Update execution in repository
 	if err := j.executionRepo.UpdateExecution(ctx, j.execution, nil); err != nil {
 		j.logger.Error("Failed to update execution status for retry",
@@ -55,8 +60,8 @@ func (j *WorkflowRetryJob) Process(ctx context.Context) error {
 	}
 
 	// Create a new execution job to handle the retry
-	job := NewWorkflowExecutionJob(j.execution, j.workflow, j.workflowRepo, j.executionRepo, j.logger)
-	
+	job := NewWorkflowExecutionJob(j.execution, j.workflow, j.workflowRepo, j.executionRepo, j.digestService, j.logger)
+
 	// Process the execution directly
 	return job.Process(ctx)
 }
@@ -101,7 +106,7 @@ func (j *DelayedStepJob) Process(ctx context.Context) error {
 	j.stepExecution.Status = domain.ExecutionStatusRunning
 	now := time.Now()
 	j.stepExecution.StartedAt = &now
-	
+
 	// Save step execution status
 	if err := j.executionRepo.UpdateStepExecution(ctx, j.stepExecution, nil); err != nil {
 		j.logger.Error("Failed to update step execution status",
@@ -125,7 +130,7 @@ func (j *DelayedStepJob) Process(ctx context.Context) error {
 			logger.String("step_id", j.stepExecution.StepID),
 			logger.String("workflow_id", j.workflow.ID.String()),
 			logger.Err(err))
-				j.stepExecution.Status = domain.ExecutionStatusFailed
+		j.stepExecution.Status = domain.ExecutionStatusFailed
 		j.stepExecution.ErrorMessage = err.Error()
 		j.executionRepo.UpdateStepExecution(ctx, j.stepExecution, nil)
 		return err
@@ -133,7 +138,7 @@ func (j *DelayedStepJob) Process(ctx context.Context) error {
 	// Execute the step
 	var err error
 	output, err := executeStep(ctx, step, j.execution, nil, j.logger) // Using nil for input since field doesn't exist
-	
+
 	if err != nil {
 		j.stepExecution.Status = domain.ExecutionStatusFailed
 		j.stepExecution.ErrorMessage = err.Error()
@@ -148,10 +153,10 @@ func (j *DelayedStepJob) Process(ctx context.Context) error {
 			logger.String("step_id", step.ID),
 			logger.String("step_execution_id", j.stepExecution.ID.String()))
 	}
-	
+
 	now2 := time.Now()
 	j.stepExecution.CompletedAt = &now2
-	
+
 	// Save step execution result
 	if updateErr := j.executionRepo.UpdateStepExecution(ctx, j.stepExecution, nil); updateErr != nil {
 		j.logger.Error("Failed to update step execution result",
@@ -167,7 +172,6 @@ func (j *DelayedStepJob) Process(ctx context.Context) error {
 	return err
 }
 
-
 // executeStep executes a workflow step based on its type and configuration
 // This is a placeholder implementation - in a real application, this would be more complex
 func executeStep(ctx context.Context, step *domain.WorkflowStep, execution *domain.WorkflowExecution, input []byte, log logger.Logger) ([]byte, error) {
@@ -180,9 +184,22 @@ func executeStep(ctx context.Context, step *domain.WorkflowStep, execution *doma
 	case "webhook":
 		// Here we would make an HTTP request to a webhook endpoint
 		return []byte(`{"success": true, "message": "Webhook called"}`), nil
-	case "condition":
-		// Here we would evaluate a condition and return a result
-		return []byte(`{"result": true}`), nil
+	case domain.StepTypeCondition:
+		evaluator, err := NewConditionEvaluator(execution)
+		if err != nil {
+			return nil, err
+		}
+		met, taken, skipped, err := conditionBranch(evaluator, step)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]interface{}{
+			"type":          "condition",
+			"evaluated":     true,
+			"result":        met,
+			"next_steps":    taken,
+			"skipped_steps": skipped,
+		})
 	default:
 		return nil, fmt.Errorf("unsupported step type: %s", step.Type)
 	}
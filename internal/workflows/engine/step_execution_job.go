@@ -24,7 +24,10 @@ type StepExecutionJob struct {
 	executionRepo       repos.ExecutionRepository
 	eventBus            events.EventBus
 	notificationService *notificationServices.NotificationService
+	digestService       *notificationServices.DigestService
 	logger              logger.Logger
+	branchSteps         []string
+	branched            bool
 }
 
 // NewStepExecutionJob creates a new step execution job
@@ -36,6 +39,7 @@ func NewStepExecutionJob(
 	executionRepo repos.ExecutionRepository,
 	eventBus events.EventBus,
 	notificationService *notificationServices.NotificationService,
+	digestService *notificationServices.DigestService,
 	logger logger.Logger,
 ) workerpool.Job {
 	return &StepExecutionJob{
@@ -46,6 +50,7 @@ func NewStepExecutionJob(
 		executionRepo:       executionRepo,
 		eventBus:            eventBus,
 		notificationService: notificationService,
+		digestService:       digestService,
 		logger:              logger,
 	}
 }
@@ -60,7 +65,7 @@ func (j *StepExecutionJob) Process(ctx context.Context) error {
 
 	// Start the step execution
 	j.stepExecution.Start()
-		// Update step execution status to running
+	// Update step execution status to running
 	if err := j.executionRepo.UpdateStepExecution(ctx, j.stepExecution, nil); err != nil {
 		j.logger.Error("Failed to update step execution status to running",
 			logger.String("step_execution_id", j.stepExecution.ID.String()),
@@ -97,7 +102,7 @@ func (j *StepExecutionJob) Process(ctx context.Context) error {
 	// Complete or fail the step
 	status := "completed"
 	errorMessage := ""
-	
+
 	if stepError != nil {
 		j.stepExecution.Fail(stepError.Error())
 		j.logger.Error("Step execution failed",
@@ -113,6 +118,10 @@ func (j *StepExecutionJob) Process(ctx context.Context) error {
 			logger.String("step_id", j.workflowStep.ID),
 			logger.String("step_execution_id", j.stepExecution.ID.String()))
 	}
+
+	// Make the step result visible to conditions on later steps
+	j.execution.SyncStepExecution(j.stepExecution)
+
 	// Save step execution final state
 	if updateErr := j.executionRepo.UpdateStepExecution(ctx, j.stepExecution, nil); updateErr != nil {
 		j.logger.Error("Failed to update step execution final status",
@@ -122,7 +131,7 @@ func (j *StepExecutionJob) Process(ctx context.Context) error {
 
 	// Publish step execution event
 	j.publishStepExecutionEvent(ctx, status, duration, result, errorMessage)
-	
+
 	// Process next steps if this step completed successfully
 	if stepError == nil {
 		j.processNextSteps(ctx)
@@ -133,16 +142,22 @@ func (j *StepExecutionJob) Process(ctx context.Context) error {
 
 // processNextSteps processes the next steps in the workflow
 func (j *StepExecutionJob) processNextSteps(ctx context.Context) {
-	if len(j.workflowStep.NextSteps) == 0 {
+	nextSteps := j.workflowStep.NextSteps
+	if j.branched {
+		// Condition steps continue down the branch they evaluated to
+		nextSteps = j.branchSteps
+	}
+
+	if len(nextSteps) == 0 {
 		j.logger.Debug("No next steps to process",
 			logger.String("step_id", j.workflowStep.ID))
 		return
 	}
 	j.logger.Info("Processing next steps",
 		logger.String("step_id", j.workflowStep.ID),
-		logger.String("next_steps", fmt.Sprintf("%v", j.workflowStep.NextSteps)))
+		logger.String("next_steps", fmt.Sprintf("%v", nextSteps)))
 
-	for _, nextStepID := range j.workflowStep.NextSteps {
+	for _, nextStepID := range nextSteps {
 		// Find the next step in the workflow
 		var nextStep *domain.WorkflowStep
 		for _, step := range j.workflow.Steps {
@@ -165,9 +180,14 @@ func (j *StepExecutionJob) processNextSteps(ctx context.Context) {
 				logger.String("next_step_id", nextStepID))
 			continue
 		}
+		if !j.evaluateStepConditions(nextStep) {
+			j.logger.Debug("Next step conditions not met, skipping",
+				logger.String("next_step_id", nextStepID))
+			continue
+		}
 		// Create step execution for next step
 		nextStepExecution := j.execution.AddStepExecution(nextStep.ID, nextStep.Type)
-		
+
 		// Save step execution
 		if err := j.executionRepo.CreateStepExecution(ctx, nextStepExecution, nil); err != nil {
 			j.logger.Error("Failed to create next step execution",
@@ -176,17 +196,17 @@ func (j *StepExecutionJob) processNextSteps(ctx context.Context) {
 			continue
 		}
 		// Create and submit next step job
-		nextStepJob := NewStepExecutionJob(nextStepExecution, nextStep, j.execution, j.workflow, j.executionRepo, j.eventBus, j.notificationService, j.logger)
-				// For now, we'll log that the next step should be processed
+		nextStepJob := NewStepExecutionJob(nextStepExecution, nextStep, j.execution, j.workflow, j.executionRepo, j.eventBus, j.notificationService, j.digestService, j.logger)
+		// For now, we'll log that the next step should be processed
 		// In a real implementation, you might want to submit this to the worker pool
 		// or handle it based on your specific workflow execution strategy
 		j.logger.Info("Next step ready for processing",
 			logger.String("next_step_id", nextStepID),
 			logger.String("next_step_execution_id", nextStepExecution.ID.String()))
-		
+
 		// You could submit to worker pool here:
 		// if err := workerPool.Submit(nextStepJob); err != nil { ... }
-		
+
 		// For now, let's process it directly (could cause deep recursion in complex workflows)
 		if err := nextStepJob.Process(ctx); err != nil {
 			j.logger.Error("Failed to process next step",
@@ -198,39 +218,39 @@ func (j *StepExecutionJob) processNextSteps(ctx context.Context) {
 
 // Step execution methods (same as in workflow_execution_job.go)
 func (j *StepExecutionJob) executeEmailStep(ctx context.Context) (map[string]interface{}, error) {
-	j.logger.Info("Executing email step", 
+	j.logger.Info("Executing email step",
 		logger.String("step_id", j.workflowStep.ID),
 		logger.String("execution_id", j.execution.ID.String()))
-	
+
 	// Get recipient from context
 	recipient := j.getRecipientFromContext("email")
 	if recipient == "" {
 		return nil, fmt.Errorf("no email recipient found in context")
 	}
-	
+
 	// Get template ID from step config
 	templateID := j.getTemplateFromConfig()
 	if templateID == "" {
 		return nil, fmt.Errorf("no template_id specified in step configuration")
 	}
-	
+
 	// Get priority from config or use default
 	priority := "normal"
 	if p, ok := j.workflowStep.Config["priority"].(string); ok && p != "" {
 		priority = p
 	}
-	
+
 	// Extract subject and body from config or use defaults
 	subject := fmt.Sprintf("Workflow %s notification", j.workflow.Name)
 	if s, ok := j.workflowStep.Config["subject"].(string); ok && s != "" {
 		subject = s
 	}
-	
+
 	body := fmt.Sprintf("You have a notification from workflow %s", j.workflow.Name)
 	if b, ok := j.workflowStep.Config["body"].(string); ok && b != "" {
 		body = b
 	}
-	
+
 	// Prepare variables for template
 	variables := map[string]interface{}{
 		"workflow_id":   j.workflow.ID.String(),
@@ -240,12 +260,12 @@ func (j *StepExecutionJob) executeEmailStep(ctx context.Context) (map[string]int
 		"step_name":     j.workflowStep.Name,
 		"context":       j.execution.Context,
 	}
-	
+
 	// Add custom data if it exists
 	if cd, ok := j.workflowStep.Config["custom_data"]; ok {
 		variables["custom_data"] = cd
 	}
-	
+
 	// Create notification request using the notification service
 	notificationReq := notificationServices.SendNotificationRequest{
 		TenantID:   j.execution.TenantID,
@@ -257,7 +277,7 @@ func (j *StepExecutionJob) executeEmailStep(ctx context.Context) (map[string]int
 		Variables:  variables,
 		Priority:   priority,
 	}
-	
+
 	// Send notification via the notification service
 	response, err := j.notificationService.SendNotification(ctx, notificationReq)
 	if err != nil {
@@ -268,14 +288,14 @@ func (j *StepExecutionJob) executeEmailStep(ctx context.Context) (map[string]int
 			logger.Err(err))
 		return nil, fmt.Errorf("failed to send email notification: %w", err)
 	}
-	
+
 	j.logger.Info("Email notification sent successfully",
 		logger.String("step_id", j.workflowStep.ID),
 		logger.String("execution_id", j.execution.ID.String()),
 		logger.String("notification_id", response.NotificationID),
 		logger.String("recipient", recipient),
 		logger.String("template_id", templateID))
-	
+
 	return map[string]interface{}{
 		"type":            "email",
 		"sent":            true,
@@ -287,39 +307,39 @@ func (j *StepExecutionJob) executeEmailStep(ctx context.Context) (map[string]int
 }
 
 func (j *StepExecutionJob) executeSMSStep(ctx context.Context) (map[string]interface{}, error) {
-	j.logger.Info("Executing SMS step", 
+	j.logger.Info("Executing SMS step",
 		logger.String("step_id", j.workflowStep.ID),
 		logger.String("execution_id", j.execution.ID.String()))
-	
+
 	// Get recipient from context
 	recipient := j.getRecipientFromContext("phone")
 	if recipient == "" {
 		return nil, fmt.Errorf("no phone recipient found in context")
 	}
-	
+
 	// Get template ID from step config
 	templateID := j.getTemplateFromConfig()
 	if templateID == "" {
 		return nil, fmt.Errorf("no template_id specified in step configuration")
 	}
-	
+
 	// Get priority from config or use default
 	priority := "normal"
 	if p, ok := j.workflowStep.Config["priority"].(string); ok && p != "" {
 		priority = p
 	}
-	
+
 	// Extract subject and body from config or use defaults
 	subject := fmt.Sprintf("Workflow %s notification", j.workflow.Name)
 	if s, ok := j.workflowStep.Config["subject"].(string); ok && s != "" {
 		subject = s
 	}
-	
+
 	body := fmt.Sprintf("You have a notification from workflow %s", j.workflow.Name)
 	if b, ok := j.workflowStep.Config["body"].(string); ok && b != "" {
 		body = b
 	}
-	
+
 	// Prepare variables for template
 	variables := map[string]interface{}{
 		"workflow_id":   j.workflow.ID.String(),
@@ -329,12 +349,12 @@ func (j *StepExecutionJob) executeSMSStep(ctx context.Context) (map[string]inter
 		"step_name":     j.workflowStep.Name,
 		"context":       j.execution.Context,
 	}
-	
+
 	// Add custom data if it exists
 	if cd, ok := j.workflowStep.Config["custom_data"]; ok {
 		variables["custom_data"] = cd
 	}
-	
+
 	// Create notification request using the notification service
 	notificationReq := notificationServices.SendNotificationRequest{
 		TenantID:   j.execution.TenantID,
@@ -346,7 +366,7 @@ func (j *StepExecutionJob) executeSMSStep(ctx context.Context) (map[string]inter
 		Variables:  variables,
 		Priority:   priority,
 	}
-	
+
 	// Send notification via the notification service
 	response, err := j.notificationService.SendNotification(ctx, notificationReq)
 	if err != nil {
@@ -357,14 +377,14 @@ func (j *StepExecutionJob) executeSMSStep(ctx context.Context) (map[string]inter
 			logger.Err(err))
 		return nil, fmt.Errorf("failed to send SMS notification: %w", err)
 	}
-	
+
 	j.logger.Info("SMS notification sent successfully",
 		logger.String("step_id", j.workflowStep.ID),
 		logger.String("execution_id", j.execution.ID.String()),
 		logger.String("notification_id", response.NotificationID),
 		logger.String("recipient", recipient),
 		logger.String("template_id", templateID))
-		return map[string]interface{}{
+	return map[string]interface{}{
 		"type":            "sms",
 		"sent":            true,
 		"recipient":       recipient,
@@ -375,39 +395,39 @@ func (j *StepExecutionJob) executeSMSStep(ctx context.Context) (map[string]inter
 }
 
 func (j *StepExecutionJob) executePushStep(ctx context.Context) (map[string]interface{}, error) {
-	j.logger.Info("Executing push step", 
+	j.logger.Info("Executing push step",
 		logger.String("step_id", j.workflowStep.ID),
 		logger.String("execution_id", j.execution.ID.String()))
-	
+
 	// Get device_id from context
 	deviceID := j.getRecipientFromContext("device_id")
 	if deviceID == "" {
 		return nil, fmt.Errorf("no device_id found in context")
 	}
-	
+
 	// Get template ID from step config
 	templateID := j.getTemplateFromConfig()
 	if templateID == "" {
 		return nil, fmt.Errorf("no template_id specified in step configuration")
 	}
-	
+
 	// Get priority from config or use default
 	priority := "normal"
 	if p, ok := j.workflowStep.Config["priority"].(string); ok && p != "" {
 		priority = p
 	}
-	
+
 	// Extract subject and body from config or use defaults
 	subject := fmt.Sprintf("Workflow %s notification", j.workflow.Name)
 	if s, ok := j.workflowStep.Config["subject"].(string); ok && s != "" {
 		subject = s
 	}
-	
+
 	body := fmt.Sprintf("You have a notification from workflow %s", j.workflow.Name)
 	if b, ok := j.workflowStep.Config["body"].(string); ok && b != "" {
 		body = b
 	}
-	
+
 	// Prepare variables for template
 	variables := map[string]interface{}{
 		"workflow_id":   j.workflow.ID.String(),
@@ -417,12 +437,12 @@ func (j *StepExecutionJob) executePushStep(ctx context.Context) (map[string]inte
 		"step_name":     j.workflowStep.Name,
 		"context":       j.execution.Context,
 	}
-	
+
 	// Add custom data if it exists
 	if cd, ok := j.workflowStep.Config["custom_data"]; ok {
 		variables["custom_data"] = cd
 	}
-	
+
 	// Create notification request using the notification service
 	notificationReq := notificationServices.SendNotificationRequest{
 		TenantID:   j.execution.TenantID,
@@ -434,7 +454,7 @@ func (j *StepExecutionJob) executePushStep(ctx context.Context) (map[string]inte
 		Variables:  variables,
 		Priority:   priority,
 	}
-	
+
 	// Send notification via the notification service
 	response, err := j.notificationService.SendNotification(ctx, notificationReq)
 	if err != nil {
@@ -445,14 +465,14 @@ func (j *StepExecutionJob) executePushStep(ctx context.Context) (map[string]inte
 			logger.Err(err))
 		return nil, fmt.Errorf("failed to send push notification: %w", err)
 	}
-	
+
 	j.logger.Info("Push notification sent successfully",
 		logger.String("step_id", j.workflowStep.ID),
 		logger.String("execution_id", j.execution.ID.String()),
 		logger.String("notification_id", response.NotificationID),
 		logger.String("device_id", deviceID),
 		logger.String("template_id", templateID))
-	
+
 	return map[string]interface{}{
 		"type":            "push",
 		"sent":            true,
@@ -464,71 +484,117 @@ func (j *StepExecutionJob) executePushStep(ctx context.Context) (map[string]inte
 }
 
 func (j *StepExecutionJob) executeWebhookStep(ctx context.Context) (map[string]interface{}, error) {
-	j.logger.Info("Executing webhook step", 
+	j.logger.Info("Executing webhook step",
 		logger.String("step_id", j.workflowStep.ID),
 		logger.String("execution_id", j.execution.ID.String()))
-	
+
 	// Get webhook URL from step config
 	webhookURL, _ := j.workflowStep.Config["url"].(string)
-	
+
 	return map[string]interface{}{
 		"type": "webhook",
 		"sent": true,
-		"url": webhookURL,
+		"url":  webhookURL,
 	}, nil
 }
 
+// executeDigestStep adds the execution's event to the user's digest for the step
 func (j *StepExecutionJob) executeDigestStep(ctx context.Context) (map[string]interface{}, error) {
-	j.logger.Info("Executing digest step", 
+	j.logger.Info("Executing digest step",
 		logger.String("step_id", j.workflowStep.ID),
 		logger.String("execution_id", j.execution.ID.String()))
-	
-	return map[string]interface{}{
-		"type": "digest",
-		"scheduled": true,
-	}, nil
+
+	return addToDigest(ctx, j.digestService, j.workflow, j.execution, j.workflowStep, j.logger)
 }
 
 func (j *StepExecutionJob) executeConditionStep(ctx context.Context) (map[string]interface{}, error) {
-	j.logger.Info("Executing condition step", 
+	j.logger.Info("Executing condition step",
 		logger.String("step_id", j.workflowStep.ID),
 		logger.String("execution_id", j.execution.ID.String()))
-	
+
+	evaluator, err := NewConditionEvaluator(j.execution)
+	if err != nil {
+		return nil, err
+	}
+
+	met, taken, skipped, err := conditionBranch(evaluator, j.workflowStep)
+	if err != nil {
+		return nil, err
+	}
+
+	j.branched = true
+	j.branchSteps = taken
+
+	j.logger.Info("Condition step evaluated",
+		logger.String("step_id", j.workflowStep.ID),
+		logger.String("execution_id", j.execution.ID.String()),
+		logger.Bool("result", met))
+
 	return map[string]interface{}{
-		"type": "condition",
-		"evaluated": true,
+		"type":          "condition",
+		"evaluated":     true,
+		"result":        met,
+		"next_steps":    taken,
+		"skipped_steps": skipped,
 	}, nil
 }
 
+// evaluateStepConditions evaluates if step conditions are met
+func (j *StepExecutionJob) evaluateStepConditions(step *domain.WorkflowStep) bool {
+	if len(step.Conditions) == 0 {
+		return true
+	}
+
+	evaluator, err := NewConditionEvaluator(j.execution)
+	if err != nil {
+		j.logger.Error("Failed to build condition evaluator",
+			logger.String("step_id", step.ID),
+			logger.String("execution_id", j.execution.ID.String()),
+			logger.Err(err))
+		return false
+	}
+
+	met, err := evaluator.EvaluateAll(step.Conditions)
+	if err != nil {
+		j.logger.Error("Failed to evaluate step conditions",
+			logger.String("step_id", step.ID),
+			logger.String("execution_id", j.execution.ID.String()),
+			logger.Err(err))
+		return false
+	}
+
+	return met
+}
+
 // Helper methods
 func (j *StepExecutionJob) getRecipientFromContext(field string) string {
 	// Check if execution exists
 	if j.execution == nil {
-		j.logger.Warn("Execution is nil", 
+		j.logger.Warn("Execution is nil",
 			logger.String("step_id", j.workflowStep.ID))
 		return ""
 	}
 
 	// Check if subscriber map exists or is empty
 	if j.execution.Context.Subscriber == nil || len(j.execution.Context.Subscriber) == 0 {
-		j.logger.Warn("Subscriber map is nil or empty in execution context", 
+		j.logger.Warn("Subscriber map is nil or empty in execution context",
 			logger.String("step_id", j.workflowStep.ID),
 			logger.String("execution_id", j.execution.ID.String()))
 		return ""
 	}
-	
+
 	// Try to extract the specified field
 	if subscriber, ok := j.execution.Context.Subscriber[field].(string); ok {
 		if subscriber == "" {
-			j.logger.Warn("Empty recipient value found in context", 
+			j.logger.Warn("Empty recipient value found in context",
 				logger.String("field", field),
 				logger.String("step_id", j.workflowStep.ID),
 				logger.String("execution_id", j.execution.ID.String()))
 		}
 		return subscriber
 	}
-	
-	j.logger.Warn("Recipient field not found in context", 
+
+	j.logger.Warn("Recipient field not found in context",
 		logger.String("field", field),
 		logger.String("step_id", j.workflowStep.ID),
 		logger.String("execution_id", j.execution.ID.String()))
@@ -538,27 +604,27 @@ func (j *StepExecutionJob) getRecipientFromContext(field string) string {
 func (j *StepExecutionJob) getTemplateFromConfig() string {
 	// Check if config exists
 	if j.workflowStep.Config == nil {
-		j.logger.Warn("Step config is nil", 
+		j.logger.Warn("Step config is nil",
 			logger.String("step_id", j.workflowStep.ID),
 			logger.String("step_type", string(j.workflowStep.Type)))
 		return ""
 	}
-	
+
 	// Extract template ID from config
 	templateID, ok := j.workflowStep.Config["template_id"].(string)
 	if !ok {
-		j.logger.Warn("Template ID not found in step config or not a string", 
+		j.logger.Warn("Template ID not found in step config or not a string",
 			logger.String("step_id", j.workflowStep.ID),
 			logger.String("step_type", string(j.workflowStep.Type)))
 		return ""
 	}
-	
+
 	if templateID == "" {
-		j.logger.Warn("Empty template ID in step config", 
+		j.logger.Warn("Empty template ID in step config",
 			logger.String("step_id", j.workflowStep.ID),
 			logger.String("step_type", string(j.workflowStep.Type)))
 	}
-	
+
 	return templateID
 }
 
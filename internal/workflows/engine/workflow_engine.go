@@ -21,6 +21,7 @@ type WorkflowEngine struct {
 	logger              logger.Logger
 	eventBus            events.EventBus
 	notificationService *notificationServices.NotificationService
+	digestService       *notificationServices.DigestService
 	stopCh              chan struct{}
 	pollInterval        time.Duration
 }
@@ -33,6 +34,7 @@ func NewWorkflowEngine(
 	logger logger.Logger,
 	eventBus events.EventBus,
 	notificationService *notificationServices.NotificationService,
+	digestService *notificationServices.DigestService,
 	pollInterval time.Duration,
 ) *WorkflowEngine {
 	return &WorkflowEngine{
@@ -42,6 +44,7 @@ func NewWorkflowEngine(
 		logger:              logger,
 		eventBus:            eventBus,
 		notificationService: notificationService,
+		digestService:       digestService,
 		pollInterval:        pollInterval,
 		stopCh:              make(chan struct{}),
 	}
@@ -50,10 +53,10 @@ func NewWorkflowEngine(
 // Start begins the workflow engine polling loop
 func (e *WorkflowEngine) Start(ctx context.Context) error {
 	e.logger.Info("Starting workflow engine")
-	
+
 	go e.pollForPendingExecutions(ctx)
 	go e.pollForDelayedSteps(ctx)
-	
+
 	return nil
 }
 
@@ -77,22 +80,22 @@ func (e *WorkflowEngine) TriggerWorkflow(ctx context.Context, workflowID string,
 
 	// Create new execution
 	execution := domain.NewWorkflowExecution(workflow.ID, workflow.TenantID, triggerID, payload, execCtx)
-		// Save execution
+	// Save execution
 	err = e.executionRepo.CreateExecution(ctx, execution, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create execution: %w", err)
 	}
-	
+
 	savedExecution := execution
 
 	// Submit execution job to worker pool
-	job := NewWorkflowExecutionJob(savedExecution, workflow, e.workflowRepo, e.executionRepo, e.logger)
+	job := NewWorkflowExecutionJob(savedExecution, workflow, e.workflowRepo, e.executionRepo, e.digestService, e.logger)
 	if err := e.workerPool.Submit(job); err != nil {
 		e.logger.Error("Failed to submit workflow execution job",
 			logger.String("execution_id", savedExecution.ID.String()),
 			logger.String("workflow_id", workflowID),
 			logger.Err(err))
-				// Mark execution as failed
+		// Mark execution as failed
 		savedExecution.Fail("Failed to submit execution job: " + err.Error())
 		e.executionRepo.UpdateExecution(ctx, savedExecution, nil)
 		return nil, fmt.Errorf("failed to submit execution job: %w", err)
@@ -112,7 +115,7 @@ func (e *WorkflowEngine) pollForPendingExecutions(ctx context.Context) {
 	defer ticker.Stop()
 
 	e.logger.Info("Started polling for pending workflow executions")
-	
+
 	for {
 		select {
 		case <-ticker.C:
@@ -135,7 +138,7 @@ func (e *WorkflowEngine) pollForDelayedSteps(ctx context.Context) {
 	defer ticker.Stop()
 
 	e.logger.Info("Started polling for delayed workflow steps")
-	
+
 	for {
 		select {
 		case <-ticker.C:
@@ -177,14 +180,14 @@ func (e *WorkflowEngine) processPendingExecutions(ctx context.Context) error {
 		}
 
 		// Submit execution job to worker pool
-		job := NewWorkflowExecutionJob(execution, workflow, e.workflowRepo, e.executionRepo, e.logger)
+		job := NewWorkflowExecutionJob(execution, workflow, e.workflowRepo, e.executionRepo, e.digestService, e.logger)
 		if err := e.workerPool.Submit(job); err != nil {
 			e.logger.Error("Failed to submit pending execution job",
 				logger.String("execution_id", execution.ID.String()),
 				logger.Err(err))
 		}
 	}
-	
+
 	return nil
 }
 
@@ -203,7 +206,7 @@ func (e *WorkflowEngine) processDelayedSteps(ctx context.Context) error {
 	for _, stepExecution := range delayedSteps {
 		// Get the execution and workflow
 		executionID := stepExecution.ExecutionID.String()
-		
+
 		// First get the execution to get the tenant ID
 		executions, err := e.executionRepo.ListExecutions(ctx, "", repos.ExecutionFilters{
 			Limit: 1,
@@ -215,7 +218,7 @@ func (e *WorkflowEngine) processDelayedSteps(ctx context.Context) error {
 				logger.Err(err))
 			continue
 		}
-		
+
 		tenantID := executions[0].TenantID
 		execution, err := e.executionRepo.GetExecutionByID(ctx, tenantID, executionID)
 		if err != nil {
@@ -249,8 +252,8 @@ func (e *WorkflowEngine) processDelayedSteps(ctx context.Context) error {
 				logger.String("step_id", stepExecution.StepID),
 				logger.String("workflow_id", workflow.ID.String()))
 			continue
-		}		// Submit step execution job to worker pool
-		job := NewStepExecutionJob(stepExecution, workflowStep, execution, workflow, e.executionRepo, e.eventBus, e.notificationService, e.logger)
+		} // Submit step execution job to worker pool
+		job := NewStepExecutionJob(stepExecution, workflowStep, execution, workflow, e.executionRepo, e.eventBus, e.notificationService, e.digestService, e.logger)
 		if err := e.workerPool.Submit(job); err != nil {
 			e.logger.Error("Failed to submit delayed step execution job",
 				logger.String("step_execution_id", stepExecution.ID.String()),
@@ -260,7 +263,6 @@ func (e *WorkflowEngine) processDelayedSteps(ctx context.Context) error {
 	return nil
 }
 
-
 // SetPollInterval sets the polling interval for the engine
 func (e *WorkflowEngine) SetPollInterval(interval time.Duration) {
 	e.pollInterval = interval
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	notificationServices "getnoti.com/internal/notifications/services"
 	"getnoti.com/internal/workflows/domain"
 	"getnoti.com/internal/workflows/repos"
 	"getnoti.com/pkg/logger"
@@ -18,15 +19,18 @@ type WorkflowExecutionJob struct {
 	workflow      *domain.Workflow
 	workflowRepo  repos.WorkflowRepository
 	executionRepo repos.ExecutionRepository
+	digestService *notificationServices.DigestService
 	logger        logger.Logger
+	skippedSteps  map[string]bool
 }
 
 // NewWorkflowExecutionJob creates a new workflow execution job
 func NewWorkflowExecutionJob(
 	execution *domain.WorkflowExecution,
-	workflow *domain.Workflow, 
+	workflow *domain.Workflow,
 	workflowRepo repos.WorkflowRepository,
 	executionRepo repos.ExecutionRepository,
+	digestService *notificationServices.DigestService,
 	logger logger.Logger,
 ) workerpool.Job {
 	return &WorkflowExecutionJob{
@@ -34,7 +38,9 @@ func NewWorkflowExecutionJob(
 		workflow:      workflow,
 		workflowRepo:  workflowRepo,
 		executionRepo: executionRepo,
+		digestService: digestService,
 		logger:        logger,
+		skippedSteps:  make(map[string]bool),
 	}
 }
 
@@ -47,7 +53,7 @@ func (j *WorkflowExecutionJob) Process(ctx context.Context) error {
 
 	// Start the execution
 	j.execution.Start()
-	
+
 	// Update execution status to running
 	if err := j.executionRepo.UpdateExecution(ctx, j.execution, nil); err != nil {
 		j.logger.Error("Failed to update execution status to running",
@@ -93,7 +99,7 @@ func (j *WorkflowExecutionJob) processWorkflowSteps(ctx context.Context) error {
 	// Sort steps by position
 	steps := make([]domain.WorkflowStep, len(j.workflow.Steps))
 	copy(steps, j.workflow.Steps)
-	
+
 	// Simple bubble sort by position (could use sort.Slice for better performance)
 	for i := 0; i < len(steps)-1; i++ {
 		for k := 0; k < len(steps)-i-1; k++ {
@@ -113,6 +119,15 @@ func (j *WorkflowExecutionJob) processWorkflowSteps(ctx context.Context) error {
 			continue
 		}
 
+		// Skip steps on a branch that a condition step did not take
+		if j.skippedSteps[step.ID] {
+			j.logger.Debug("Skipping step on untaken branch",
+				logger.String("step_id", step.ID),
+				logger.String("step_name", step.Name),
+				logger.String("execution_id", j.execution.ID.String()))
+			continue
+		}
+
 		// Check step conditions
 		if !j.evaluateStepConditions(step) {
 			j.logger.Debug("Step conditions not met, skipping",
@@ -135,7 +150,7 @@ func (j *WorkflowExecutionJob) processWorkflowSteps(ctx context.Context) error {
 func (j *WorkflowExecutionJob) processStep(ctx context.Context, step *domain.WorkflowStep) error {
 	// Create step execution
 	stepExecution := j.execution.AddStepExecution(step.ID, step.Type)
-	
+
 	// Save step execution
 	err := j.executionRepo.CreateStepExecution(ctx, stepExecution, nil)
 	if err != nil {
@@ -167,10 +182,10 @@ func (j *WorkflowExecutionJob) processDelayStep(ctx context.Context, step *domai
 
 	// Calculate delay time
 	delayTime := time.Now().Add(time.Duration(delayMinutes) * time.Minute)
-	
+
 	// Set delay on step execution
 	stepExecution.SetDelayUntil(delayTime)
-	
+
 	j.logger.Info("Scheduling delayed step execution",
 		logger.String("step_id", step.ID),
 		logger.String("execution_id", j.execution.ID.String()),
@@ -184,7 +199,7 @@ func (j *WorkflowExecutionJob) processDelayStep(ctx context.Context, step *domai
 // executeStep executes a step based on its type
 func (j *WorkflowExecutionJob) executeStep(ctx context.Context, step *domain.WorkflowStep, stepExecution *domain.StepExecution) error {
 	stepExecution.Start()
-	
+
 	// Update step execution to running
 	if err := j.executionRepo.UpdateStepExecution(ctx, stepExecution, nil); err != nil {
 		j.logger.Error("Failed to update step execution status to running",
@@ -228,6 +243,9 @@ func (j *WorkflowExecutionJob) executeStep(ctx context.Context, step *domain.Wor
 			logger.String("step_execution_id", stepExecution.ID.String()))
 	}
 
+	// Make the step result visible to conditions on later steps
+	j.execution.SyncStepExecution(stepExecution)
+
 	// Save step execution final state
 	if err := j.executionRepo.UpdateStepExecution(ctx, stepExecution, nil); err != nil {
 		j.logger.Error("Failed to update step execution final status",
@@ -244,29 +262,26 @@ func (j *WorkflowExecutionJob) evaluateStepConditions(step domain.WorkflowStep)
 		return true // No conditions means always execute
 	}
 
-	// For now, implement simple AND logic for all conditions
-	for _, condition := range step.Conditions {
-		if !j.evaluateCondition(condition) {
-			return false
-		}
+	evaluator, err := NewConditionEvaluator(j.execution)
+	if err != nil {
+		j.logger.Error("Failed to build condition evaluator",
+			logger.String("step_id", step.ID),
+			logger.String("execution_id", j.execution.ID.String()),
+			logger.Err(err))
+		return false
 	}
-	
-	return true
-}
 
-// evaluateCondition evaluates a single condition
-func (j *WorkflowExecutionJob) evaluateCondition(condition domain.Condition) bool {
-	// This is a simplified implementation
-	// In a real system, you'd want more sophisticated condition evaluation
-	// that can access execution context, previous step results, etc.
-	
-	j.logger.Debug("Evaluating condition",
-		logger.String("field", condition.Field),
-		logger.String("operator", condition.Operator),
-		logger.Any("value", condition.Value))
-
-	// For now, always return true (implement your condition logic here)
-	return true
+	// Top-level conditions are combined with AND; use groups for OR
+	met, err := evaluator.EvaluateAll(step.Conditions)
+	if err != nil {
+		j.logger.Error("Failed to evaluate step conditions",
+			logger.String("step_id", step.ID),
+			logger.String("execution_id", j.execution.ID.String()),
+			logger.Err(err))
+		return false
+	}
+
+	return met
 }
 
 // Step execution methods (placeholder implementations)
@@ -274,8 +289,8 @@ func (j *WorkflowExecutionJob) executeEmailStep(ctx context.Context, step *domai
 	j.logger.Info("Executing email step", logger.String("step_id", step.ID))
 	// TODO: Integrate with your notification system
 	return map[string]interface{}{
-		"type": "email",
-		"sent": true,
+		"type":      "email",
+		"sent":      true,
 		"recipient": "user@example.com",
 	}, nil
 }
@@ -284,8 +299,8 @@ func (j *WorkflowExecutionJob) executeSMSStep(ctx context.Context, step *domain.
 	j.logger.Info("Executing SMS step", logger.String("step_id", step.ID))
 	// TODO: Integrate with your SMS provider
 	return map[string]interface{}{
-		"type": "sms",
-		"sent": true,
+		"type":      "sms",
+		"sent":      true,
 		"recipient": "+1234567890",
 	}, nil
 }
@@ -294,8 +309,8 @@ func (j *WorkflowExecutionJob) executePushStep(ctx context.Context, step *domain
 	j.logger.Info("Executing push step", logger.String("step_id", step.ID))
 	// TODO: Integrate with your push notification system
 	return map[string]interface{}{
-		"type": "push",
-		"sent": true,
+		"type":      "push",
+		"sent":      true,
 		"device_id": "device123",
 	}, nil
 }
@@ -306,24 +321,75 @@ func (j *WorkflowExecutionJob) executeWebhookStep(ctx context.Context, step *dom
 	return map[string]interface{}{
 		"type": "webhook",
 		"sent": true,
-		"url": "https://example.com/webhook",
+		"url":  "https://example.com/webhook",
 	}, nil
 }
 
+// executeDigestStep adds the execution's event to the user's digest for the step
 func (j *WorkflowExecutionJob) executeDigestStep(ctx context.Context, step *domain.WorkflowStep) (map[string]interface{}, error) {
 	j.logger.Info("Executing digest step", logger.String("step_id", step.ID))
-	// TODO: Implement digest functionality
-	return map[string]interface{}{
-		"type": "digest",
-		"scheduled": true,
-	}, nil
+	return addToDigest(ctx, j.digestService, j.workflow, j.execution, step, j.logger)
 }
 
 func (j *WorkflowExecutionJob) executeConditionStep(ctx context.Context, step *domain.WorkflowStep) (map[string]interface{}, error) {
 	j.logger.Info("Executing condition step", logger.String("step_id", step.ID))
-	// TODO: Implement conditional branching
+
+	evaluator, err := NewConditionEvaluator(j.execution)
+	if err != nil {
+		return nil, err
+	}
+
+	met, taken, skipped, err := conditionBranch(evaluator, step)
+	if err != nil {
+		return nil, err
+	}
+
+	// The whole untaken branch is skipped, down to the steps it shares with the taken one
+	takenSteps := branchSteps(j.workflow.Steps, taken)
+	skippedSteps := branchSteps(j.workflow.Steps, skipped)
+	skipped = skipped[:0:0]
+	for _, candidate := range j.workflow.Steps {
+		if skippedSteps[candidate.ID] && !takenSteps[candidate.ID] && candidate.ID != step.ID {
+			j.skippedSteps[candidate.ID] = true
+			skipped = append(skipped, candidate.ID)
+		}
+	}
+	// Steps on the taken branch may have been skipped by an earlier condition
+	for stepID := range takenSteps {
+		delete(j.skippedSteps, stepID)
+	}
+
+	j.logger.Info("Condition step evaluated",
+		logger.String("step_id", step.ID),
+		logger.String("execution_id", j.execution.ID.String()),
+		logger.Bool("result", met))
+
 	return map[string]interface{}{
-		"type": "condition",
-		"evaluated": true,
+		"type":          "condition",
+		"evaluated":     true,
+		"result":        met,
+		"next_steps":    taken,
+		"skipped_steps": skipped,
 	}, nil
 }
+
+// branchSteps returns the given steps and every step reachable from them through NextSteps
+func branchSteps(steps []domain.WorkflowStep, roots []string) map[string]bool {
+	next := make(map[string][]string, len(steps))
+	for _, step := range steps {
+		next[step.ID] = step.NextSteps
+	}
+
+	reached := make(map[string]bool)
+	pending := append([]string(nil), roots...)
+	for len(pending) > 0 {
+		stepID := pending[0]
+		pending = pending[1:]
+		if reached[stepID] {
+			continue
+		}
+		reached[stepID] = true
+		pending = append(pending, next[stepID]...)
+	}
+	return reached
+}
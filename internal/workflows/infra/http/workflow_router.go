@@ -0,0 +1,424 @@
+package workflowroutes
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"getnoti.com/internal/container"
+	"getnoti.com/internal/shared/handler"
+	"getnoti.com/internal/shared/middleware"
+	"getnoti.com/internal/workflows/domain"
+	"getnoti.com/internal/workflows/dtos"
+	"getnoti.com/internal/workflows/engine"
+	"getnoti.com/internal/workflows/services"
+	"getnoti.com/pkg/db"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+var (
+	errWorkflowIDRequired  = errors.New("workflow ID is required")
+	errExecutionIDRequired = errors.New("execution ID is required")
+	errNameRequired        = errors.New("name is required")
+	errTriggerRequired     = errors.New("trigger identifier is required")
+	errStepsRequired       = errors.New("at least one step is required")
+)
+
+type Handlers struct {
+	BaseHandler      *handler.BaseHandler
+	ServiceContainer *container.ServiceContainer
+}
+
+func NewHandlers(baseHandler *handler.BaseHandler, serviceContainer *container.ServiceContainer) *Handlers {
+	return &Handlers{
+		BaseHandler:      baseHandler,
+		ServiceContainer: serviceContainer,
+	}
+}
+
+// CreateWorkflow handles HTTP requests to create workflows
+func (h *Handlers) CreateWorkflow(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	var req dtos.CreateWorkflowRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if err := validateWorkflowDefinition(req.Name, req.Trigger, req.Steps); err != nil {
+		h.BaseHandler.HandleError(w, "Invalid workflow", err, http.StatusBadRequest)
+		return
+	}
+
+	workflow, err := h.ServiceContainer.GetWorkflowService().CreateWorkflow(r.Context(), tenantID, &req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to create workflow", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSONStatus(w, http.StatusCreated, workflow)
+}
+
+// GetWorkflows handles HTTP requests to list workflows for a tenant
+func (h *Handlers) GetWorkflows(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	limit, offset := parsePagination(r)
+
+	req := &dtos.ListWorkflowsRequest{
+		Status: r.URL.Query().Get("status"),
+		Search: r.URL.Query().Get("search"),
+		Limit:  limit,
+		Offset: offset,
+	}
+
+	workflows, err := h.ServiceContainer.GetWorkflowService().ListWorkflows(r.Context(), tenantID, req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to list workflows", err, http.StatusInternalServerError)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, workflows)
+}
+
+// GetWorkflow handles HTTP requests to get a specific workflow
+func (h *Handlers) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	workflowID := chi.URLParam(r, "id")
+
+	if workflowID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errWorkflowIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	workflow, err := h.ServiceContainer.GetWorkflowService().GetWorkflow(r.Context(), tenantID, workflowID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get workflow", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, workflow)
+}
+
+// UpdateWorkflow handles HTTP requests to update workflows
+func (h *Handlers) UpdateWorkflow(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	workflowID := chi.URLParam(r, "id")
+
+	if workflowID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errWorkflowIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	var req dtos.UpdateWorkflowRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if err := validateWorkflowDefinition(req.Name, req.Trigger, req.Steps); err != nil {
+		h.BaseHandler.HandleError(w, "Invalid workflow", err, http.StatusBadRequest)
+		return
+	}
+
+	workflow, err := h.ServiceContainer.GetWorkflowService().UpdateWorkflow(r.Context(), tenantID, workflowID, &req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to update workflow", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, workflow)
+}
+
+// DeleteWorkflow handles HTTP requests to delete workflows
+func (h *Handlers) DeleteWorkflow(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	workflowID := chi.URLParam(r, "id")
+
+	if workflowID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errWorkflowIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.ServiceContainer.GetWorkflowService().DeleteWorkflow(r.Context(), tenantID, workflowID); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to delete workflow", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, map[string]string{
+		"message": "Workflow deleted successfully",
+	})
+}
+
+// ActivateWorkflow handles HTTP requests to activate workflows
+func (h *Handlers) ActivateWorkflow(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	workflowID := chi.URLParam(r, "id")
+
+	if workflowID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errWorkflowIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.ServiceContainer.GetWorkflowService().ActivateWorkflow(r.Context(), tenantID, workflowID); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to activate workflow", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, map[string]string{
+		"message": "Workflow activated successfully",
+	})
+}
+
+// PauseWorkflow handles HTTP requests to pause workflows
+func (h *Handlers) PauseWorkflow(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	workflowID := chi.URLParam(r, "id")
+
+	if workflowID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errWorkflowIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.ServiceContainer.GetWorkflowService().PauseWorkflow(r.Context(), tenantID, workflowID); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to pause workflow", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, map[string]string{
+		"message": "Workflow paused successfully",
+	})
+}
+
+// TriggerWorkflow handles HTTP requests to trigger a specific workflow
+func (h *Handlers) TriggerWorkflow(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	workflowID := chi.URLParam(r, "id")
+
+	if workflowID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errWorkflowIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	var req dtos.TriggerWorkflowRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	execution, err := h.ServiceContainer.GetWorkflowService().TriggerWorkflowByID(r.Context(), tenantID, workflowID, &req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to trigger workflow", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSONStatus(w, http.StatusAccepted, execution)
+}
+
+// TriggerByIdentifier handles HTTP requests to trigger a workflow by its trigger identifier
+func (h *Handlers) TriggerByIdentifier(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+
+	var req dtos.TriggerWorkflowRequest
+	if !h.BaseHandler.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if req.TriggerIdentifier == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errTriggerRequired, http.StatusBadRequest)
+		return
+	}
+
+	execution, err := h.ServiceContainer.GetWorkflowService().TriggerWorkflow(r.Context(), tenantID, &req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to trigger workflow", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSONStatus(w, http.StatusAccepted, execution)
+}
+
+// GetExecutions handles HTTP requests to list workflow executions for a tenant
+func (h *Handlers) GetExecutions(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	limit, offset := parsePagination(r)
+
+	req := &dtos.ListExecutionsRequest{
+		WorkflowID: r.URL.Query().Get("workflow_id"),
+		Status:     r.URL.Query().Get("status"),
+		TriggerID:  r.URL.Query().Get("trigger_id"),
+		Limit:      limit,
+		Offset:     offset,
+	}
+
+	executions, err := h.ServiceContainer.GetExecutionService().ListExecutions(r.Context(), tenantID, req)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to list executions", err, http.StatusInternalServerError)
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, executions)
+}
+
+// GetExecution handles HTTP requests to get a specific execution
+func (h *Handlers) GetExecution(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	executionID := chi.URLParam(r, "id")
+
+	if executionID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errExecutionIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	execution, err := h.ServiceContainer.GetExecutionService().GetExecution(r.Context(), tenantID, executionID)
+	if err != nil {
+		h.BaseHandler.HandleError(w, "Failed to get execution", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, execution)
+}
+
+// CancelExecution handles HTTP requests to cancel an execution
+func (h *Handlers) CancelExecution(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	executionID := chi.URLParam(r, "id")
+
+	if executionID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errExecutionIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.ServiceContainer.GetExecutionService().CancelExecution(r.Context(), tenantID, executionID); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to cancel execution", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, map[string]string{
+		"message": "Execution cancelled successfully",
+	})
+}
+
+// RetryExecution handles HTTP requests to retry a failed or cancelled execution
+func (h *Handlers) RetryExecution(w http.ResponseWriter, r *http.Request) {
+	tenantID := r.Context().Value(middleware.TenantIDKey).(string)
+	executionID := chi.URLParam(r, "id")
+
+	if executionID == "" {
+		h.BaseHandler.HandleError(w, "Invalid request", errExecutionIDRequired, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.ServiceContainer.GetExecutionService().RetryExecution(r.Context(), tenantID, executionID); err != nil {
+		h.BaseHandler.HandleError(w, "Failed to retry execution", err, workflowErrorStatus(err))
+		return
+	}
+
+	h.BaseHandler.RespondWithJSON(w, map[string]string{
+		"message": "Execution queued for retry",
+	})
+}
+
+// validateWorkflowDefinition checks the fields required to create or update a workflow
+func validateWorkflowDefinition(name string, trigger dtos.WorkflowTriggerDTO, steps []dtos.WorkflowStepDTO) error {
+	if name == "" {
+		return errNameRequired
+	}
+	if trigger.Identifier == "" {
+		return errTriggerRequired
+	}
+	if len(steps) == 0 {
+		return errStepsRequired
+	}
+	for _, step := range steps {
+		domainStep := domain.WorkflowStep{
+			Type:       domain.StepType(step.Type),
+			Name:       step.Name,
+			Config:     step.Config,
+			Conditions: dtos.ToDomainConditions(step.Conditions),
+		}
+		if err := engine.ValidateStepConditions(&domainStep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parsePagination reads limit and offset query parameters
+func parsePagination(r *http.Request) (int, int) {
+	limit := defaultPageLimit
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	offset := 0
+	if v, err := strconv.Atoi(r.URL.Query().Get("offset")); err == nil && v > 0 {
+		offset = v
+	}
+
+	return limit, offset
+}
+
+// workflowErrorStatus maps service errors to HTTP status codes
+func workflowErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrWorkflowNotFound),
+		errors.Is(err, domain.ErrExecutionNotFound),
+		errors.Is(err, domain.ErrTriggerNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrWorkflowAlreadyExists),
+		errors.Is(err, domain.ErrWorkflowInactive),
+		errors.Is(err, domain.ErrExecutionCompleted),
+		errors.Is(err, domain.ErrExecutionFailed),
+		errors.Is(err, services.ErrExecutionNotRetryable):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrWorkflowNoSteps),
+		errors.Is(err, domain.ErrStepValidation),
+		errors.Is(err, domain.ErrInvalidPayload):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// NewRouter sets up the router with all workflow routes
+func NewRouter(serviceContainer *container.ServiceContainer, dbManager *db.Manager) *chi.Mux {
+	b := handler.NewBaseHandler(dbManager)
+	h := NewHandlers(b, serviceContainer)
+
+	r := chi.NewRouter()
+
+	// Set up routes
+	r.Post("/", h.CreateWorkflow)
+	r.Get("/", h.GetWorkflows)
+	r.Post("/trigger", h.TriggerByIdentifier)
+	r.Get("/{id}", h.GetWorkflow)
+	r.Put("/{id}", h.UpdateWorkflow)
+	r.Delete("/{id}", h.DeleteWorkflow)
+	r.Post("/{id}/activate", h.ActivateWorkflow)
+	r.Post("/{id}/pause", h.PauseWorkflow)
+	r.Post("/{id}/trigger", h.TriggerWorkflow)
+
+	return r
+}
+
+// NewExecutionRouter sets up the router with all workflow execution routes
+func NewExecutionRouter(serviceContainer *container.ServiceContainer, dbManager *db.Manager) *chi.Mux {
+	b := handler.NewBaseHandler(dbManager)
+	h := NewHandlers(b, serviceContainer)
+
+	r := chi.NewRouter()
+
+	// Set up routes
+	r.Get("/", h.GetExecutions)
+	r.Get("/{id}", h.GetExecution)
+	r.Post("/{id}/cancel", h.CancelExecution)
+	r.Post("/{id}/retry", h.RetryExecution)
+
+	return r
+}
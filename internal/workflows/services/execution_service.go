@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"getnoti.com/internal/workflows/domain"
 	"getnoti.com/internal/workflows/dtos"
@@ -10,6 +13,9 @@ import (
 	"getnoti.com/pkg/logger"
 )
 
+// ErrExecutionNotRetryable is returned when retrying an execution that has not failed or been cancelled
+var ErrExecutionNotRetryable = errors.New("only failed or cancelled executions can be retried")
+
 type ExecutionService struct {
 	executionRepo repos.ExecutionRepository
 	logger        logger.Logger
@@ -26,7 +32,7 @@ func NewExecutionService(
 }
 
 func (s *ExecutionService) GetExecution(ctx context.Context, tenantID, executionID string) (*dtos.WorkflowExecutionResponse, error) {
-	execution, err := s.executionRepo.GetExecutionByID(ctx, tenantID, executionID)
+	execution, err := s.getExecution(ctx, tenantID, executionID)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +78,7 @@ func (s *ExecutionService) CancelExecution(ctx context.Context, tenantID, execut
 		logger.String("tenant_id", tenantID),
 		logger.String("execution_id", executionID))
 
-	execution, err := s.executionRepo.GetExecutionByID(ctx, tenantID, executionID)
+	execution, err := s.getExecution(ctx, tenantID, executionID)
 	if err != nil {
 		return err
 	}
@@ -84,8 +90,14 @@ func (s *ExecutionService) CancelExecution(ctx context.Context, tenantID, execut
 	if execution.Status == domain.ExecutionStatusFailed {
 		return domain.ErrExecutionFailed
 	}
+
+	if execution.Status == domain.ExecutionStatusCancelled {
+		return nil
+	}
 	// Update execution status to cancelled
+	now := time.Now()
 	execution.Status = domain.ExecutionStatusCancelled
+	execution.CompletedAt = &now
 	if err := s.executionRepo.UpdateExecution(ctx, execution, nil); err != nil {
 		s.logger.Error("Failed to cancel execution",
 			logger.String("tenant_id", tenantID),
@@ -106,18 +118,19 @@ func (s *ExecutionService) RetryExecution(ctx context.Context, tenantID, executi
 		logger.String("tenant_id", tenantID),
 		logger.String("execution_id", executionID))
 
-	execution, err := s.executionRepo.GetExecutionByID(ctx, tenantID, executionID)
+	execution, err := s.getExecution(ctx, tenantID, executionID)
 	if err != nil {
 		return err
 	}
 
-	if execution.Status != domain.ExecutionStatusFailed {
-		return fmt.Errorf("only failed executions can be retried")
+	if execution.Status != domain.ExecutionStatusFailed && execution.Status != domain.ExecutionStatusCancelled {
+		return ErrExecutionNotRetryable
 	}
 
 	// Reset execution status
 	execution.Status = domain.ExecutionStatusPending
 	execution.ErrorMessage = ""
+	execution.CompletedAt = nil
 
 	// Reset failed step executions
 	for i := range execution.Steps {
@@ -126,7 +139,7 @@ func (s *ExecutionService) RetryExecution(ctx context.Context, tenantID, executi
 			execution.Steps[i].ErrorMessage = ""
 			execution.Steps[i].RetryCount++
 		}
-	}	
+	}
 	if err := s.executionRepo.UpdateExecution(ctx, execution, nil); err != nil {
 		s.logger.Error("Failed to retry execution",
 			logger.String("tenant_id", tenantID),
@@ -141,3 +154,15 @@ func (s *ExecutionService) RetryExecution(ctx context.Context, tenantID, executi
 
 	return nil
 }
+
+// getExecution loads an execution for the tenant
+func (s *ExecutionService) getExecution(ctx context.Context, tenantID, executionID string) (*domain.WorkflowExecution, error) {
+	execution, err := s.executionRepo.GetExecutionByID(ctx, tenantID, executionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrExecutionNotFound
+		}
+		return nil, err
+	}
+	return execution, nil
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
 
 	"getnoti.com/internal/workflows/domain"
 	"getnoti.com/internal/workflows/dtos"
@@ -11,20 +13,28 @@ import (
 	"getnoti.com/pkg/logger"
 )
 
+// WorkflowExecutor starts executions for triggered workflows
+type WorkflowExecutor interface {
+	TriggerWorkflow(ctx context.Context, workflowID string, triggerID string, payload []byte, execCtx domain.ExecutionContext) (*domain.WorkflowExecution, error)
+}
+
 type WorkflowService struct {
 	workflowRepo  repos.WorkflowRepository
 	executionRepo repos.ExecutionRepository
+	executor      WorkflowExecutor
 	logger        logger.Logger
 }
 
 func NewWorkflowService(
 	workflowRepo repos.WorkflowRepository,
 	executionRepo repos.ExecutionRepository,
+	executor WorkflowExecutor,
 	logger logger.Logger,
 ) *WorkflowService {
 	return &WorkflowService{
 		workflowRepo:  workflowRepo,
 		executionRepo: executionRepo,
+		executor:      executor,
 		logger:        logger,
 	}
 }
@@ -33,13 +43,13 @@ func (s *WorkflowService) CreateWorkflow(ctx context.Context, tenantID string, r
 	s.logger.InfoContext(ctx, "Creating workflow",
 		logger.String("tenant_id", tenantID),
 		logger.String("name", req.Name))
-	// TODO: Check if workflow with same trigger identifier already exists
-	// Note: GetByTriggerIdentifier not available in current interface
-	// For now, skip duplicate check - can be added when interface is updated
+	if existing, err := s.workflowRepo.GetByTriggerIdentifier(ctx, tenantID, req.Trigger.Identifier); err == nil && existing != nil {
+		return nil, domain.ErrWorkflowAlreadyExists
+	}
 
 	// Create new workflow
 	workflow := domain.NewWorkflow(tenantID, req.Name, req.Description)
-	
+
 	// Set trigger
 	workflow.Trigger = domain.WorkflowTrigger{
 		Type:       req.Trigger.Type,
@@ -49,20 +59,12 @@ func (s *WorkflowService) CreateWorkflow(ctx context.Context, tenantID string, r
 
 	// Add steps
 	for _, stepDTO := range req.Steps {
-		conditions := make([]domain.Condition, len(stepDTO.Conditions))
-		for i, condDTO := range stepDTO.Conditions {
-			conditions[i] = domain.Condition{
-				Field:    condDTO.Field,
-				Operator: condDTO.Operator,
-				Value:    condDTO.Value,
-			}
-		}
-
 		step := domain.WorkflowStep{
+			ID:         stepDTO.ID,
 			Type:       domain.StepType(stepDTO.Type),
 			Name:       stepDTO.Name,
 			Config:     stepDTO.Config,
-			Conditions: conditions,
+			Conditions: dtos.ToDomainConditions(stepDTO.Conditions),
 			NextSteps:  stepDTO.NextSteps,
 			Enabled:    stepDTO.Enabled,
 		}
@@ -84,14 +86,11 @@ func (s *WorkflowService) CreateWorkflow(ctx context.Context, tenantID string, r
 }
 
 func (s *WorkflowService) GetWorkflow(ctx context.Context, tenantID, workflowID string) (*dtos.WorkflowResponse, error) {
-	workflow, err := s.workflowRepo.GetWorkflowByID(ctx, workflowID)
+	workflow, err := s.getTenantWorkflow(ctx, tenantID, workflowID)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Add tenant validation - ensure workflow belongs to tenant
-	// For now, trusting that workflowID is unique across tenants
-
 	return dtos.ToWorkflowResponse(workflow), nil
 }
 
@@ -99,12 +98,16 @@ func (s *WorkflowService) UpdateWorkflow(ctx context.Context, tenantID, workflow
 	s.logger.InfoContext(ctx, "Updating workflow",
 		logger.String("tenant_id", tenantID),
 		logger.String("workflow_id", workflowID))
-	workflow, err := s.workflowRepo.GetWorkflowByID(ctx, workflowID)
+	workflow, err := s.getTenantWorkflow(ctx, tenantID, workflowID)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Add tenant validation - ensure workflow belongs to tenant
+	if workflow.Trigger.Identifier != req.Trigger.Identifier {
+		if existing, err := s.workflowRepo.GetByTriggerIdentifier(ctx, tenantID, req.Trigger.Identifier); err == nil && existing != nil && existing.ID != workflow.ID {
+			return nil, domain.ErrWorkflowAlreadyExists
+		}
+	}
 
 	// Update workflow fields
 	workflow.Name = req.Name
@@ -118,20 +121,12 @@ func (s *WorkflowService) UpdateWorkflow(ctx context.Context, tenantID, workflow
 	// Clear and rebuild steps
 	workflow.Steps = []domain.WorkflowStep{}
 	for _, stepDTO := range req.Steps {
-		conditions := make([]domain.Condition, len(stepDTO.Conditions))
-		for i, condDTO := range stepDTO.Conditions {
-			conditions[i] = domain.Condition{
-				Field:    condDTO.Field,
-				Operator: condDTO.Operator,
-				Value:    condDTO.Value,
-			}
-		}
-
 		step := domain.WorkflowStep{
+			ID:         stepDTO.ID,
 			Type:       domain.StepType(stepDTO.Type),
 			Name:       stepDTO.Name,
 			Config:     stepDTO.Config,
-			Conditions: conditions,
+			Conditions: dtos.ToDomainConditions(stepDTO.Conditions),
 			NextSteps:  stepDTO.NextSteps,
 			Enabled:    stepDTO.Enabled,
 		}
@@ -157,6 +152,10 @@ func (s *WorkflowService) DeleteWorkflow(ctx context.Context, tenantID, workflow
 	s.logger.InfoContext(ctx, "Deleting workflow",
 		logger.String("tenant_id", tenantID),
 		logger.String("workflow_id", workflowID))
+	if _, err := s.getTenantWorkflow(ctx, tenantID, workflowID); err != nil {
+		return err
+	}
+
 	if err := s.workflowRepo.DeleteWorkflow(ctx, workflowID); err != nil {
 		s.logger.Error("Failed to delete workflow",
 			logger.String("tenant_id", tenantID),
@@ -165,8 +164,6 @@ func (s *WorkflowService) DeleteWorkflow(ctx context.Context, tenantID, workflow
 		return fmt.Errorf("failed to delete workflow: %w", err)
 	}
 
-	// TODO: Add tenant validation - ensure workflow belongs to tenant before deletion
-
 	s.logger.InfoContext(ctx, "Workflow deleted successfully",
 		logger.String("tenant_id", tenantID),
 		logger.String("workflow_id", workflowID))
@@ -175,39 +172,31 @@ func (s *WorkflowService) DeleteWorkflow(ctx context.Context, tenantID, workflow
 }
 
 func (s *WorkflowService) ListWorkflows(ctx context.Context, tenantID string, req *dtos.ListWorkflowsRequest) (*dtos.ListWorkflowsResponse, error) {
-	// Note: Current interface doesn't support tenant-specific or filtered queries
-	// Using basic ListWorkflows method and filtering in memory for now
-	// TODO: Update interface to support tenant filtering and search parameters
-	
-	workflows, _, err := s.workflowRepo.ListWorkflows(ctx, req.Limit, req.Offset)
+	filters := repos.WorkflowFilters{
+		Status: req.Status,
+		Search: req.Search,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+
+	workflows, err := s.workflowRepo.List(ctx, tenantID, filters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list workflows: %w", err)
 	}
 
-	// Filter workflows by tenant (in-memory filtering as temporary solution)
-	var filteredWorkflows []*domain.Workflow
-	for _, workflow := range workflows {
-		if workflow.TenantID == tenantID {
-			// Apply search filter if provided
-			if req.Search == "" || 
-			   strings.Contains(strings.ToLower(workflow.Name), strings.ToLower(req.Search)) ||
-			   strings.Contains(strings.ToLower(workflow.Description), strings.ToLower(req.Search)) {
-				// Apply status filter if provided
-				if req.Status == "" || string(workflow.Status) == req.Status {
-					filteredWorkflows = append(filteredWorkflows, workflow)
-				}
-			}
-		}
+	total, err := s.workflowRepo.CountWithFilters(ctx, tenantID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count workflows: %w", err)
 	}
 
 	response := &dtos.ListWorkflowsResponse{
-		Workflows: make([]dtos.WorkflowResponse, len(filteredWorkflows)),
-		Total:     int64(len(filteredWorkflows)), // Note: This is filtered count, not total count
+		Workflows: make([]dtos.WorkflowResponse, len(workflows)),
+		Total:     total,
 		Limit:     req.Limit,
 		Offset:    req.Offset,
 	}
 
-	for i, workflow := range filteredWorkflows {
+	for i, workflow := range workflows {
 		response.Workflows[i] = *dtos.ToWorkflowResponse(workflow)
 	}
 
@@ -219,12 +208,11 @@ func (s *WorkflowService) ActivateWorkflow(ctx context.Context, tenantID, workfl
 		logger.String("tenant_id", tenantID),
 		logger.String("workflow_id", workflowID))
 
-	workflow, err := s.workflowRepo.GetWorkflowByID(ctx, workflowID)
+	workflow, err := s.getTenantWorkflow(ctx, tenantID, workflowID)
 	if err != nil {
 		return err
 	}
 
-	// TODO: Add tenant validation - ensure workflow belongs to tenant
 	if err := workflow.Activate(); err != nil {
 		return err
 	}
@@ -250,13 +238,11 @@ func (s *WorkflowService) PauseWorkflow(ctx context.Context, tenantID, workflowI
 		logger.String("tenant_id", tenantID),
 		logger.String("workflow_id", workflowID))
 
-	workflow, err := s.workflowRepo.GetWorkflowByID(ctx, workflowID)
+	workflow, err := s.getTenantWorkflow(ctx, tenantID, workflowID)
 	if err != nil {
 		return err
 	}
 
-	// TODO: Add tenant validation - ensure workflow belongs to tenant
-
 	workflow.Pause()
 
 	_, err = s.workflowRepo.UpdateWorkflow(ctx, workflow)
@@ -279,12 +265,89 @@ func (s *WorkflowService) TriggerWorkflow(ctx context.Context, tenantID string,
 	s.logger.InfoContext(ctx, "Triggering workflow",
 		logger.String("tenant_id", tenantID),
 		logger.String("trigger_identifier", req.TriggerIdentifier))
-	
-	// TODO: Find workflow by trigger identifier
-	// Current interface doesn't support GetByTriggerIdentifier
-	// For now, return an error - this method needs interface update to work properly
-	s.logger.Error("TriggerWorkflow not fully implemented - GetByTriggerIdentifier not available in current interface",
+
+	workflow, err := s.workflowRepo.GetByTriggerIdentifier(ctx, tenantID, req.TriggerIdentifier)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrTriggerNotFound
+		}
+		return nil, fmt.Errorf("failed to get workflow by trigger: %w", err)
+	}
+
+	return s.startExecution(ctx, workflow, req)
+}
+
+// TriggerWorkflowByID starts an execution of a specific workflow
+func (s *WorkflowService) TriggerWorkflowByID(ctx context.Context, tenantID, workflowID string, req *dtos.TriggerWorkflowRequest) (*dtos.WorkflowExecutionResponse, error) {
+	s.logger.InfoContext(ctx, "Triggering workflow",
 		logger.String("tenant_id", tenantID),
-		logger.String("trigger_identifier", req.TriggerIdentifier))
-	return nil, fmt.Errorf("trigger workflow not implemented: GetByTriggerIdentifier method not available in repository interface")
+		logger.String("workflow_id", workflowID))
+
+	workflow, err := s.getTenantWorkflow(ctx, tenantID, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startExecution(ctx, workflow, req)
+}
+
+// startExecution hands a new execution of an active workflow to the executor
+func (s *WorkflowService) startExecution(ctx context.Context, workflow *domain.Workflow, req *dtos.TriggerWorkflowRequest) (*dtos.WorkflowExecutionResponse, error) {
+	if !workflow.IsActive() {
+		return nil, domain.ErrWorkflowInactive
+	}
+
+	payload, err := json.Marshal(req.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPayload, err)
+	}
+
+	execCtx := domain.ExecutionContext{
+		UserID:     req.Context.UserID,
+		Subscriber: req.Context.Subscriber,
+		Variables:  req.Context.Variables,
+		Metadata:   req.Context.Metadata,
+	}
+
+	var execution *domain.WorkflowExecution
+	if s.executor != nil {
+		execution, err = s.executor.TriggerWorkflow(ctx, workflow.ID.String(), workflow.Trigger.Identifier, payload, execCtx)
+		if err != nil {
+			s.logger.Error("Failed to trigger workflow",
+				logger.String("tenant_id", workflow.TenantID),
+				logger.String("workflow_id", workflow.ID.String()),
+				logger.Err(err))
+			return nil, fmt.Errorf("failed to trigger workflow: %w", err)
+		}
+	} else {
+		// Without an executor the pending execution is picked up by the engine's poller
+		execution = domain.NewWorkflowExecution(workflow.ID, workflow.TenantID, workflow.Trigger.Identifier, payload, execCtx)
+		if err := s.executionRepo.CreateExecution(ctx, execution, nil); err != nil {
+			return nil, fmt.Errorf("failed to create execution: %w", err)
+		}
+	}
+
+	s.logger.InfoContext(ctx, "Workflow triggered successfully",
+		logger.String("tenant_id", workflow.TenantID),
+		logger.String("workflow_id", workflow.ID.String()),
+		logger.String("execution_id", execution.ID.String()))
+
+	return dtos.ToExecutionResponse(execution), nil
+}
+
+// getTenantWorkflow loads a workflow and ensures it belongs to the tenant
+func (s *WorkflowService) getTenantWorkflow(ctx context.Context, tenantID, workflowID string) (*domain.Workflow, error) {
+	workflow, err := s.workflowRepo.GetWorkflowByID(ctx, workflowID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrWorkflowNotFound
+		}
+		return nil, err
+	}
+
+	if workflow.TenantID != tenantID {
+		return nil, domain.ErrWorkflowNotFound
+	}
+
+	return workflow, nil
 }
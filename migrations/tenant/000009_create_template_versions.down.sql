@@ -0,0 +1,7 @@
+ALTER TABLE notifications DROP COLUMN IF EXISTS template_version;
+
+ALTER TABLE templates DROP COLUMN IF EXISTS published_version;
+ALTER TABLE templates DROP COLUMN IF EXISTS draft_version;
+
+DROP INDEX IF EXISTS idx_template_versions_template_id;
+DROP TABLE IF EXISTS template_versions;
@@ -0,0 +1,22 @@
+-- Immutable template versions; templates keep pointers to the draft and published versions
+CREATE TABLE template_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    template_id UUID NOT NULL REFERENCES templates(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    content TEXT NOT NULL,
+    variables JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (template_id, version)
+);
+
+CREATE INDEX idx_template_versions_template_id ON template_versions(template_id);
+
+ALTER TABLE templates ADD COLUMN draft_version INT NOT NULL DEFAULT 1;
+ALTER TABLE templates ADD COLUMN published_version INT NOT NULL DEFAULT 1;
+
+-- Existing templates become version 1, published
+INSERT INTO template_versions (template_id, version, content, variables)
+SELECT id, 1, content, variables FROM templates;
+
+-- Record which template version rendered each notification
+ALTER TABLE notifications ADD COLUMN template_version INT;
@@ -0,0 +1,2 @@
+ALTER TABLE template_versions DROP COLUMN IF EXISTS variants;
+ALTER TABLE templates DROP COLUMN IF EXISTS variants;
@@ -0,0 +1,3 @@
+-- Channel-specific content variants keyed by channel type
+ALTER TABLE templates ADD COLUMN variants JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE template_versions ADD COLUMN variants JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,7 @@
+ALTER TABLE notifications DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE template_versions DROP COLUMN IF EXISTS translations;
+ALTER TABLE templates DROP COLUMN IF EXISTS translations;
+
+ALTER TABLE tenant_preferences DROP COLUMN IF EXISTS default_locale;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
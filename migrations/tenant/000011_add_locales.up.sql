@@ -0,0 +1,10 @@
+-- User and tenant default locales
+ALTER TABLE users ADD COLUMN locale VARCHAR(35) NOT NULL DEFAULT '';
+ALTER TABLE tenant_preferences ADD COLUMN default_locale VARCHAR(35) NOT NULL DEFAULT '';
+
+-- Template translations keyed by locale
+ALTER TABLE templates ADD COLUMN translations JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE template_versions ADD COLUMN translations JSONB NOT NULL DEFAULT '{}';
+
+-- Record the locale each notification was rendered in
+ALTER TABLE notifications ADD COLUMN locale VARCHAR(35);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_notification_attempts_provider_message;
+DROP INDEX IF EXISTS idx_notification_attempts_notification;
+DROP TABLE IF EXISTS notification_attempts;
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS created_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,19 @@
+-- The repository has always written tenant_id, but the column was never created
+ALTER TABLE notifications ADD COLUMN tenant_id VARCHAR(36) NOT NULL DEFAULT '';
+ALTER TABLE notifications ADD COLUMN created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE notifications ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- One row per status transition, with the provider that handled it
+CREATE TABLE notification_attempts (
+    id UUID PRIMARY KEY,
+    notification_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    provider_id UUID,
+    provider_message_id VARCHAR(255),
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (notification_id) REFERENCES notifications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_notification_attempts_notification ON notification_attempts(notification_id, created_at);
+CREATE INDEX idx_notification_attempts_provider_message ON notification_attempts(provider_message_id);
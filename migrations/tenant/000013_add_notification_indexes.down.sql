@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_notifications_provider_created;
+DROP INDEX IF EXISTS idx_notifications_template_created;
+DROP INDEX IF EXISTS idx_notifications_channel_created;
+DROP INDEX IF EXISTS idx_notifications_status_created;
+DROP INDEX IF EXISTS idx_notifications_user_created;
+DROP INDEX IF EXISTS idx_notifications_created;
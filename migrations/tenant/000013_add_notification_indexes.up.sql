@@ -0,0 +1,8 @@
+-- Listing is newest first with a (created_at, id) cursor; each filter gets a
+-- matching index so paging stays an index range scan
+CREATE INDEX idx_notifications_created ON notifications(created_at DESC, id DESC);
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at DESC, id DESC);
+CREATE INDEX idx_notifications_status_created ON notifications(status, created_at DESC, id DESC);
+CREATE INDEX idx_notifications_channel_created ON notifications(channel, created_at DESC, id DESC);
+CREATE INDEX idx_notifications_template_created ON notifications(template_id, created_at DESC, id DESC);
+CREATE INDEX idx_notifications_provider_created ON notifications(provider_id, created_at DESC, id DESC);
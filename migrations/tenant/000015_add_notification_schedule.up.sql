@@ -0,0 +1,5 @@
+-- Notifications sent for a future time wait as scheduled until the scheduler
+-- releases them, so the schedule survives restarts
+ALTER TABLE notifications ADD COLUMN scheduled_for TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_notifications_status_scheduled ON notifications(status, scheduled_for);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notification_batch_recipients_status;
+DROP TABLE IF EXISTS notification_batch_recipients;
+DROP TABLE IF EXISTS notification_batches;
@@ -0,0 +1,27 @@
+-- A batch sends one notification to many recipients; each recipient row
+-- records the notification created for it and how the send went
+CREATE TABLE notification_batches (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(36) NOT NULL,
+    type VARCHAR(50),
+    channel VARCHAR(50) NOT NULL,
+    template_id UUID,
+    total_recipients INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE notification_batch_recipients (
+    batch_id UUID NOT NULL,
+    position INTEGER NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    variables JSONB,
+    notification_id UUID,
+    status VARCHAR(50) NOT NULL,
+    error TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (batch_id, position),
+    FOREIGN KEY (batch_id) REFERENCES notification_batches(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_notification_batch_recipients_status ON notification_batch_recipients(batch_id, status);
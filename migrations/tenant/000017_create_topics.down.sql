@@ -0,0 +1,4 @@
+ALTER TABLE notification_batches DROP COLUMN IF EXISTS topic_id;
+DROP INDEX IF EXISTS idx_topic_subscriptions_user;
+DROP TABLE IF EXISTS topic_subscriptions;
+DROP TABLE IF EXISTS topics;
@@ -0,0 +1,23 @@
+-- Topics are named groups of users, such as the admins of an account, that a
+-- notification can be sent to in one request
+CREATE TABLE topics (
+    id UUID PRIMARY KEY,
+    topic_key VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE topic_subscriptions (
+    topic_id UUID NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (topic_id, user_id),
+    FOREIGN KEY (topic_id) REFERENCES topics(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_topic_subscriptions_user ON topic_subscriptions(user_id);
+
+-- Batches started by a topic send keep the topic they expanded
+ALTER TABLE notification_batches ADD COLUMN topic_id UUID;
@@ -0,0 +1,2 @@
+ALTER TABLE notifications DROP COLUMN IF EXISTS override_preferences;
+ALTER TABLE notifications DROP COLUMN IF EXISTS category;
@@ -0,0 +1,4 @@
+-- Preferences are checked when a notification is dispatched, which for
+-- scheduled sends is long after the request, so the inputs are stored
+ALTER TABLE notifications ADD COLUMN category VARCHAR(100);
+ALTER TABLE notifications ADD COLUMN override_preferences BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,7 @@
+ALTER TABLE notifications DROP COLUMN IF EXISTS urgent;
+
+ALTER TABLE tenant_preferences DROP COLUMN IF EXISTS quiet_hours;
+ALTER TABLE tenant_preferences DROP COLUMN IF EXISTS timezone;
+
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS quiet_hours;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS timezone;
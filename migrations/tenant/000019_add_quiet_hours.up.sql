@@ -0,0 +1,10 @@
+-- Per-user timezone and quiet hours; an empty timezone or NULL window falls
+-- back to the tenant default
+ALTER TABLE user_preferences ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE user_preferences ADD COLUMN quiet_hours JSONB;
+
+ALTER TABLE tenant_preferences ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE tenant_preferences ADD COLUMN quiet_hours JSONB NOT NULL DEFAULT '{"enabled": false, "start": "", "end": ""}';
+
+-- Urgent notifications are delivered during quiet hours instead of deferred
+ALTER TABLE notifications ADD COLUMN urgent BOOLEAN NOT NULL DEFAULT FALSE;
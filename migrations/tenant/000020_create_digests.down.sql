@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS digest_events;
+DROP TABLE IF EXISTS digests;
@@ -0,0 +1,31 @@
+-- A digest collects a user's events for a key until its window ends and is
+-- then sent as one notification listing them
+CREATE TABLE digests (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    digest_key VARCHAR(255) NOT NULL,
+    channel VARCHAR(50) NOT NULL,
+    template_id UUID NOT NULL,
+    category VARCHAR(100),
+    status VARCHAR(50) NOT NULL,
+    send_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    event_count INTEGER NOT NULL DEFAULT 0,
+    notification_id UUID,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One open digest per user and key; events arriving after it closes open the next one
+CREATE UNIQUE INDEX idx_digests_open_key ON digests(user_id, digest_key) WHERE status = 'open';
+CREATE INDEX idx_digests_status_send_at ON digests(status, send_at);
+
+CREATE TABLE digest_events (
+    id UUID PRIMARY KEY,
+    digest_id UUID NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (digest_id) REFERENCES digests(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_digest_events_digest ON digest_events(digest_id, created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS frequency_counters;
+
+ALTER TABLE tenant_preferences DROP COLUMN IF EXISTS frequency_caps;
@@ -0,0 +1,13 @@
+-- Tenant-wide per-user send limits; see FrequencyCap
+ALTER TABLE tenant_preferences ADD COLUMN frequency_caps JSONB NOT NULL DEFAULT '[]';
+
+-- Sends counted per user, cap and fixed window. Counters are reserved with a
+-- conditional update so every API instance sharing the tenant database sees
+-- the same counts.
+CREATE TABLE frequency_counters (
+    user_id VARCHAR(255) NOT NULL,
+    cap_key VARCHAR(255) NOT NULL,
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, cap_key, window_start)
+);
@@ -0,0 +1,13 @@
+-- Browser push subscriptions; a user has one per browser or device
+CREATE TABLE web_push_subscriptions (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    endpoint TEXT NOT NULL UNIQUE,
+    p256dh VARCHAR(255) NOT NULL,
+    auth VARCHAR(255) NOT NULL,
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_web_push_subscriptions_user ON web_push_subscriptions(user_id, created_at);
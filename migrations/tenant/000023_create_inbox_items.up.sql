@@ -0,0 +1,20 @@
+-- In-app messages delivered to a user's inbox
+CREATE TABLE inbox_items (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    notification_id UUID,
+    category VARCHAR(255),
+    title TEXT,
+    body TEXT NOT NULL,
+    data JSONB,
+    read_at TIMESTAMP WITH TIME ZONE,
+    archived_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The feed pages through a user's items newest first
+CREATE INDEX idx_inbox_items_user_created ON inbox_items(user_id, created_at DESC, id DESC);
+-- Unread counts only touch unread items that are still in the feed
+CREATE INDEX idx_inbox_items_user_unread ON inbox_items(user_id) WHERE read_at IS NULL AND archived_at IS NULL;
+-- Items are looked up by the notification that delivered them
+CREATE INDEX idx_inbox_items_notification ON inbox_items(notification_id) WHERE notification_id IS NOT NULL;
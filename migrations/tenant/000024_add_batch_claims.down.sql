@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notification_batch_recipients_claim;
+ALTER TABLE notification_batch_recipients DROP COLUMN IF EXISTS claimed_until;
+ALTER TABLE notification_batches DROP COLUMN IF EXISTS request;
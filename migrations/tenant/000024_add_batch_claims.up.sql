@@ -0,0 +1,7 @@
+-- Batch sends are driven from these tables so they survive restarts: a batch
+-- keeps the request its recipients are sent with, and a recipient is claimed
+-- by a worker until claimed_until while it is being sent
+ALTER TABLE notification_batches ADD COLUMN request JSONB;
+ALTER TABLE notification_batch_recipients ADD COLUMN claimed_until TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_notification_batch_recipients_claim ON notification_batch_recipients(status, claimed_until);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_notifications_claim;
+ALTER TABLE notifications DROP COLUMN IF EXISTS claimed_until;
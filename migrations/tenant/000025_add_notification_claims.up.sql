@@ -0,0 +1,5 @@
+-- A scheduled notification is claimed until claimed_until while it is released;
+-- one still pending after that was interrupted and is released again
+ALTER TABLE notifications ADD COLUMN claimed_until TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_notifications_claim ON notifications(status, claimed_until);
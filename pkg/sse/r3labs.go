@@ -10,8 +10,14 @@ type R3LabsServer struct {
 	srv *sse.Server
 }
 
+// New creates a server that opens a channel's stream when its first client
+// connects. Events published while nobody is connected are dropped and not
+// replayed, so clients load current state before they subscribe.
 func New() *R3LabsServer {
-	return &R3LabsServer{srv: sse.New()}
+	srv := sse.New()
+	srv.AutoStream = true
+	srv.AutoReplay = false
+	return &R3LabsServer{srv: srv}
 }
 
 func (s *R3LabsServer) ServeHTTP(w http.ResponseWriter, r *http.Request, channel string) {
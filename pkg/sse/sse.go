@@ -5,8 +5,13 @@ import (
 )
 
 type Server interface {
+	Publisher
 	// ServeHTTP streams events for a given channel (e.g., tenant or user)
 	ServeHTTP(w http.ResponseWriter, r *http.Request, channel string)
+}
+
+// Publisher publishes events to channels without serving them
+type Publisher interface {
 	// Publish sends an event to the specified channel
 	Publish(channel string, data []byte) error
 }